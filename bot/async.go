@@ -1,12 +1,14 @@
 package bot
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/diamondburned/arikawa/v3/api"
 	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
 	"github.com/jxsl13/twstatus-bot/model"
 )
 
@@ -120,7 +122,7 @@ func (b *Bot) updateChannelNotification(n model.PlayerCountNotificationMessage)
 	}
 
 	// remove all requests from database
-	for _, umt := range n.RemoveUserMessageReactions {
+	for _, umt := range n.RemoveRequests {
 		err = dao.RemovePlayerCountNotificationRequest(b.ctx, model.PlayerCountNotificationRequest{
 			MessageUserTarget: model.MessageUserTarget{
 				UserID: umt.UserID,
@@ -139,27 +141,24 @@ func (b *Bot) updateChannelNotification(n model.PlayerCountNotificationMessage)
 		}
 	}
 
-	// delete all reactions from specified messages
-	for _, mt := range n.RemoveMessageReactions {
-		err = b.state.DeleteReactions(n.ChannelID, mt.MessageID, mt.Reaction())
-		if err != nil && !ErrIsNotFound(err) {
-			b.l.Errorf("failed to delete reaction %s from message %s: %v", mt.Reaction(), n.MessageTarget(mt.MessageID), err)
-			err = nil
-		}
-	}
-
 	mentionUsers := n.UserIDs
 	if len(n.UserIDs) > 100 {
 		// we do not expect more than 100 users to be mentioned anyway
 		mentionUsers = n.UserIDs[:100]
 	}
 
+	mentionRoles := n.RoleIDs
+	if len(n.RoleIDs) > 100 {
+		mentionRoles = n.RoleIDs[:100]
+	}
+
 	// send new message
 	msg, err := b.state.SendMessageComplex(n.ChannelTarget.ChannelID, api.SendMessageData{
-		Content: n.Format(),
+		Content: n.Format(b.guildLocalizer(b.ctx, n.GuildID)),
 		Flags:   discord.SuppressEmbeds,
 		AllowedMentions: &api.AllowedMentions{
 			Users: mentionUsers,
+			Roles: mentionRoles,
 		},
 	})
 	if err != nil {
@@ -172,6 +171,147 @@ func (b *Bot) updateChannelNotification(n model.PlayerCountNotificationMessage)
 		return err
 	}
 
+	// role notifications are persistent, the cooldown starts with this notification
+	for _, mr := range n.NotifiedRoles {
+		err = dao.MarkPlayerCountRoleNotified(b.ctx, n.ChannelTarget, mr)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *Bot) alertUpdater(id int) {
+	log.Printf("goroutine %d starting async goroutine for server status alerts", id)
+
+loop:
+	for {
+		select {
+		case <-b.ctx.Done():
+			break loop
+		case alert, ok := <-b.a:
+			if !ok {
+				break loop
+			}
+			err := b.sendServerStatusAlert(alert)
+			if err != nil {
+				b.l.Errorf("goroutine %0d: failed to send server status alert for %s: %v", id, alert.Address, err)
+			}
+		}
+	}
+
+	log.Printf("goroutine %d: closed async goroutine for server status alerts", id)
+}
+
+func (b *Bot) sendServerStatusAlert(a model.ServerStatusAlert) (err error) {
+	data := api.SendMessageData{
+		Embeds:          []discord.Embed{a.ToEmbed(b.guildLocalizer(b.ctx, a.GuildID))},
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+
+	var errs []error
+	if a.AlertChannelID != 0 {
+		_, err = b.state.SendMessageComplex(a.AlertChannelID, data)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to send alert to channel %s: %w", a.AlertChannelID, err))
+		}
+	}
+
+	if a.AlertUserID != 0 {
+		err = b.sendDirectMessage(a.AlertUserID, data)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (b *Bot) directMessageUpdater(id int) {
+	log.Printf("goroutine %d starting async goroutine for direct messages", id)
+
+loop:
+	for {
+		select {
+		case <-b.ctx.Done():
+			break loop
+		case dm, ok := <-b.dm:
+			if !ok {
+				break loop
+			}
+			err := b.sendDirectMessage(dm.UserID, api.SendMessageData{
+				Content:         dm.Content,
+				AllowedMentions: &api.AllowedMentions{ /* none */ },
+			})
+			if err != nil {
+				b.l.Errorf("goroutine %0d: %v", id, err)
+			}
+		}
+	}
+
+	log.Printf("goroutine %d: closed async goroutine for direct messages", id)
+}
+
+func (b *Bot) webhookUpdater(id int) {
+	log.Printf("goroutine %d starting async goroutine for webhooks", id)
+
+loop:
+	for {
+		select {
+		case <-b.ctx.Done():
+			break loop
+		case e, ok := <-b.w:
+			if !ok {
+				break loop
+			}
+			err := b.deliverWebhookEvent(e)
+			if err != nil {
+				b.l.Errorf("goroutine %0d: failed to deliver webhook event %s to %s: %v", id, e.Event.Type, e.Webhook.URL, err)
+			}
+		}
+	}
+
+	log.Printf("goroutine %d: closed async goroutine for webhooks", id)
+}
+
+func (b *Bot) deliverWebhookEvent(e model.WebhookEvent) error {
+	result, sendErr := b.webhooks.Send(b.ctx, e.Webhook.URL, e.Webhook.Secret, string(e.Event.Type), e.Event)
+
+	delivery := model.WebhookDelivery{
+		WebhookID:  e.Webhook.ID,
+		Event:      e.Event.Type,
+		StatusCode: result.StatusCode,
+		Attempts:   result.Attempts,
+	}
+	if sendErr != nil {
+		delivery.Error = sendErr.Error()
+	}
+
+	dao, closer, err := b.ConnDAO(b.ctx)
+	if err != nil {
+		return errors.Join(sendErr, err)
+	}
+	defer closer()
+
+	err = dao.AddWebhookDelivery(b.ctx, delivery)
+	if err != nil {
+		return errors.Join(sendErr, err)
+	}
+	// delivery failures are part of the delivery log and visible via /list-webhooks
+	return nil
+}
+
+// sendDirectMessage sends a private message to the given user.
+func (b *Bot) sendDirectMessage(userID discord.UserID, data api.SendMessageData) error {
+	dm, err := b.state.CreatePrivateChannel(userID)
+	if err != nil {
+		return fmt.Errorf("failed to create direct message channel for user %s: %w", userID, err)
+	}
+
+	_, err = b.state.SendMessageComplex(dm.ID, data)
+	if err != nil {
+		return fmt.Errorf("failed to send direct message to user %s: %w", userID, err)
+	}
 	return nil
 }
 
@@ -190,6 +330,13 @@ func (b *Bot) cacheCleanup(id int) {
 			// do something
 			resetTimer(timer, cleanupInterval, &drained)
 
+			b.searches.Range(func(key string, value cachedServerSearch) bool {
+				if time.Now().After(value.Until) {
+					b.searches.Delete(key)
+				}
+				return true
+			})
+
 			size := b.conflictMap.Size()
 			if size == 0 {
 				// nothing to do
@@ -213,3 +360,235 @@ func (b *Bot) cacheCleanup(id int) {
 		}
 	}
 }
+
+func (b *Bot) historyMaintenance(id int) {
+	log.Printf("goroutine %d starting async goroutine for history maintenance", id)
+	var (
+		interval = time.Hour
+		timer    = time.NewTimer(interval)
+		drained  = false
+	)
+	defer closeTimer(timer, &drained)
+
+	// forecasts are based on hourly aggregates, so they only change once per hour
+	err := b.refreshForecasts(time.Now())
+	if err != nil {
+		b.l.Errorf("goroutine %d: failed to refresh forecasts: %v", id, err)
+	}
+	for {
+		select {
+		case <-timer.C:
+			drained = true
+			resetTimer(timer, interval, &drained)
+
+			now := time.Now()
+			err := b.maintainHistory(now)
+			if err != nil {
+				b.l.Errorf("goroutine %d: failed to maintain server history: %v", id, err)
+			}
+
+			err = b.refreshForecasts(now)
+			if err != nil {
+				b.l.Errorf("goroutine %d: failed to refresh forecasts: %v", id, err)
+			}
+		case <-b.ctx.Done():
+			log.Printf("goroutine %d: closed async goroutine for history maintenance", id)
+			return
+		}
+	}
+}
+
+func (b *Bot) maintainHistory(now time.Time) (err error) {
+	dao, closer, err := b.TxDAO(b.ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = closer(err)
+	}()
+
+	err = dao.RollupServerHistory(b.ctx, now)
+	if err != nil {
+		return err
+	}
+
+	err = dao.PruneServerHistory(b.ctx, now, b.history.Retention, b.history.HourlyRetention)
+	if err != nil {
+		return err
+	}
+
+	err = dao.PrunePlayerSessions(b.ctx, now, b.history.SessionRetention)
+	if err != nil {
+		return err
+	}
+
+	err = dao.PruneMapSessions(b.ctx, now, b.history.SessionRetention)
+	if err != nil {
+		return err
+	}
+
+	return dao.PruneKnownServers(b.ctx, now, b.history.DiscoveryRetention)
+}
+
+// refreshForecasts trains the forecast models of all tracked servers that are used for the hints in status messages.
+func (b *Bot) refreshForecasts(now time.Time) error {
+	if !b.history.ForecastHint {
+		return nil
+	}
+
+	dao, closer, err := b.ConnDAO(b.ctx)
+	if err != nil {
+		return err
+	}
+	defer closer()
+
+	trackings, err := dao.ListAllTrackings(b.ctx)
+	if err != nil {
+		return err
+	}
+	addresses := make([]string, 0, len(trackings))
+	seen := make(map[string]bool, len(trackings))
+	for _, t := range trackings {
+		if seen[t.Address] {
+			continue
+		}
+		seen[t.Address] = true
+		addresses = append(addresses, t.Address)
+	}
+
+	models, err := dao.GetForecastModels(b.ctx, addresses, now)
+	if err != nil {
+		return err
+	}
+
+	b.forecasts.Clear()
+	for address, m := range models {
+		b.forecasts.Store(address, m)
+	}
+	return nil
+}
+
+func (b *Bot) weeklyHeatmapPoster(id int) {
+	log.Printf("goroutine %d starting async goroutine for weekly heatmaps", id)
+	var (
+		interval = 15 * time.Minute
+		timer    = time.NewTimer(interval)
+		drained  = false
+	)
+	defer closeTimer(timer, &drained)
+	for {
+		select {
+		case <-timer.C:
+			drained = true
+			resetTimer(timer, interval, &drained)
+
+			err := b.postWeeklyHeatmaps(time.Now())
+			if err != nil {
+				b.l.Errorf("goroutine %d: failed to post weekly heatmaps: %v", id, err)
+			}
+		case <-b.ctx.Done():
+			log.Printf("goroutine %d: closed async goroutine for weekly heatmaps", id)
+			return
+		}
+	}
+}
+
+// postWeeklyHeatmaps posts all heatmaps that were not posted since the start of the current week.
+// Heatmaps that cannot be posted are attempted again after a backoff.
+func (b *Bot) postWeeklyHeatmaps(now time.Time) (err error) {
+	dao, closer, err := b.ConnDAO(b.ctx)
+	if err != nil {
+		return err
+	}
+	defer closer()
+
+	heatmaps, err := dao.ListDueWeeklyHeatmaps(b.ctx, now)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, w := range heatmaps {
+		ctx := i18n.NewContext(b.ctx, b.guildLocalizer(b.ctx, w.GuildID))
+		content, files, err := renderHeatmap(ctx, dao, w, now)
+		if err == nil {
+			_, err = b.state.SendMessageComplex(w.PostChannelID, api.SendMessageData{
+				Content:         content,
+				Files:           files,
+				AllowedMentions: &api.AllowedMentions{ /* none */ },
+			})
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to post weekly heatmap of %s to channel %s: %w", w.Subject(i18n.English), w.PostChannelID, err))
+			err = dao.MarkWeeklyHeatmapFailed(b.ctx, w, now)
+		} else {
+			err = dao.MarkWeeklyHeatmapPosted(b.ctx, w, now)
+		}
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (b *Bot) weeklyDigestPoster(id int) {
+	log.Printf("goroutine %d starting async goroutine for weekly digests", id)
+	var (
+		interval = time.Minute
+		timer    = time.NewTimer(interval)
+		drained  = false
+	)
+	defer closeTimer(timer, &drained)
+	for {
+		select {
+		case <-timer.C:
+			drained = true
+			resetTimer(timer, interval, &drained)
+
+			err := b.postWeeklyDigests(time.Now())
+			if err != nil {
+				b.l.Errorf("goroutine %d: failed to post weekly digests: %v", id, err)
+			}
+		case <-b.ctx.Done():
+			log.Printf("goroutine %d: closed async goroutine for weekly digests", id)
+			return
+		}
+	}
+}
+
+// postWeeklyDigests posts all digests that were not posted since their last scheduled point in time.
+// Digests that cannot be posted are attempted again after a backoff.
+func (b *Bot) postWeeklyDigests(now time.Time) (err error) {
+	dao, closer, err := b.ConnDAO(b.ctx)
+	if err != nil {
+		return err
+	}
+	defer closer()
+
+	digests, err := dao.ListDueWeeklyDigests(b.ctx, now)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, d := range digests {
+		ctx := i18n.NewContext(b.ctx, b.guildLocalizer(b.ctx, d.GuildID))
+		content, err := renderDigest(ctx, dao, d, now)
+		if err == nil {
+			_, err = b.state.SendMessageComplex(d.PostChannelID, api.SendMessageData{
+				Content:         content,
+				AllowedMentions: &api.AllowedMentions{ /* none */ },
+			})
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to post weekly digest of channel %s to channel %s: %w", d.ChannelID, d.PostChannelID, err))
+			err = dao.MarkWeeklyDigestFailed(b.ctx, d, now)
+		} else {
+			err = dao.MarkWeeklyDigestPosted(b.ctx, d, now)
+		}
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+	}
+	return errors.Join(errs...)
+}
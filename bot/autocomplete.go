@@ -0,0 +1,72 @@
+package bot
+
+import (
+	"context"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/model"
+)
+
+// hasAutocompletion returns true in case any of the options is autocompleted.
+func hasAutocompletion(options []discord.CommandOption) bool {
+	for _, o := range options {
+		if s, ok := o.(*discord.StringOption); ok && s.Autocomplete {
+			return true
+		}
+	}
+	return false
+}
+
+// autocomplete suggests server addresses for address options and flag abbreviations for abbr options.
+// Address options may contain a comma separated list, in which case only the last address is completed.
+func (b *Bot) autocomplete(ctx context.Context, data cmdroute.AutocompleteData) api.AutocompleteChoices {
+	var focused discord.AutocompleteOption
+	for _, o := range data.Options {
+		if o.Focused {
+			focused = o
+			break
+		}
+	}
+
+	dao, closer, err := b.ConnDAO(ctx)
+	if err != nil {
+		b.l.Errorf("failed to autocomplete %s of /%s: %v", focused.Name, data.Name, err)
+		return api.AutocompleteStringChoices{}
+	}
+	defer closer()
+
+	var (
+		value   = focused.String()
+		prefix  = ""
+		choices []model.AutocompleteChoice
+	)
+	switch focused.Name {
+	case "address":
+		if idx := strings.LastIndex(value, ","); idx >= 0 {
+			prefix, value = value[:idx+1], value[idx+1:]
+		}
+		choices, err = dao.AutocompleteServers(ctx, strings.TrimSpace(value))
+	case "abbr":
+		choices, err = dao.AutocompleteFlags(ctx, strings.TrimSpace(value))
+	}
+	if err != nil {
+		b.l.Errorf("failed to autocomplete %s of /%s: %v", focused.Name, data.Name, err)
+		return api.AutocompleteStringChoices{}
+	}
+
+	result := make(api.AutocompleteStringChoices, 0, len(choices))
+	for _, c := range choices {
+		c, ok := c.WithPrefix(prefix)
+		if !ok {
+			continue
+		}
+		result = append(result, discord.StringChoice{
+			Name:  c.Name,
+			Value: c.Value,
+		})
+	}
+	return result
+}
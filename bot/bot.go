@@ -6,7 +6,7 @@ import (
 	"fmt"
 	"log"
 	"runtime"
-	"sort"
+	"slices"
 	"sync"
 	"time"
 
@@ -18,15 +18,20 @@ import (
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
 	"github.com/jxsl13/twstatus-bot/dao"
 	"github.com/jxsl13/twstatus-bot/db"
+	"github.com/jxsl13/twstatus-bot/i18n"
 	"github.com/jxsl13/twstatus-bot/logging"
 	"github.com/jxsl13/twstatus-bot/model"
 	"github.com/jxsl13/twstatus-bot/sqlc"
-	"github.com/jxsl13/twstatus-bot/utils"
+	"github.com/jxsl13/twstatus-bot/webhook"
 	"github.com/puzpuzpuz/xsync/v3"
 )
 
 const (
 	channelOptionName = "channel"
+
+	webhookRetries      = 3
+	webhookRetryWait    = time.Second
+	webhookRetryMaxWait = 30 * time.Second
 )
 
 var ownerCommandList = []api.CreateCommandData{
@@ -35,196 +40,1024 @@ var ownerCommandList = []api.CreateCommandData{
 		Description: "List all guilds that are allowed to use this bot",
 	},
 	{
-		Name:        "add-guild",
-		Description: "Allow a guild to use this bot",
+		Name:        "add-guild",
+		Description: "Allow a guild to use this bot",
+		Options: []discord.CommandOption{
+			&discord.StringOption{
+				OptionName:  "description",
+				Description: "A description for this guild.",
+				MinLength:   option.NewInt(4),
+				MaxLength:   option.NewInt(256),
+				Required:    true,
+			},
+			&discord.StringOption{
+				OptionName:  "id",
+				Description: "The guild id of the guild you want to add.",
+				MinLength:   option.NewInt(1),
+				MaxLength:   option.NewInt(20),
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:        "remove-guild",
+		Description: "Remove a guild from the allowed guilds",
+		Options: []discord.CommandOption{
+			&discord.StringOption{
+				OptionName:  "id",
+				Description: "The guild id of the guild you want to remove.",
+				MinLength:   option.NewInt(1),
+				MaxLength:   option.NewInt(20),
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:        "update-servers",
+		Description: "Update the server list",
+	},
+	{
+		Name:        "update-messages",
+		Description: "Update all discord messages with server status lists",
+	},
+}
+
+var userCommandList = []api.CreateCommandData{
+	{
+		Name:        "help",
+		Description: "Show this help message",
+	},
+	{
+		Name:           "add-channel",
+		Description:    "Add a channel to the allowed channels",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.ChannelOption{
+				OptionName:  channelOptionName,
+				Description: "The channel id of the channel you want to add.",
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:           "remove-channel",
+		Description:    "Remove a channel from the allowed channels",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.ChannelOption{
+				OptionName:  channelOptionName,
+				Description: "The channel id of the channel you want to remove.",
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:           "list-channels",
+		Description:    "List all channels of the current guild that are registered for this bot",
+		NoDMPermission: true,
+	},
+	{
+		Name:           "list-flag-mappings",
+		Description:    "List all flag mappings for the current or given channel",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.ChannelOption{
+				OptionName:  channelOptionName,
+				Description: "The channel id of the channel you want to list the flag mappings for.",
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:           "add-flag-mapping",
+		Description:    "Add a flag mapping for the current channel",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.StringOption{
+				OptionName:   "abbr",
+				Description:  "The abbreviation of the flag you want to add a different emoji for.",
+				Required:     true,
+				Autocomplete: true,
+				MinLength:    option.NewInt(2),
+				MaxLength:    option.NewInt(7), // len("default")
+			},
+			&discord.StringOption{
+				OptionName:  "emoji",
+				Description: "The emoji you want to use for this flag (any text).",
+				Required:    true,
+				MinLength:   option.NewInt(1), // :X:
+				MaxLength:   option.NewInt(256),
+			},
+			&discord.ChannelOption{
+				OptionName:  channelOptionName,
+				Description: "The channel id of the channel you want to add a flag mapping for.",
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:           "remove-flag-mapping",
+		Description:    "Remove a flag mapping for the current or provided channel",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.StringOption{
+				OptionName:   "abbr",
+				Description:  "The abbreviation of the flag you want to remove a mapping for.",
+				Required:     true,
+				Autocomplete: true,
+				MinLength:    option.NewInt(2),
+				MaxLength:    option.NewInt(7), // len("default")
+			},
+			&discord.ChannelOption{
+				OptionName:  channelOptionName,
+				Description: "The channel id of the channel you want to remove a flag mapping for.",
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:           "list-flags",
+		Description:    "show all known flags",
+		NoDMPermission: true,
+	},
+	{
+		Name:           "add-tracking",
+		Description:    "Add tracking of a Teeworlds server for the current or given channel",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.StringOption{
+				OptionName:   "address",
+				Description:  "One or a list of comma separated server addresses that you want to track.",
+				Required:     true,
+				Autocomplete: true,
+				MinLength:    option.NewInt(9),
+			},
+			&discord.ChannelOption{
+				OptionName:  channelOptionName,
+				Description: "The channel id of the channel you want to track the server for.",
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:           "list-role-notifications",
+		Description:    "List all role notifications for the current or given channel",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.ChannelOption{
+				OptionName:  channelOptionName,
+				Description: "The channel id of the channel you want to list the role notifications for.",
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:           "add-role-notification",
+		Description:    "Mention a role when a tracked server reaches a player count threshold",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.RoleOption{
+				OptionName:  "role",
+				Description: "The role that should be mentioned.",
+				Required:    true,
+			},
+			&discord.StringOption{
+				OptionName:   "address",
+				Description:  "The address of the tracked server.",
+				Required:     true,
+				Autocomplete: true,
+				MinLength:    option.NewInt(9),
+			},
+			&discord.IntegerOption{
+				OptionName:  "threshold",
+				Description: "The number of players that is required to mention the role.",
+				Required:    true,
+				Min:         option.NewInt(1),
+				Max:         option.NewInt(255),
+			},
+			&discord.IntegerOption{
+				OptionName:  "cooldown",
+				Description: "Minimum number of minutes between two mentions of the role (default: 60).",
+				Required:    false,
+				Min:         option.NewInt(0),
+				Max:         option.NewInt(7 * 24 * 60),
+			},
+			&discord.ChannelOption{
+				OptionName:  channelOptionName,
+				Description: "The channel id of the channel that contains the tracked server.",
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:           "remove-role-notification",
+		Description:    "Remove a role notification from a tracked server",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.RoleOption{
+				OptionName:  "role",
+				Description: "The role that should not be mentioned anymore.",
+				Required:    true,
+			},
+			&discord.StringOption{
+				OptionName:   "address",
+				Description:  "The address of the tracked server.",
+				Required:     true,
+				Autocomplete: true,
+				MinLength:    option.NewInt(9),
+			},
+			&discord.ChannelOption{
+				OptionName:  channelOptionName,
+				Description: "The channel id of the channel that contains the tracked server.",
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:           "list-status-alerts",
+		Description:    "List all offline alerts for the current or given channel",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.ChannelOption{
+				OptionName:  channelOptionName,
+				Description: "The channel id of the channel you want to list the offline alerts for.",
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:           "add-status-alert",
+		Description:    "Send an alert when a tracked server goes offline or comes back online",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.StringOption{
+				OptionName:   "address",
+				Description:  "The address of the tracked server.",
+				Required:     true,
+				Autocomplete: true,
+				MinLength:    option.NewInt(9),
+			},
+			&discord.ChannelOption{
+				OptionName:  "alert-channel",
+				Description: "The channel the alerts should be posted to.",
+				Required:    false,
+			},
+			&discord.BooleanOption{
+				OptionName:  "dm",
+				Description: "Send the alerts to you via direct message.",
+				Required:    false,
+			},
+			&discord.IntegerOption{
+				OptionName:  "debounce",
+				Description: "Number of minutes the server must be offline before an alert is sent (default: 1).",
+				Required:    false,
+				Min:         option.NewInt(0),
+				Max:         option.NewInt(24 * 60),
+			},
+			&discord.ChannelOption{
+				OptionName:  channelOptionName,
+				Description: "The channel id of the channel that contains the tracked server.",
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:           "remove-status-alert",
+		Description:    "Remove the offline alert from a tracked server",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.StringOption{
+				OptionName:   "address",
+				Description:  "The address of the tracked server.",
+				Required:     true,
+				Autocomplete: true,
+				MinLength:    option.NewInt(9),
+			},
+			&discord.ChannelOption{
+				OptionName:  channelOptionName,
+				Description: "The channel id of the channel that contains the tracked server.",
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:           "list-map-subscriptions",
+		Description:    "List your map subscriptions for the current or given channel",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.ChannelOption{
+				OptionName:  channelOptionName,
+				Description: "The channel id of the channel you want to list your map subscriptions for.",
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:           "subscribe-map",
+		Description:    "Get notified when a tracked server changes to a specific map",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.StringOption{
+				OptionName:   "address",
+				Description:  "The address of the tracked server.",
+				Required:     true,
+				Autocomplete: true,
+				MinLength:    option.NewInt(9),
+			},
+			&discord.StringOption{
+				OptionName:  "map",
+				Description: "The map name or a pattern like ctf* or dm?.",
+				Required:    true,
+				MinLength:   option.NewInt(1),
+				MaxLength:   option.NewInt(128),
+			},
+			&discord.BooleanOption{
+				OptionName:  "dm",
+				Description: "Send the notification via direct message instead of a mention.",
+				Required:    false,
+			},
+			&discord.ChannelOption{
+				OptionName:  channelOptionName,
+				Description: "The channel id of the channel that contains the tracked server.",
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:           "unsubscribe-map",
+		Description:    "Remove one of your map subscriptions",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.StringOption{
+				OptionName:   "address",
+				Description:  "The address of the tracked server.",
+				Required:     true,
+				Autocomplete: true,
+				MinLength:    option.NewInt(9),
+			},
+			&discord.StringOption{
+				OptionName:  "map",
+				Description: "The map name or pattern you subscribed to.",
+				Required:    true,
+				MinLength:   option.NewInt(1),
+				MaxLength:   option.NewInt(128),
+			},
+			&discord.ChannelOption{
+				OptionName:  channelOptionName,
+				Description: "The channel id of the channel that contains the tracked server.",
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:           "notification-settings",
+		Description:    "Show or change your time zone and quiet hours for notifications",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.StringOption{
+				OptionName:  "timezone",
+				Description: "Your IANA time zone, e.g. Europe/Berlin or America/Sao_Paulo.",
+				Required:    false,
+				MinLength:   option.NewInt(1),
+				MaxLength:   option.NewInt(64),
+			},
+			&discord.StringOption{
+				OptionName:  "quiet-start",
+				Description: "Start of your quiet hours in the format HH:MM, e.g. 22:00.",
+				Required:    false,
+				MinLength:   option.NewInt(4),
+				MaxLength:   option.NewInt(5),
+			},
+			&discord.StringOption{
+				OptionName:  "quiet-end",
+				Description: "End of your quiet hours in the format HH:MM. Use the start time to disable quiet hours.",
+				Required:    false,
+				MinLength:   option.NewInt(4),
+				MaxLength:   option.NewInt(5),
+			},
+			&discord.StringOption{
+				OptionName:  "quiet-days",
+				Description: "Days on which quiet hours start, e.g. mon,tue,wed or all, weekdays, weekend.",
+				Required:    false,
+				MinLength:   option.NewInt(3),
+				MaxLength:   option.NewInt(64),
+			},
+		},
+	},
+	{
+		Name:           "list-webhooks",
+		Description:    "List all webhooks of the current guild and their last delivery",
+		NoDMPermission: true,
+	},
+	{
+		Name:           "add-webhook",
+		Description:    "Send signed server events of all tracked servers of this guild to a webhook",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.StringOption{
+				OptionName:  "url",
+				Description: "The https url that receives the events.",
+				Required:    true,
+				MinLength:   option.NewInt(10),
+				MaxLength:   option.NewInt(2048),
+			},
+			&discord.StringOption{
+				OptionName:  "secret",
+				Description: "The secret used to sign the events. A random secret is generated if omitted.",
+				Required:    false,
+				MinLength:   option.NewInt(16),
+				MaxLength:   option.NewInt(256),
+			},
+			&discord.StringOption{
+				OptionName:  "events",
+				Description: "Comma separated list of events, e.g. server.online,server.offline (default: all).",
+				Required:    false,
+				MaxLength:   option.NewInt(256),
+			},
+		},
+	},
+	{
+		Name:           "remove-webhook",
+		Description:    "Remove a webhook from the current guild",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.StringOption{
+				OptionName:  "url",
+				Description: "The url of the webhook.",
+				Required:    true,
+				MinLength:   option.NewInt(10),
+				MaxLength:   option.NewInt(2048),
+			},
+		},
+	},
+	{
+		Name:           "stats",
+		Description:    "Show a chart of the player count history of a tracked server",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.StringOption{
+				OptionName:   "address",
+				Description:  "The address of the tracked server.",
+				Required:     true,
+				Autocomplete: true,
+				MinLength:    option.NewInt(9),
+			},
+			&discord.StringOption{
+				OptionName:  "range",
+				Description: "The time range of the chart (default: 24h).",
+				Required:    false,
+				Choices: []discord.StringChoice{
+					{Name: "24 hours", Value: "24h"},
+					{Name: "7 days", Value: "7d"},
+					{Name: "30 days", Value: "30d"},
+				},
+			},
+			&discord.ChannelOption{
+				OptionName:  channelOptionName,
+				Description: "The channel id of the channel that contains the tracked server.",
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:           "heatmap",
+		Description:    "Show the average player count per weekday and hour of tracked servers",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.StringOption{
+				OptionName:   "address",
+				Description:  "The address of the tracked server (default: all tracked servers of the channel).",
+				Required:     false,
+				Autocomplete: true,
+				MinLength:    option.NewInt(9),
+			},
+			&discord.ChannelOption{
+				OptionName:  channelOptionName,
+				Description: "The channel id of the channel that contains the tracked servers.",
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:           "list-weekly-heatmaps",
+		Description:    "List all activity heatmaps that are posted once a week",
+		NoDMPermission: true,
+	},
+	{
+		Name:           "add-weekly-heatmap",
+		Description:    "Post the activity heatmap of tracked servers every monday",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.StringOption{
+				OptionName:   "address",
+				Description:  "The address of the tracked server (default: all tracked servers of the channel).",
+				Required:     false,
+				Autocomplete: true,
+				MinLength:    option.NewInt(9),
+			},
+			&discord.ChannelOption{
+				OptionName:  "post-channel",
+				Description: "The channel the heatmap is posted to (default: current channel).",
+				Required:    false,
+			},
+			&discord.ChannelOption{
+				OptionName:  channelOptionName,
+				Description: "The channel id of the channel that contains the tracked servers.",
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:           "remove-weekly-heatmap",
+		Description:    "Stop posting an activity heatmap every week",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.StringOption{
+				OptionName:   "address",
+				Description:  "The address of the tracked server (default: all tracked servers of the channel).",
+				Required:     false,
+				Autocomplete: true,
+				MinLength:    option.NewInt(9),
+			},
+			&discord.ChannelOption{
+				OptionName:  channelOptionName,
+				Description: "The channel id of the channel that contains the tracked servers.",
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:           "guild-settings",
+		Description:    "Show or change the settings of this Discord server, e.g. its time zone, bot manager role or language",
+		NoDMPermission: true,
+		DefaultMemberPermissions: discord.NewPermissions(
+			discord.PermissionAdministrator,
+		),
+		Options: []discord.CommandOption{
+			&discord.StringOption{
+				OptionName:  "timezone",
+				Description: "The IANA time zone of this Discord server, e.g. Europe/Berlin.",
+				Required:    false,
+				MinLength:   option.NewInt(1),
+				MaxLength:   option.NewInt(64),
+			},
+			&discord.RoleOption{
+				OptionName:  "manager-role",
+				Description: "Members with this role may manage the bot without administrator permissions.",
+				Required:    false,
+			},
+			&discord.BooleanOption{
+				OptionName:  "remove-manager-role",
+				Description: "Only administrators may manage the bot.",
+				Required:    false,
+			},
+			&discord.StringOption{
+				OptionName:  "language",
+				Description: "The language of the bot, by default the language of this Discord server.",
+				Required:    false,
+				Choices: []discord.StringChoice{
+					{Name: "Discord server language", Value: autoLanguage},
+					{Name: "English", Value: string(discord.EnglishUS)},
+					{Name: "Deutsch", Value: string(discord.German)},
+					{Name: "Русский", Value: string(discord.Russian)},
+					{Name: "Português do Brasil", Value: string(discord.PortugueseBR)},
+				},
+			},
+		},
+	},
+	{
+		Name:           "seen",
+		Description:    "Show when and on which tracked server a player was last online",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.StringOption{
+				OptionName:  "name",
+				Description: "The name of the player (case insensitive).",
+				Required:    true,
+				MinLength:   option.NewInt(1),
+				MaxLength:   option.NewInt(32),
+			},
+		},
+	},
+	{
+		Name:           "sessions",
+		Description:    "List the most recent player sessions of a tracked server",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.StringOption{
+				OptionName:   "address",
+				Description:  "The address of the tracked server.",
+				Required:     true,
+				Autocomplete: true,
+				MinLength:    option.NewInt(9),
+			},
+			&discord.ChannelOption{
+				OptionName:  channelOptionName,
+				Description: "The channel id of the channel that contains the tracked server.",
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:           "leaderboard",
+		Description:    "List the players or clans with the most playtime on the tracked servers",
+		NoDMPermission: true,
 		Options: []discord.CommandOption{
 			&discord.StringOption{
-				OptionName:  "description",
-				Description: "A description for this guild.",
-				MinLength:   option.NewInt(4),
-				MaxLength:   option.NewInt(256),
-				Required:    true,
+				OptionName:  "period",
+				Description: "The period of the leaderboard, weeks and months are in UTC (default: month).",
+				Required:    false,
+				Choices: []discord.StringChoice{
+					{Name: "this week", Value: "week"},
+					{Name: "last week", Value: "last-week"},
+					{Name: "this month", Value: "month"},
+					{Name: "last month", Value: "last-month"},
+					{Name: "all-time", Value: "all-time"},
+				},
 			},
 			&discord.StringOption{
-				OptionName:  "id",
-				Description: "The guild id of the guild you want to add.",
-				MinLength:   option.NewInt(1),
-				MaxLength:   option.NewInt(20),
+				OptionName:   "address",
+				Description:  "The address of a tracked server (default: all tracked servers).",
+				Required:     false,
+				Autocomplete: true,
+				MinLength:    option.NewInt(9),
+			},
+			&discord.BooleanOption{
+				OptionName:  "clans",
+				Description: "Rank clans instead of players.",
 				Required:    false,
 			},
 		},
 	},
 	{
-		Name:        "remove-guild",
-		Description: "Remove a guild from the allowed guilds",
+		Name:           "uptime",
+		Description:    "Show the availability and outages of a tracked server",
+		NoDMPermission: true,
 		Options: []discord.CommandOption{
 			&discord.StringOption{
-				OptionName:  "id",
-				Description: "The guild id of the guild you want to remove.",
-				MinLength:   option.NewInt(1),
-				MaxLength:   option.NewInt(20),
+				OptionName:   "address",
+				Description:  "The address of the tracked server.",
+				Required:     true,
+				Autocomplete: true,
+				MinLength:    option.NewInt(9),
+			},
+			&discord.ChannelOption{
+				OptionName:  channelOptionName,
+				Description: "The channel id of the channel that contains the tracked server.",
 				Required:    false,
 			},
 		},
 	},
 	{
-		Name:        "update-servers",
-		Description: "Update the server list",
+		Name:           "weekly-digest",
+		Description:    "Show the digest of the tracked servers of a channel for the last seven days",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.ChannelOption{
+				OptionName:  channelOptionName,
+				Description: "The channel id of the channel that contains the tracked servers.",
+				Required:    false,
+			},
+		},
 	},
 	{
-		Name:        "update-messages",
-		Description: "Update all discord messages with server status lists",
+		Name:           "list-weekly-digests",
+		Description:    "List all channels that post a weekly digest",
+		NoDMPermission: true,
 	},
-}
-
-var userCommandList = []api.CreateCommandData{
 	{
-		Name:        "help",
-		Description: "Show this help message",
-		DefaultMemberPermissions: discord.NewPermissions(
-			discord.PermissionAdministrator,
-		),
+		Name:           "set-weekly-digest",
+		Description:    "Post a digest of the tracked servers of a channel every week",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.StringOption{
+				OptionName:  "day",
+				Description: "The day of the week the digest is posted on.",
+				Required:    true,
+				Choices: []discord.StringChoice{
+					{Name: "Monday", Value: "mon"},
+					{Name: "Tuesday", Value: "tue"},
+					{Name: "Wednesday", Value: "wed"},
+					{Name: "Thursday", Value: "thu"},
+					{Name: "Friday", Value: "fri"},
+					{Name: "Saturday", Value: "sat"},
+					{Name: "Sunday", Value: "sun"},
+				},
+			},
+			&discord.StringOption{
+				OptionName:  "time",
+				Description: "The time of the day the digest is posted at, format HH:MM (default: 18:00).",
+				Required:    false,
+				MinLength:   option.NewInt(4),
+				MaxLength:   option.NewInt(5),
+			},
+			&discord.StringOption{
+				OptionName:  "timezone",
+				Description: "The IANA time zone of the schedule, e.g. Europe/Berlin (default: time zone of the guild).",
+				Required:    false,
+			},
+			&discord.ChannelOption{
+				OptionName:  "post-channel",
+				Description: "The channel the digest is posted to (default: current channel).",
+				Required:    false,
+			},
+			&discord.ChannelOption{
+				OptionName:  channelOptionName,
+				Description: "The channel id of the channel that contains the tracked servers.",
+				Required:    false,
+			},
+		},
 	},
 	{
-		Name:           "add-channel",
-		Description:    "Add a channel to the allowed channels",
+		Name:           "remove-weekly-digest",
+		Description:    "Stop posting the weekly digest of a channel",
 		NoDMPermission: true,
-		DefaultMemberPermissions: discord.NewPermissions(
-			discord.PermissionAdministrator,
-		),
 		Options: []discord.CommandOption{
 			&discord.ChannelOption{
 				OptionName:  channelOptionName,
-				Description: "The channel id of the channel you want to add.",
+				Description: "The channel id of the channel that contains the tracked servers.",
 				Required:    false,
 			},
 		},
 	},
 	{
-		Name:           "remove-channel",
-		Description:    "Remove a channel from the allowed channels",
+		Name:           "maps",
+		Description:    "List the recently played and the most popular maps of a tracked server",
 		NoDMPermission: true,
-		DefaultMemberPermissions: discord.NewPermissions(
-			discord.PermissionAdministrator,
-		),
 		Options: []discord.CommandOption{
+			&discord.StringOption{
+				OptionName:   "address",
+				Description:  "The address of the tracked server.",
+				Required:     true,
+				Autocomplete: true,
+				MinLength:    option.NewInt(9),
+			},
+			&discord.StringOption{
+				OptionName:  "range",
+				Description: "The time range of the most popular maps (default: 7d).",
+				Required:    false,
+				Choices: []discord.StringChoice{
+					{Name: "24 hours", Value: "24h"},
+					{Name: "7 days", Value: "7d"},
+					{Name: "30 days", Value: "30d"},
+				},
+			},
 			&discord.ChannelOption{
 				OptionName:  channelOptionName,
-				Description: "The channel id of the channel you want to remove.",
+				Description: "The channel id of the channel that contains the tracked server.",
 				Required:    false,
 			},
 		},
 	},
 	{
-		Name:           "list-channels",
-		Description:    "List all channels of the current guild that are registered for this bot",
+		Name:           "export",
+		Description:    "Export the player counts, sessions and map changes of a tracked server",
 		NoDMPermission: true,
-		DefaultMemberPermissions: discord.NewPermissions(
-			discord.PermissionAdministrator,
-		),
+		Options: []discord.CommandOption{
+			&discord.StringOption{
+				OptionName:   "address",
+				Description:  "The address of the tracked server.",
+				Required:     true,
+				Autocomplete: true,
+				MinLength:    option.NewInt(9),
+			},
+			&discord.StringOption{
+				OptionName:  "range",
+				Description: "The time range of the export (default: 24h).",
+				Required:    false,
+				Choices: []discord.StringChoice{
+					{Name: "24 hours", Value: "24h"},
+					{Name: "7 days", Value: "7d"},
+					{Name: "30 days", Value: "30d"},
+				},
+			},
+			&discord.StringOption{
+				OptionName:  "format",
+				Description: "The file format of the export (default: csv).",
+				Required:    false,
+				Choices: []discord.StringChoice{
+					{Name: "CSV", Value: "csv"},
+					{Name: "JSON", Value: "json"},
+				},
+			},
+			&discord.ChannelOption{
+				OptionName:  channelOptionName,
+				Description: "The channel id of the channel that contains the tracked server.",
+				Required:    false,
+			},
+		},
 	},
 	{
-		Name:           "list-flag-mappings",
-		Description:    "List all flag mappings for the current or given channel",
+		Name:           "forecast",
+		Description:    "Predict the player count of a tracked server for the next hours",
 		NoDMPermission: true,
-		DefaultMemberPermissions: discord.NewPermissions(
-			discord.PermissionAdministrator,
-		),
 		Options: []discord.CommandOption{
+			&discord.StringOption{
+				OptionName:   "address",
+				Description:  "The address of the tracked server.",
+				Required:     true,
+				Autocomplete: true,
+				MinLength:    option.NewInt(9),
+			},
+			&discord.IntegerOption{
+				OptionName:  "hours",
+				Description: "The number of hours to predict (default: 6).",
+				Required:    false,
+				Min:         option.NewInt(1),
+				Max:         option.NewInt(model.MaxForecastHours),
+			},
 			&discord.ChannelOption{
 				OptionName:  channelOptionName,
-				Description: "The channel id of the channel you want to list the flag mappings for.",
+				Description: "The channel id of the channel that contains the tracked server.",
 				Required:    false,
 			},
 		},
 	},
 	{
-		Name:           "add-flag-mapping",
-		Description:    "Add a flag mapping for the current channel",
+		Name:           "find-player",
+		Description:    "Find a player on any server of the master server",
 		NoDMPermission: true,
-		DefaultMemberPermissions: discord.NewPermissions(
-			discord.PermissionAdministrator,
-		),
 		Options: []discord.CommandOption{
 			&discord.StringOption{
-				OptionName:  "abbr",
-				Description: "The abbreviation of the flag you want to add a different emoji for.",
+				OptionName:  "name",
+				Description: "The name of the player.",
 				Required:    true,
-				MinLength:   option.NewInt(2),
-				MaxLength:   option.NewInt(7), // len("default")
+				MinLength:   option.NewInt(1),
+				MaxLength:   option.NewInt(32),
 			},
 			&discord.StringOption{
-				OptionName:  "emoji",
-				Description: "The emoji you want to use for this flag (any text).",
-				Required:    true,
-				MinLength:   option.NewInt(1), // :X:
-				MaxLength:   option.NewInt(256),
+				OptionName:  "match",
+				Description: "How closely the name must match (default: fuzzy).",
+				Required:    false,
+				Choices: []discord.StringChoice{
+					{Name: "exact", Value: "exact"},
+					{Name: "prefix", Value: "prefix"},
+					{Name: "fuzzy", Value: "fuzzy"},
+				},
 			},
-			&discord.ChannelOption{
-				OptionName:  channelOptionName,
-				Description: "The channel id of the channel you want to add a flag mapping for.",
+		},
+	},
+	{
+		Name:           "search-servers",
+		Description:    "Search all servers of the master server",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.StringOption{
+				OptionName:  "name",
+				Description: "A part of the server name.",
+				Required:    false,
+				MaxLength:   option.NewInt(128),
+			},
+			&discord.StringOption{
+				OptionName:  "gametype",
+				Description: "A part of the gametype like DDNet or CTF.",
+				Required:    false,
+				MaxLength:   option.NewInt(64),
+			},
+			&discord.StringOption{
+				OptionName:  "map",
+				Description: "A part of the map name.",
+				Required:    false,
+				MaxLength:   option.NewInt(128),
+			},
+			&discord.IntegerOption{
+				OptionName:  "min-players",
+				Description: "The minimum number of players, spectators are not counted.",
+				Required:    false,
+				Min:         option.NewInt(0),
+				Max:         option.NewInt(255),
+			},
+			&discord.BooleanOption{
+				OptionName:  "passworded",
+				Description: "Whether the server requires a password.",
+				Required:    false,
+			},
+			&discord.StringOption{
+				OptionName:  "version",
+				Description: "The beginning of the server version like 0.6 or 18.",
+				Required:    false,
+				MaxLength:   option.NewInt(64),
+			},
+			&discord.StringOption{
+				OptionName:  "region",
+				Description: "The region of the server.",
 				Required:    false,
+				Choices: []discord.StringChoice{
+					{Name: "Africa", Value: "af"},
+					{Name: "Asia", Value: "as"},
+					{Name: "Europe", Value: "eu"},
+					{Name: "North America", Value: "na"},
+					{Name: "Oceania", Value: "oc"},
+					{Name: "South America", Value: "sa"},
+				},
 			},
 		},
 	},
 	{
-		Name:           "remove-flag-mapping",
-		Description:    "Remove a flag mapping for the current or provided channel",
+		Name:           "server-info",
+		Description:    "Preview the current status of any server of the master server",
 		NoDMPermission: true,
-		DefaultMemberPermissions: discord.NewPermissions(
-			discord.PermissionAdministrator,
-		),
 		Options: []discord.CommandOption{
 			&discord.StringOption{
-				OptionName:  "abbr",
-				Description: "The abbreviation of the flag you want to remove a mapping for.",
-				Required:    true,
-				MinLength:   option.NewInt(2),
-				MaxLength:   option.NewInt(7), // len("default")
+				OptionName:   "address",
+				Description:  "The address of the server.",
+				Required:     true,
+				Autocomplete: true,
+				MinLength:    option.NewInt(9),
+			},
+		},
+	},
+	{
+		Name:           "top-servers",
+		Description:    "List the most populated servers of the master server",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.StringOption{
+				OptionName:  "gametype",
+				Description: "A part of the gametype like DDNet or CTF.",
+				Required:    false,
+				MaxLength:   option.NewInt(64),
+			},
+			&discord.IntegerOption{
+				OptionName:  "count",
+				Description: "The number of servers, defaults to 10.",
+				Required:    false,
+				Min:         option.NewInt(1),
+				Max:         option.NewInt(model.MaxTopServers),
+			},
+		},
+	},
+	{
+		Name:           "add-top-servers",
+		Description:    "Add a message that is kept up to date with the most populated servers",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.StringOption{
+				OptionName:  "gametype",
+				Description: "A part of the gametype like DDNet or CTF.",
+				Required:    false,
+				MaxLength:   option.NewInt(64),
+			},
+			&discord.IntegerOption{
+				OptionName:  "count",
+				Description: "The number of servers, defaults to 10.",
+				Required:    false,
+				Min:         option.NewInt(1),
+				Max:         option.NewInt(model.MaxTopServers),
 			},
 			&discord.ChannelOption{
 				OptionName:  channelOptionName,
-				Description: "The channel id of the channel you want to remove a flag mapping for.",
+				Description: "The channel that contains the message.",
 				Required:    false,
 			},
 		},
 	},
 	{
-		Name:           "list-flags",
-		Description:    "show all known flags",
+		Name:           "list-discovery-rules",
+		Description:    "List the rules that alert about new servers",
 		NoDMPermission: true,
-		DefaultMemberPermissions: discord.NewPermissions(
-			discord.PermissionAdministrator,
-		),
 	},
 	{
-		Name:           "add-tracking",
-		Description:    "Add tracking of a Teeworlds server for the current or given channel",
+		Name:           "add-discovery-rule",
+		Description:    "Send an alert when a new server with a matching name or gametype appears",
 		NoDMPermission: true,
-		DefaultMemberPermissions: discord.NewPermissions(
-			discord.PermissionAdministrator,
-		),
 		Options: []discord.CommandOption{
 			&discord.StringOption{
-				OptionName:  "address",
-				Description: "One or a list of comma separated server addresses that you want to track.",
-				Required:    true,
-				MinLength:   option.NewInt(9),
+				OptionName:  "name",
+				Description: "A part of the server name like your clan tag.",
+				Required:    false,
+				MaxLength:   option.NewInt(64),
+			},
+			&discord.StringOption{
+				OptionName:  "gametype",
+				Description: "A part of the gametype like DDNet or CTF.",
+				Required:    false,
+				MaxLength:   option.NewInt(64),
 			},
 			&discord.ChannelOption{
-				OptionName:  channelOptionName,
-				Description: "The channel id of the channel you want to track the server for.",
+				OptionName:  "alert-channel",
+				Description: "The channel the alerts should be posted to, it must be added with /add-channel first.",
 				Required:    false,
 			},
 		},
 	},
+	{
+		Name:           "remove-discovery-rule",
+		Description:    "Remove a rule that alerts about new servers",
+		NoDMPermission: true,
+		Options: []discord.CommandOption{
+			&discord.IntegerOption{
+				OptionName:  "id",
+				Description: "The id of the rule as shown by /list-discovery-rules.",
+				Required:    true,
+				Min:         option.NewInt(1),
+			},
+		},
+	},
 	{
 		Name:           "start",
 		Description:    "Start the bot for  the given channel",
 		NoDMPermission: true,
-		DefaultMemberPermissions: discord.NewPermissions(
-			discord.PermissionAdministrator,
-		),
 		Options: []discord.CommandOption{
 			&discord.ChannelOption{
 				OptionName:  channelOptionName,
@@ -237,9 +1070,6 @@ var userCommandList = []api.CreateCommandData{
 		Name:           "stop",
 		Description:    "Stop the bot for the given channel",
 		NoDMPermission: true,
-		DefaultMemberPermissions: discord.NewPermissions(
-			discord.PermissionAdministrator,
-		),
 		Options: []discord.CommandOption{
 			&discord.ChannelOption{
 				OptionName:  channelOptionName,
@@ -250,6 +1080,22 @@ var userCommandList = []api.CreateCommandData{
 	},
 }
 
+// HistoryConfig defines how the player count history of tracked servers is stored.
+type HistoryConfig struct {
+	// minimum duration between two samples of a server
+	Resolution time.Duration
+	// raw samples are removed after this duration
+	Retention time.Duration
+	// hourly aggregates are removed after this duration, daily aggregates are kept forever
+	HourlyRetention time.Duration
+	// player and map sessions are removed after this duration
+	SessionRetention time.Duration
+	// servers that were not listed on the master server for this duration are discovered again
+	DiscoveryRetention time.Duration
+	// adds a hint to status messages in case a server is usually busy soon
+	ForecastHint bool
+}
+
 type Bot struct {
 	ctx             context.Context
 	state           *state.State
@@ -261,9 +1107,22 @@ type Bot struct {
 	userID          discord.UserID
 	c               chan model.ChangedServerStatus
 	n               chan model.PlayerCountNotificationMessage
+	a               chan model.ServerStatusAlert
+	dm              chan model.DirectMessage
+	w               chan model.WebhookEvent
+	ts              chan model.TopServersMessage
+	d               chan model.DiscoveryAlert
+	webhooks        *webhook.Client
 	pollingInterval time.Duration
+	history         HistoryConfig
 	conflictMap     *xsync.MapOf[model.MessageTarget, Backoff]
+	forecasts       *xsync.MapOf[string, model.ForecastModel]
+	searches        *xsync.MapOf[string, cachedServerSearch]
+	languages       *xsync.MapOf[discord.GuildID, discord.Language]
 	l               *logging.Logger
+
+	// only accessed by the server updater goroutine
+	lastPlaytimeUpdate time.Time
 }
 
 // New requires a discord bot token and returns a Bot instance.
@@ -277,6 +1136,7 @@ func New(
 	channelID discord.ChannelID,
 	pollingInterval time.Duration,
 	legacyMessageFormat bool,
+	history HistoryConfig,
 ) (*Bot, error) {
 
 	s := state.New("Bot " + token)
@@ -293,15 +1153,25 @@ func New(
 		useEmbeds:       !legacyMessageFormat,
 		c:               make(chan model.ChangedServerStatus, 1024),
 		n:               make(chan model.PlayerCountNotificationMessage, 1024),
+		a:               make(chan model.ServerStatusAlert, 256),
+		dm:              make(chan model.DirectMessage, 256),
+		w:               make(chan model.WebhookEvent, 1024),
+		ts:              make(chan model.TopServersMessage, 256),
+		d:               make(chan model.DiscoveryAlert, 256),
+		webhooks:        webhook.NewClient(webhookRetries, webhookRetryWait, webhookRetryMaxWait),
 		conflictMap:     xsync.NewMapOf[model.MessageTarget, Backoff](),
+		forecasts:       xsync.NewMapOf[string, model.ForecastModel](),
+		searches:        xsync.NewMapOf[string, cachedServerSearch](),
+		languages:       xsync.NewMapOf[discord.GuildID, discord.Language](),
 		pollingInterval: pollingInterval,
+		history:         history,
 		guildID:         guildID,
 		channelID:       channelID,
 		l:               logging.NewLogger(ctx),
 	}
 
 	s.AddIntents(
-		gateway.IntentGuilds | gateway.IntentGuildMessages | gateway.IntentGuildMessageReactions,
+		gateway.IntentGuilds | gateway.IntentGuildMessages,
 	)
 
 	var startupOnce sync.Once
@@ -326,7 +1196,7 @@ func New(
 				bot.l.Infof("initialized server list with %d source and %d target servers", src, dst)
 			}
 
-			// sync trackings and player notification requests
+			// sync trackings
 			err = bot.syncDatabaseState(ctx)
 			if err != nil {
 				log.Fatalf("failed to synchronize database with discord state: %v", err)
@@ -336,6 +1206,15 @@ func New(
 
 			// start polling
 			go bot.cacheCleanup(routines)
+
+			routines++
+			go bot.historyMaintenance(routines)
+
+			routines++
+			go bot.weeklyHeatmapPoster(routines)
+
+			routines++
+			go bot.weeklyDigestPoster(routines)
 			go bot.serverUpdater(pollingInterval)
 			for i := 0; i < max(2*runtime.NumCPU(), 5); i++ {
 				routines++
@@ -346,6 +1225,23 @@ func New(
 				routines++
 				go bot.notificationUpdater(routines)
 			}
+
+			routines++
+			go bot.alertUpdater(routines)
+
+			routines++
+			go bot.directMessageUpdater(routines)
+
+			for i := 0; i < max(runtime.NumCPU(), 3); i++ {
+				routines++
+				go bot.webhookUpdater(routines)
+			}
+
+			routines++
+			go bot.topServersUpdater(routines)
+
+			routines++
+			go bot.discoveryAlerter(routines)
 		})
 	})
 
@@ -353,10 +1249,9 @@ func New(
 	s.AddHandler(bot.handleMessageDeletion)
 	s.AddHandler(bot.handleAddGuild)
 	s.AddHandler(bot.handleRemoveGuild)
-	s.AddHandler(bot.handleAddReactions)
-	s.AddHandler(bot.handleRemoveReactions)
 
-	r := cmdroute.NewRouter()
+	r := NewRouter()
+	r.Use(bot.localize, bot.managerOnly)
 
 	// bot owner commands
 	r.AddFunc("list-guilds", bot.listGuilds)
@@ -374,9 +1269,63 @@ func New(
 	r.AddFunc("list-flag-mappings", bot.listFlagMappings)
 	r.AddFunc("remove-flag-mapping", bot.removeFlagMapping)
 	r.AddFunc("add-tracking", bot.addTracking)
+	r.AddFunc("list-role-notifications", bot.listRoleNotifications)
+	r.AddFunc("add-role-notification", bot.addRoleNotification)
+	r.AddFunc("remove-role-notification", bot.removeRoleNotification)
+	r.AddFunc("list-status-alerts", bot.listStatusAlerts)
+	r.AddFunc("add-status-alert", bot.addStatusAlert)
+	r.AddFunc("remove-status-alert", bot.removeStatusAlert)
+	r.AddFunc("list-map-subscriptions", bot.listMapSubscriptions)
+	r.AddFunc("subscribe-map", bot.subscribeMap)
+	r.AddFunc("unsubscribe-map", bot.unsubscribeMap)
+	r.AddFunc("notification-settings", bot.notificationSettings)
+	r.AddFunc("list-webhooks", bot.listWebhooks)
+	r.AddFunc("add-webhook", bot.addWebhook)
+	r.AddFunc("remove-webhook", bot.removeWebhook)
+	r.AddFunc("stats", bot.stats)
+	r.AddFunc("heatmap", bot.heatmap)
+	r.AddFunc("list-weekly-heatmaps", bot.listWeeklyHeatmaps)
+	r.AddFunc("add-weekly-heatmap", bot.addWeeklyHeatmap)
+	r.AddFunc("remove-weekly-heatmap", bot.removeWeeklyHeatmap)
+	r.AddFunc("guild-settings", bot.guildSettings)
+	r.AddFunc("seen", bot.seen)
+	r.AddFunc("sessions", bot.sessions)
+	r.AddFunc("leaderboard", bot.leaderboard)
+	r.AddFunc("uptime", bot.uptime)
+	r.AddFunc("weekly-digest", bot.weeklyDigest)
+	r.AddFunc("list-weekly-digests", bot.listWeeklyDigests)
+	r.AddFunc("set-weekly-digest", bot.setWeeklyDigest)
+	r.AddFunc("remove-weekly-digest", bot.removeWeeklyDigest)
+	r.AddFunc("maps", bot.maps)
+	r.AddFunc("export", bot.export)
+	r.AddFunc("forecast", bot.forecast)
+	r.AddFunc("find-player", bot.findPlayer)
+	r.AddComponentPrefixFunc(findPlayerComponentPrefix, bot.findPlayerComponent)
+	r.AddFunc("search-servers", bot.searchServers)
+	r.AddComponentPrefixFunc(searchServersComponentPrefix, bot.searchServersComponent)
+	r.AddComponentPrefixFunc(trackServerComponentPrefix, bot.trackServerComponent)
+	r.AddFunc("server-info", bot.serverInfo)
+	r.AddComponentPrefixFunc(notifyComponentPrefix, bot.notifyComponent)
+	r.AddComponentPrefixFunc(detailsComponentPrefix, bot.detailsComponent)
+	r.AddComponentPrefixFunc(confirmComponentPrefix, bot.confirmComponent)
+	r.AddComponentPrefixFunc(cancelComponentPrefix, bot.cancelComponent)
+	r.AddFunc("top-servers", bot.topServers)
+	r.AddFunc("add-top-servers", bot.addTopServers)
+	r.AddFunc("list-discovery-rules", bot.listDiscoveryRules)
+	r.AddFunc("add-discovery-rule", bot.addDiscoveryRule)
+	r.AddFunc("remove-discovery-rule", bot.removeDiscoveryRule)
 	r.AddFunc("start", bot.startChannel)
 	r.AddFunc("stop", bot.stopChannel)
 
+	// suggest server addresses and flag abbreviations while typing
+	for _, commands := range [][]api.CreateCommandData{ownerCommandList, userCommandList} {
+		for _, cmd := range commands {
+			if hasAutocompletion(cmd.Options) {
+				r.AddAutocompleterFunc(cmd.Name, bot.autocomplete)
+			}
+		}
+	}
+
 	s.AddInteractionHandler(r)
 
 	_, err = s.BulkOverwriteGuildCommands(app.ID, bot.guildID, ownerCommandList)
@@ -385,6 +1334,7 @@ func New(
 	}
 
 	// update user facing commands
+	i18n.LocalizeCommands(userCommandList)
 	err = cmdroute.OverwriteCommands(s, userCommandList)
 	if err != nil {
 		return nil, err
@@ -404,12 +1354,47 @@ func (b *Bot) Close() error {
 }
 
 func (b *Bot) IsSuperAdmin(data cmdroute.CommandData) bool {
+	return b.isSuperAdmin(data.Event)
+}
+
+// isAdministrator returns true in case the sender of the interaction has administrator permissions.
+// The roles of the sender are part of the interaction, which does not require the member to be cached.
+func (b *Bot) isAdministrator(e *discord.InteractionEvent) (bool, error) {
+	if e.Member == nil {
+		return false, nil
+	}
+
+	guild, err := b.state.Guild(e.GuildID)
+	if err != nil {
+		return false, err
+	}
+	if guild.OwnerID == e.Member.User.ID {
+		return true, nil
+	}
+
+	roles, err := b.state.Roles(e.GuildID)
+	if err != nil {
+		return false, err
+	}
+	for _, role := range roles {
+		// the @everyone role has the id of the guild
+		if role.ID != discord.RoleID(e.GuildID) && !slices.Contains(e.Member.RoleIDs, role.ID) {
+			continue
+		}
+		if role.Permissions.Has(discord.PermissionAdministrator) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (b *Bot) isSuperAdmin(e *discord.InteractionEvent) bool {
 	// must be infigured guild and channel
-	if !(data.Event.GuildID == b.guildID && data.Event.ChannelID == b.channelID) {
+	if !(e.GuildID == b.guildID && e.ChannelID == b.channelID) {
 		return false
 	}
 
-	userID := data.Event.SenderID()
+	userID := e.SenderID()
 	for _, admin := range b.superAdmins {
 		if admin == userID {
 			return true
@@ -418,18 +1403,28 @@ func (b *Bot) IsSuperAdmin(data cmdroute.CommandData) bool {
 	return false
 }
 
-func ErrAccessForbidden() *api.InteractionResponseData {
-	return errorResponse(fmt.Errorf("access forbidden"))
+func ErrAccessForbidden(ctx context.Context) *api.InteractionResponseData {
+	return errorResponse(ctx, i18n.NewError("access_forbidden"))
 }
 
-func errorResponse(err error) *api.InteractionResponseData {
+func errorResponse(ctx context.Context, err error) *api.InteractionResponseData {
+	l := i18n.FromContext(ctx)
 	return &api.InteractionResponseData{
-		Content:         option.NewNullableString("**Error:** " + err.Error()),
+		Content:         option.NewNullableString(l.T("error", l.Error(err))),
 		Flags:           discord.EphemeralMessage,
 		AllowedMentions: &api.AllowedMentions{ /* none */ },
 	}
 }
 
+// componentErrorResponse answers a component interaction with a new error message
+// and keeps the message of the component unchanged.
+func componentErrorResponse(ctx context.Context, err error) *api.InteractionResponse {
+	return &api.InteractionResponse{
+		Type: api.MessageInteractionWithSource,
+		Data: errorResponse(ctx, err),
+	}
+}
+
 func (b *Bot) TxDAO(ctx context.Context) (d *dao.DAO, closer func(error) error, err error) {
 	tx, closer, err := b.db.Tx(ctx)
 	if err != nil {
@@ -452,20 +1447,15 @@ func (b *Bot) syncDatabaseState(ctx context.Context) (err error) {
 		err = closer(err)
 	}()
 
-	err = dao.RemovePlayerCountNotificationRequests(ctx)
-	if err != nil {
-		return err
-	}
-
 	trackings, err := dao.ListAllTrackings(ctx)
 	if err != nil {
 		return err
 	}
 
-	notifications := make(map[model.MessageUserTarget]model.PlayerCountNotificationRequest)
-
+	// status messages without components must be updated
+	outdated := make([]discord.MessageID, 0)
 	for _, t := range trackings {
-		log.Printf("fetching message %s for notification tracking", t.MessageTarget)
+		log.Printf("fetching message %s for tracking", t.MessageTarget)
 		m, err := b.state.Message(t.ChannelID, t.MessageID)
 		if err != nil {
 			if ErrIsNotFound(err) || ErrIsAccessDenied(err) {
@@ -479,72 +1469,22 @@ func (b *Bot) syncDatabaseState(ctx context.Context) (err error) {
 			return err
 		}
 
-		// iterate over all message reactions
-		for _, reaction := range m.Reactions {
-			emoji := reaction.Emoji.APIString()
-			if _, ok := model.ReactionPlayerCountNotificationMap[emoji]; !ok {
-				// none of the ones that we want to look at
-				continue
-			}
-			log.Printf("fetching users for emoji %s of message %s", emoji, t.MessageTarget)
-			users, err := b.state.Reactions(m.ChannelID, t.MessageID, emoji, 0)
-			if err != nil {
-				if ErrIsNotFound(err) || ErrIsAccessDenied(err) {
-					continue
-				}
+		// notifications used to be requested with reactions, the requests are already
+		// part of the database, which is why the reactions can be removed.
+		if len(m.Reactions) > 0 {
+			log.Printf("removing legacy notification reactions of message %s", t.MessageTarget)
+			err = b.state.DeleteAllReactions(t.ChannelID, t.MessageID)
+			if err != nil && !ErrIsNotFound(err) && !ErrIsAccessDenied(err) {
 				return err
 			}
-			val := model.ReactionPlayerCountNotificationMap[emoji]
+		}
 
-			log.Printf("found %d users for emoji %s of message %s", len(users), emoji, t.MessageTarget)
-			for _, user := range users {
-				userTarget := model.MessageUserTarget{
-					MessageTarget: t.MessageTarget,
-					UserID:        user.ID,
-				}
-				if n, ok := notifications[userTarget]; ok {
-					// only persist the smallest threshold
-					if val < n.Threshold {
-						// remove previous reaction that has a bigger value
-						err = b.state.DeleteUserReaction(
-							n.ChannelID,
-							n.MessageID,
-							n.UserID,
-							model.ReactionPlayerCountNotificationReverseMap[n.Threshold],
-						)
-						if err != nil {
-							return err
-						}
-
-						// update to new lower value
-						n.Threshold = val
-						notifications[userTarget] = n
-					} else {
-						// remove previous reaction that has a bigger value
-						err = b.state.DeleteUserReaction(
-							n.ChannelID,
-							n.MessageID,
-							n.UserID,
-							model.ReactionPlayerCountNotificationReverseMap[val],
-						)
-						if err != nil {
-							return err
-						}
-					}
-				} else {
-					notifications[userTarget] = model.PlayerCountNotificationRequest{
-						MessageUserTarget: userTarget,
-						Threshold:         val,
-					}
-				}
-			}
+		if len(m.Components) == 0 {
+			outdated = append(outdated, t.MessageID)
 		}
 	}
 
-	values := utils.Values(notifications)
-	sort.Sort(model.ByPlayerCountNotificationRequestIDs(values))
-
-	err = dao.SetPlayerCountNotificationRequestList(ctx, values)
+	err = dao.ResetPrevActiveServers(ctx, outdated)
 	if err != nil {
 		return err
 	}
@@ -8,24 +8,25 @@ import (
 	"github.com/diamondburned/arikawa/v3/api/cmdroute"
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/jxsl13/twstatus-bot/i18n"
 	"github.com/jxsl13/twstatus-bot/model"
 )
 
 func (b *Bot) listChannels(ctx context.Context, data cmdroute.CommandData) (resp *api.InteractionResponseData) {
 	dao, closer, err := b.ConnDAO(ctx)
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 	defer closer()
 
 	guildId := data.Event.GuildID
 	channels, err := dao.ListChannels(ctx, guildId)
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 
 	return &api.InteractionResponseData{
-		Content: option.NewNullableString(channels.StatusString()),
+		Content: option.NewNullableString(channels.StatusString(i18n.FromContext(ctx))),
 		Flags:   discord.EphemeralMessage,
 	}
 }
@@ -33,12 +34,12 @@ func (b *Bot) listChannels(ctx context.Context, data cmdroute.CommandData) (resp
 func (b *Bot) addChannel(ctx context.Context, data cmdroute.CommandData) (resp *api.InteractionResponseData) {
 	dao, closer, err := b.TxDAO(ctx)
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 	defer func() {
 		err = closer(err)
 		if err != nil {
-			resp = errorResponse(err)
+			resp = errorResponse(ctx, err)
 		}
 	}()
 
@@ -49,10 +50,10 @@ func (b *Bot) addChannel(ctx context.Context, data cmdroute.CommandData) (resp *
 	}
 	err = dao.AddChannel(ctx, channel)
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 
-	msg := fmt.Sprintf("Added channel: %s", channel)
+	msg := i18n.FromContext(ctx).T("channel.added", channel)
 	return &api.InteractionResponseData{
 		Content: option.NewNullableString(msg),
 		Flags:   discord.EphemeralMessage,
@@ -60,16 +61,11 @@ func (b *Bot) addChannel(ctx context.Context, data cmdroute.CommandData) (resp *
 }
 
 func (b *Bot) removeChannel(ctx context.Context, data cmdroute.CommandData) (resp *api.InteractionResponseData) {
-	dao, closer, err := b.TxDAO(ctx)
+	dao, closer, err := b.ConnDAO(ctx)
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
-	defer func() {
-		err = closer(err)
-		if err != nil {
-			resp = errorResponse(err)
-		}
-	}()
+	defer closer()
 
 	var (
 		guildID   = data.Event.GuildID
@@ -78,23 +74,97 @@ func (b *Bot) removeChannel(ctx context.Context, data cmdroute.CommandData) (res
 
 	channel, err := dao.GetChannel(ctx, guildID, channelID)
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
+	}
+
+	summary, err := dao.GetChannelRemovalSummary(ctx, channelID)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	l := i18n.FromContext(ctx)
+	question := l.T("remove_channel.question", channel, summary.Format(l))
+	return confirmationResponse(l, question, removeChannelAction, channelID.String())
+}
+
+// removeChannelConfirmed removes the channel after the removal was confirmed.
+func (b *Bot) removeChannelConfirmed(ctx context.Context, data cmdroute.ComponentData, arg string) (msg string, err error) {
+	l := i18n.FromContext(ctx)
+
+	ok, err := b.isManager(ctx, data.Event)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", i18n.NewError("access_forbidden")
+	}
+
+	id, err := discord.ParseSnowflake(arg)
+	if err != nil {
+		return "", fmt.Errorf("invalid channel id: %w", err)
+	}
+
+	var (
+		guildID   = data.Event.GuildID
+		channelID = discord.ChannelID(id)
+	)
+
+	channel, summary, msgIDs, err := b.removeChannelFromDB(ctx, guildID, channelID)
+	if err != nil {
+		return "", err
+	}
+
+	// messages are only deleted after the removal was committed,
+	// otherwise the database might track messages that do not exist anymore
+	deleted, err := b.deleteMessages(channelID, msgIDs, "channel was removed")
+	if err != nil {
+		b.l.Errorf("failed to delete messages of channel %s: %v", channelID, err)
+	}
+
+	return l.T("remove_channel.done", channel, summary.Format(l), deleted, len(msgIDs)), nil
+}
+
+// removeChannelFromDB removes the channel in a single transaction and returns the ids
+// of the bot messages of the channel that must be deleted afterwards.
+func (b *Bot) removeChannelFromDB(ctx context.Context, guildID discord.GuildID, channelID discord.ChannelID) (
+	channel model.Channel,
+	summary model.RemovalSummary,
+	msgIDs []discord.MessageID,
+	err error,
+) {
+	dao, closer, err := b.TxDAO(ctx)
+	if err != nil {
+		return channel, summary, nil, err
+	}
+	defer func() {
+		err = closer(err)
+	}()
+
+	channel, err = dao.GetChannel(ctx, guildID, channelID)
+	if err != nil {
+		return channel, summary, nil, err
+	}
+
+	summary, err = dao.GetChannelRemovalSummary(ctx, channelID)
+	if err != nil {
+		return channel, summary, nil, err
 	}
 
 	trackings, err := dao.ListTrackingsByChannelID(ctx, guildID, channelID)
 	if err != nil {
-		return errorResponse(err)
+		return channel, summary, nil, err
 	}
 
-	msgIDs := make([]discord.MessageID, 0, len(trackings))
-	for _, t := range trackings {
-		msgIDs = append(msgIDs, t.MessageID)
+	topServers, err := dao.ListTopServersMessageIDsByChannelID(ctx, guildID, channelID)
+	if err != nil {
+		return channel, summary, nil, err
 	}
 
-	delErr := b.state.DeleteMessages(channelID, msgIDs, "channel was removed")
-	if delErr != nil {
-		b.l.Errorf("failed to delete messages: %v", delErr)
+	msgIDs = make([]discord.MessageID, 0, len(trackings)+len(topServers))
+	for _, t := range trackings {
+		msgIDs = append(msgIDs, t.MessageID)
 	}
+	msgIDs = append(msgIDs, topServers...)
 
 	err = dao.RemoveChannel(
 		ctx,
@@ -102,12 +172,7 @@ func (b *Bot) removeChannel(ctx context.Context, data cmdroute.CommandData) (res
 		channelID,
 	)
 	if err != nil {
-		return errorResponse(err)
-	}
-
-	msg := fmt.Sprintf("removed channel %s", channel)
-	return &api.InteractionResponseData{
-		Content: option.NewNullableString(msg),
-		Flags:   discord.EphemeralMessage,
+		return channel, summary, nil, err
 	}
+	return channel, summary, msgIDs, nil
 }
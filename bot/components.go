@@ -0,0 +1,75 @@
+package bot
+
+import (
+	"context"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// separates the prefix of a component id from its arguments
+const componentIDSeparator = ":"
+
+// ComponentHandlerFunc handles a component interaction, args contains the part of the
+// component id after its prefix.
+type ComponentHandlerFunc func(ctx context.Context, data cmdroute.ComponentData, args string) *api.InteractionResponse
+
+// Router routes component interactions by the prefix of their custom id, which allows to
+// keep state like the current page in the component id.
+// All other interactions are handled by the command router.
+type Router struct {
+	*cmdroute.Router
+	components map[string]ComponentHandlerFunc
+	mws        []cmdroute.Middleware
+}
+
+func NewRouter() *Router {
+	return &Router{
+		Router:     cmdroute.NewRouter(),
+		components: make(map[string]ComponentHandlerFunc),
+	}
+}
+
+// Use adds middlewares that are applied to commands and autocompletions as well as to components.
+func (r *Router) Use(mws ...cmdroute.Middleware) {
+	r.Router.Use(mws...)
+	r.mws = append(r.mws, mws...)
+}
+
+// AddComponentPrefixFunc registers a component handler for all component ids with the given prefix.
+func (r *Router) AddComponentPrefixFunc(prefix string, f ComponentHandlerFunc) {
+	if _, ok := r.components[prefix]; ok {
+		panic("component prefix " + prefix + " is already registered")
+	}
+	r.components[prefix] = f
+}
+
+func (r *Router) HandleInteraction(ev *discord.InteractionEvent) *api.InteractionResponse {
+	if data, ok := ev.Data.(discord.ComponentInteraction); ok {
+		prefix, args, _ := strings.Cut(string(data.ID()), componentIDSeparator)
+		if f, ok := r.components[prefix]; ok {
+			var h cmdroute.InteractionHandler = cmdroute.InteractionHandlerFunc(
+				func(ctx context.Context, ev *discord.InteractionEvent) *api.InteractionResponse {
+					return f(ctx, cmdroute.ComponentData{
+						Event:                ev,
+						ComponentInteraction: data,
+					}, args)
+				},
+			)
+			// first middleware is called first
+			for i := len(r.mws) - 1; i >= 0; i-- {
+				h = r.mws[i](h)
+			}
+			return h.HandleInteraction(context.Background(), ev)
+		}
+	}
+	return r.Router.HandleInteraction(ev)
+}
+
+// componentID joins the prefix of a component handler and its arguments.
+// Component ids must not exceed 100 characters.
+func componentID(prefix string, args ...string) discord.ComponentID {
+	return discord.ComponentID(strings.Join(append([]string{prefix}, args...), componentIDSeparator))
+}
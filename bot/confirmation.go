@@ -0,0 +1,102 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/jxsl13/twstatus-bot/i18n"
+)
+
+const (
+	confirmComponentPrefix = "confirm"
+	cancelComponentPrefix  = "cancel"
+	confirmationTimeout    = time.Minute
+
+	// actions that require a confirmation
+	removeChannelAction = "remove-channel"
+	removeGuildAction   = "remove-guild"
+)
+
+// confirmationResponse asks the user to confirm an action that cannot be undone.
+// The action and its argument are part of the component id of the confirm button
+// together with the deadline of the confirmation.
+func confirmationResponse(l i18n.Localizer, question, action, arg string) *api.InteractionResponseData {
+	deadline := time.Now().Add(confirmationTimeout).Unix()
+	content := l.T("confirm.question", question, deadline)
+
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(content),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+		Components: &discord.ContainerComponents{
+			&discord.ActionRowComponent{
+				&discord.ButtonComponent{
+					Style:    discord.DangerButtonStyle(),
+					CustomID: componentID(confirmComponentPrefix, action, arg, strconv.FormatInt(deadline, 10)),
+					Label:    l.T("confirm.button"),
+				},
+				&discord.ButtonComponent{
+					Style:    discord.SecondaryButtonStyle(),
+					CustomID: componentID(cancelComponentPrefix),
+					Label:    l.T("confirm.cancel_button"),
+				},
+			},
+		},
+	}
+}
+
+// confirmComponent executes the confirmed action unless the confirmation expired.
+func (b *Bot) confirmComponent(ctx context.Context, data cmdroute.ComponentData, args string) *api.InteractionResponse {
+	l := i18n.FromContext(ctx)
+
+	parts := strings.Split(args, componentIDSeparator)
+	if len(parts) != 3 {
+		return componentErrorResponse(ctx, fmt.Errorf("invalid component id: %s", data.ID()))
+	}
+	action, arg := parts[0], parts[1]
+	deadline, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return componentErrorResponse(ctx, fmt.Errorf("invalid deadline: %w", err))
+	}
+	if time.Now().Unix() > deadline {
+		return closeConfirmation(l.T("confirm.expired"))
+	}
+
+	var msg string
+	switch action {
+	case removeChannelAction:
+		msg, err = b.removeChannelConfirmed(ctx, data, arg)
+	case removeGuildAction:
+		msg, err = b.removeGuildConfirmed(ctx, data, arg)
+	default:
+		err = errors.New("unknown action: " + action)
+	}
+	if err != nil {
+		return closeConfirmation(l.T("error", l.Error(err)))
+	}
+	return closeConfirmation(msg)
+}
+
+func (b *Bot) cancelComponent(ctx context.Context, data cmdroute.ComponentData, _ string) *api.InteractionResponse {
+	return closeConfirmation(i18n.FromContext(ctx).T("confirm.cancelled"))
+}
+
+// closeConfirmation replaces the confirmation dialog with the message and removes its buttons.
+func closeConfirmation(msg string) *api.InteractionResponse {
+	return &api.InteractionResponse{
+		Type: api.UpdateMessage,
+		Data: &api.InteractionResponseData{
+			Content:         option.NewNullableString(msg),
+			Components:      &discord.ContainerComponents{},
+			AllowedMentions: &api.AllowedMentions{ /* none */ },
+		},
+	}
+}
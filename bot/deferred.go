@@ -0,0 +1,67 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
+)
+
+// deferredFunc creates the response of a deferred interaction.
+type deferredFunc func(ctx context.Context) *api.InteractionResponseData
+
+// deferEdit acknowledges the interaction with a deferred ephemeral response and
+// replaces it with the response of f as soon as f returns.
+// Discord only waits three seconds for the first response of an interaction,
+// which is why commands that render images must not respond synchronously.
+func (b *Bot) deferEdit(ctx context.Context, e *discord.InteractionEvent, f deferredFunc) *api.InteractionResponseData {
+	return b.deferResponse(ctx, e, f, func(data *api.InteractionResponseData) error {
+		_, err := b.state.EditInteractionResponse(e.AppID, e.Token, api.EditInteractionResponseData{
+			Content:         data.Content,
+			Embeds:          data.Embeds,
+			Components:      data.Components,
+			AllowedMentions: data.AllowedMentions,
+			Files:           data.Files,
+		})
+		return err
+	})
+}
+
+// deferFollowUp acknowledges the interaction with a deferred ephemeral response and
+// sends the response of f as follow-up message as soon as f returns.
+func (b *Bot) deferFollowUp(ctx context.Context, e *discord.InteractionEvent, f deferredFunc) *api.InteractionResponseData {
+	return b.deferResponse(ctx, e, f, func(data *api.InteractionResponseData) error {
+		_, err := b.state.FollowUpInteraction(e.AppID, e.Token, *data)
+		return err
+	})
+}
+
+// deferResponse responds to the interaction itself, which is why the returned
+// response data is always nil unless the deferred response could not be sent.
+func (b *Bot) deferResponse(
+	ctx context.Context,
+	e *discord.InteractionEvent,
+	f deferredFunc,
+	deliver func(*api.InteractionResponseData) error,
+) *api.InteractionResponseData {
+	err := b.state.RespondInteraction(e.ID, e.Token, api.InteractionResponse{
+		Type: api.DeferredMessageInteractionWithSource,
+		Data: &api.InteractionResponseData{
+			Flags: discord.EphemeralMessage,
+		},
+	})
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	// the interaction context is not canceled on shutdown
+	ctx = i18n.NewContext(b.ctx, i18n.FromContext(ctx))
+	go func() {
+		err := deliver(f(ctx))
+		if err != nil {
+			b.l.Errorf("failed to deliver deferred response of interaction %s: %v", e.ID, err)
+		}
+	}()
+	return nil
+}
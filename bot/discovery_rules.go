@@ -0,0 +1,172 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+)
+
+type AddDiscoveryRuleParams struct {
+	Name         *string            `discord:"name"`
+	Gametype     *string            `discord:"gametype"`
+	AlertChannel *discord.ChannelID `discord:"alert-channel"`
+}
+
+type RemoveDiscoveryRuleParams struct {
+	ID int `discord:"id"`
+}
+
+func (b *Bot) listDiscoveryRules(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+	dao, closer, err := b.ConnDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer closer()
+
+	rules, err := dao.ListDiscoveryRules(ctx, data.Event.GuildID)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(rules.Format(i18n.FromContext(ctx))),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
+
+func (b *Bot) addDiscoveryRule(ctx context.Context, data cmdroute.CommandData) (resp *api.InteractionResponseData) {
+	var params AddDiscoveryRuleParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	rule := model.DiscoveryRule{
+		GuildID:        data.Event.GuildID,
+		AlertChannelID: data.Event.ChannelID,
+	}
+	if params.Name != nil {
+		rule.Name = strings.TrimSpace(*params.Name)
+	}
+	if params.Gametype != nil {
+		rule.Gametype = strings.TrimSpace(*params.Gametype)
+	}
+	if params.AlertChannel != nil {
+		rule.AlertChannelID = *params.AlertChannel
+	}
+	if rule.Name == "" && rule.Gametype == "" {
+		return errorResponse(ctx, i18n.NewError("error.discovery_rule_filter_required"))
+	}
+
+	dao, closer, err := b.TxDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer func() {
+		err = closer(err)
+		if err != nil {
+			resp = errorResponse(ctx, err)
+		}
+	}()
+
+	// rules are removed together with their alert channel
+	_, err = dao.GetChannel(ctx, rule.GuildID, rule.AlertChannelID)
+	if err != nil {
+		return errorResponse(ctx, i18n.WrapError(err, "error.channel_not_added", rule.AlertChannelID.Mention()))
+	}
+
+	err = dao.AddDiscoveryRule(ctx, rule)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	l := i18n.FromContext(ctx)
+	msg := l.T("discovery_rule.added", rule.Filter(l), rule.AlertChannelID.Mention())
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(msg),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
+
+func (b *Bot) removeDiscoveryRule(ctx context.Context, data cmdroute.CommandData) (resp *api.InteractionResponseData) {
+	var params RemoveDiscoveryRuleParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	dao, closer, err := b.TxDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer func() {
+		err = closer(err)
+		if err != nil {
+			resp = errorResponse(ctx, err)
+		}
+	}()
+
+	err = dao.RemoveDiscoveryRule(ctx, data.Event.GuildID, int64(params.ID))
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(i18n.FromContext(ctx).T("discovery_rule.removed", params.ID)),
+		Flags:   discord.EphemeralMessage,
+	}
+}
+
+func (b *Bot) discoveryAlerter(id int) {
+	log.Printf("goroutine %d starting async goroutine for discovery alerts", id)
+
+loop:
+	for {
+		select {
+		case <-b.ctx.Done():
+			break loop
+		case alert, ok := <-b.d:
+			if !ok {
+				break loop
+			}
+			err := b.sendDiscoveryAlert(alert)
+			if err != nil {
+				b.l.Errorf("goroutine %0d: failed to send discovery alert for %s: %v", id, alert.Server.Address, err)
+			}
+		}
+	}
+
+	log.Printf("goroutine %d: closed async goroutine for discovery alerts", id)
+}
+
+// sendDiscoveryAlert posts the new server with a button that allows admins to track it.
+func (b *Bot) sendDiscoveryAlert(a model.DiscoveryAlert) error {
+	l := b.guildLocalizer(b.ctx, a.GuildID)
+	_, err := b.state.SendMessageComplex(a.AlertChannelID, api.SendMessageData{
+		Embeds:          []discord.Embed{a.ToEmbed(l)},
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+		Components: discord.ContainerComponents{
+			&discord.ActionRowComponent{
+				&discord.ButtonComponent{
+					Style:    discord.SuccessButtonStyle(),
+					CustomID: componentID(trackServerComponentPrefix, a.Server.Address),
+					Label:    l.T("button.track"),
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send discovery alert to channel %s: %w", a.AlertChannelID, err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/diamondburned/arikawa/v3/utils/sendpart"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+)
+
+type ExportParams struct {
+	Address string  `discord:"address"`
+	Range   *string `discord:"range"`
+	Format  *string `discord:"format"`
+}
+
+// export attaches the recorded history of a tracked server as csv or json files.
+// Compressing and splitting large histories takes longer than Discord waits for
+// the first response, which is why the files are sent as follow-up message.
+func (b *Bot) export(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+	return b.deferFollowUp(ctx, data.Event, func(ctx context.Context) *api.InteractionResponseData {
+		return b.exportHistory(ctx, data)
+	})
+}
+
+func (b *Bot) exportHistory(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+	var params ExportParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	rangeName := defaultHistoryRange
+	if params.Range != nil {
+		rangeName = *params.Range
+	}
+	duration, err := model.ParseHistoryRange(rangeName)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	format := model.ExportFormatCSV
+	if params.Format != nil {
+		format = *params.Format
+	}
+
+	dao, closer, err := b.ConnDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer closer()
+
+	tracking, err := dao.GetTrackingByAddress(ctx, data.Event.GuildID, optionalChannelID(data), params.Address)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	to := time.Now()
+	export, err := dao.GetExport(ctx, tracking.Address, to.Add(-duration), to)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	exportFiles, err := export.Files(format, model.MaxAttachmentSize)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	// same attachment path as the files of log entries
+	files := make([]sendpart.File, 0, len(exportFiles))
+	for _, f := range exportFiles {
+		files = append(files, sendpart.File{
+			Name:   f.Name,
+			Reader: bytes.NewReader(f.Data),
+		})
+	}
+
+	msg := i18n.FromContext(ctx).T("export.done",
+		tracking.Address,
+		rangeName,
+		len(export.History.Samples),
+		len(export.Sessions),
+		len(export.MapSessions),
+	)
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(msg),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+		Files:           files,
+	}
+}
@@ -0,0 +1,144 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/markdown"
+	"github.com/jxsl13/twstatus-bot/model"
+)
+
+const (
+	findPlayerComponentPrefix = "find-player"
+	defaultFindPlayerMatch    = "fuzzy"
+	// maximum number of found players over all pages
+	maxFoundPlayers = 100
+	// leaves space for the header of a page
+	findPlayerPageSize = 1800
+)
+
+type FindPlayerParams struct {
+	Name  string  `discord:"name"`
+	Match *string `discord:"match"`
+}
+
+// findPlayer searches a player on all servers of the master server, not only on tracked servers.
+func (b *Bot) findPlayer(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+	var params FindPlayerParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	matchName := defaultFindPlayerMatch
+	if params.Match != nil {
+		matchName = *params.Match
+	}
+	match, err := model.ParsePlayerMatch(matchName)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	resp, err := b.findPlayerPage(ctx, params.Name, match, 0)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	return resp
+}
+
+// findPlayerComponent shows another page of found players.
+// The arguments contain the page, the match and the searched name.
+func (b *Bot) findPlayerComponent(ctx context.Context, data cmdroute.ComponentData, args string) *api.InteractionResponse {
+	parts := strings.SplitN(args, componentIDSeparator, 3)
+	if len(parts) != 3 {
+		return componentErrorResponse(ctx, fmt.Errorf("invalid component id: %s", data.ID()))
+	}
+
+	page, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return componentErrorResponse(ctx, fmt.Errorf("invalid page: %w", err))
+	}
+	match, err := model.ParsePlayerMatch(parts[1])
+	if err != nil {
+		return componentErrorResponse(ctx, err)
+	}
+
+	resp, err := b.findPlayerPage(ctx, parts[2], match, page)
+	if err != nil {
+		return componentErrorResponse(ctx, err)
+	}
+	return &api.InteractionResponse{
+		Type: api.UpdateMessage,
+		Data: resp,
+	}
+}
+
+// findPlayerPage searches the player and returns the requested page of the result.
+// Players may leave or join between two pages, so the page is clamped to the available pages.
+func (b *Bot) findPlayerPage(ctx context.Context, name string, match model.PlayerMatch, page int) (*api.InteractionResponseData, error) {
+	dao, closer, err := b.ConnDAO(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer closer()
+
+	players, err := dao.FindPlayers(ctx, name, match, maxFoundPlayers)
+	if err != nil {
+		return nil, err
+	}
+
+	l := i18n.FromContext(ctx)
+	components := &discord.ContainerComponents{}
+	if len(players) == 0 {
+		return &api.InteractionResponseData{
+			Content:         option.NewNullableString(l.T("find_player.none", markdown.Escape(name), match)),
+			Flags:           discord.EphemeralMessage,
+			AllowedMentions: &api.AllowedMentions{ /* none */ },
+			Components:      components,
+		}, nil
+	}
+
+	pages := splitLines(players.Lines(l), findPlayerPageSize)
+	page = max(0, min(page, len(pages)-1))
+
+	header := l.T("find_player.header", markdown.Escape(name), match, len(players))
+	if len(players) == maxFoundPlayers {
+		header = l.T("find_player.header_more", markdown.Escape(name), match, len(players))
+	}
+	if len(pages) > 1 {
+		header += ", " + l.T("page", page+1, len(pages))
+		pageID := func(p int) discord.ComponentID {
+			return componentID(findPlayerComponentPrefix, strconv.Itoa(p), match.String(), name)
+		}
+		components = &discord.ContainerComponents{
+			&discord.ActionRowComponent{
+				&discord.ButtonComponent{
+					Style:    discord.SecondaryButtonStyle(),
+					CustomID: pageID(page - 1),
+					Label:    l.T("button.previous"),
+					Disabled: page == 0,
+				},
+				&discord.ButtonComponent{
+					Style:    discord.SecondaryButtonStyle(),
+					CustomID: pageID(page + 1),
+					Label:    l.T("button.next"),
+					Disabled: page == len(pages)-1,
+				},
+			},
+		}
+	}
+
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(header + "\n" + pages[page]),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+		Components:      components,
+	}, nil
+}
@@ -2,12 +2,12 @@ package bot
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/diamondburned/arikawa/v3/api"
 	"github.com/diamondburned/arikawa/v3/api/cmdroute"
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/jxsl13/twstatus-bot/i18n"
 	"github.com/jxsl13/twstatus-bot/model"
 )
 
@@ -19,7 +19,7 @@ type AddFlagMappingParams struct {
 func (b *Bot) listFlagMappings(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
 	dao, closer, err := b.ConnDAO(ctx)
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 	defer closer()
 
@@ -30,11 +30,11 @@ func (b *Bot) listFlagMappings(ctx context.Context, data cmdroute.CommandData) *
 		channelID,
 	)
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 
 	return &api.InteractionResponseData{
-		Content: option.NewNullableString(mappings.String()),
+		Content: option.NewNullableString(mappings.Format(i18n.FromContext(ctx))),
 		Flags:   discord.EphemeralMessage,
 	}
 }
@@ -44,23 +44,23 @@ func (b *Bot) addFlagMapping(ctx context.Context, data cmdroute.CommandData) (re
 	var params AddFlagMappingParams
 	err := opts.Unmarshal(&params)
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 
 	dao, closer, err := b.TxDAO(ctx)
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 	defer func() {
 		err = closer(err)
 		if err != nil {
-			resp = errorResponse(err)
+			resp = errorResponse(ctx, err)
 		}
 	}()
 
 	flag, err := dao.GetFlagByAbbr(ctx, params.Abbr)
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 
 	mapping := model.FlagMapping{
@@ -73,10 +73,10 @@ func (b *Bot) addFlagMapping(ctx context.Context, data cmdroute.CommandData) (re
 
 	err = dao.AddFlagMapping(ctx, mapping)
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 
-	msg := fmt.Sprintf("Added flag mapping: %s", mapping.String())
+	msg := i18n.FromContext(ctx).T("flag_mapping.added", mapping.String())
 	return &api.InteractionResponseData{
 		Content: option.NewNullableString(msg),
 		Flags:   discord.EphemeralMessage,
@@ -92,17 +92,17 @@ func (b *Bot) removeFlagMapping(ctx context.Context, data cmdroute.CommandData)
 	var params RemoveFlagMappingParams
 	err := opts.Unmarshal(&params)
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 
 	dao, closer, err := b.TxDAO(ctx)
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 	defer func() {
 		err = closer(err)
 		if err != nil {
-			resp = errorResponse(err)
+			resp = errorResponse(ctx, err)
 		}
 	}()
 
@@ -113,10 +113,10 @@ func (b *Bot) removeFlagMapping(ctx context.Context, data cmdroute.CommandData)
 		params.Abbr,
 	)
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 
-	msg := fmt.Sprintf("Removed flag mapping: %s", params.Abbr)
+	msg := i18n.FromContext(ctx).T("flag_mapping.removed", params.Abbr)
 	return &api.InteractionResponseData{
 		Content: option.NewNullableString(msg),
 		Flags:   discord.EphemeralMessage,
@@ -14,14 +14,14 @@ import (
 func (b *Bot) listFlags(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
 	dao, closer, err := b.ConnDAO(ctx)
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 	defer closer()
 
 	flags, err := dao.ListFlags(ctx)
 
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 
 	var sb strings.Builder
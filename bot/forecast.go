@@ -0,0 +1,55 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/jxsl13/twstatus-bot/i18n"
+)
+
+const defaultForecastHours = 6
+
+type ForecastParams struct {
+	Address string `discord:"address"`
+	Hours   *int   `discord:"hours"`
+}
+
+// forecast predicts the player count of a tracked server for the next hours.
+func (b *Bot) forecast(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+	var params ForecastParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	hours := defaultForecastHours
+	if params.Hours != nil {
+		hours = *params.Hours
+	}
+
+	dao, closer, err := b.ConnDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer closer()
+
+	tracking, err := dao.GetTrackingByAddress(ctx, data.Event.GuildID, optionalChannelID(data), params.Address)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	forecast, err := dao.GetForecast(ctx, tracking.Address, time.Now(), hours)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(forecast.Format(i18n.FromContext(ctx))),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
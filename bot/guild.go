@@ -12,26 +12,27 @@ import (
 	"github.com/diamondburned/arikawa/v3/gateway"
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
 	d "github.com/jxsl13/twstatus-bot/dao"
+	"github.com/jxsl13/twstatus-bot/i18n"
 	"github.com/jxsl13/twstatus-bot/model"
 )
 
 func (b *Bot) listGuilds(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
 	if !b.IsSuperAdmin(data) {
-		return ErrAccessForbidden()
+		return ErrAccessForbidden(ctx)
 	}
 
 	dao, closer, err := b.ConnDAO(ctx)
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 	defer closer()
 
 	guilds, err := dao.ListGuilds(ctx)
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 
-	msg := fmt.Sprintf("Guilds: \n%s", guilds)
+	msg := i18n.FromContext(ctx).T("guild.list", guilds)
 	return &api.InteractionResponseData{
 		Content: option.NewNullableString(msg),
 		Flags:   discord.EphemeralMessage,
@@ -44,7 +45,7 @@ type AddGuildOpts struct { // optional (taken from current guild)
 
 func (b *Bot) addGuildCommand(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
 	if !b.IsSuperAdmin(data) {
-		return ErrAccessForbidden()
+		return ErrAccessForbidden(ctx)
 	}
 
 	var id discord.GuildID
@@ -58,12 +59,12 @@ func (b *Bot) addGuildCommand(ctx context.Context, data cmdroute.CommandData) *a
 	var opts AddGuildOpts
 	err = data.Options.Unmarshal(&opts)
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 
 	dao, closer, err := b.ConnDAO(ctx)
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 	defer closer()
 
@@ -73,10 +74,10 @@ func (b *Bot) addGuildCommand(ctx context.Context, data cmdroute.CommandData) *a
 	})
 
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 
-	msg := fmt.Sprintf("Added guild %d (%s)", data.Event.GuildID, opts.Description)
+	msg := i18n.FromContext(ctx).T("guild.added", data.Event.GuildID, opts.Description)
 	return &api.InteractionResponseData{
 		Content: option.NewNullableString(msg),
 		Flags:   discord.EphemeralMessage,
@@ -85,7 +86,7 @@ func (b *Bot) addGuildCommand(ctx context.Context, data cmdroute.CommandData) *a
 
 func (b *Bot) removeGuildCommand(ctx context.Context, data cmdroute.CommandData) (resp *api.InteractionResponseData) {
 	if !b.IsSuperAdmin(data) {
-		return ErrAccessForbidden()
+		return ErrAccessForbidden(ctx)
 	}
 
 	var id discord.GuildID
@@ -96,27 +97,58 @@ func (b *Bot) removeGuildCommand(ctx context.Context, data cmdroute.CommandData)
 		id = data.Event.GuildID
 	}
 
+	dao, closer, err := b.ConnDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer closer()
+
+	guild, err := dao.GetGuild(ctx, id)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	summary, err := dao.GetGuildRemovalSummary(ctx, id)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	l := i18n.FromContext(ctx)
+	question := l.T("remove_guild.question", guild.ID, guild.Description, summary.Format(l))
+	return confirmationResponse(l, question, removeGuildAction, id.String())
+}
+
+// removeGuildConfirmed removes the guild after the removal was confirmed.
+func (b *Bot) removeGuildConfirmed(ctx context.Context, data cmdroute.ComponentData, arg string) (msg string, err error) {
+	l := i18n.FromContext(ctx)
+	if !b.isSuperAdmin(data.Event) {
+		return "", i18n.NewError("access_forbidden")
+	}
+
+	id, err := discord.ParseSnowflake(arg)
+	if err != nil {
+		return "", fmt.Errorf("invalid guild id: %w", err)
+	}
+
 	dao, closer, err := b.TxDAO(ctx)
 	if err != nil {
-		return errorResponse(err)
+		return "", err
 	}
 	defer func() {
 		err = closer(err)
-		if err != nil {
-			resp = errorResponse(err)
-		}
 	}()
 
-	guild, err := dao.RemoveGuild(ctx, id)
+	summary, err := dao.GetGuildRemovalSummary(ctx, discord.GuildID(id))
 	if err != nil {
-		return errorResponse(err)
+		return "", err
 	}
 
-	msg := fmt.Sprintf("Removed guild `%d` (%s)", guild.ID, guild.Description)
-	return &api.InteractionResponseData{
-		Content: option.NewNullableString(msg),
-		Flags:   discord.EphemeralMessage,
+	guild, err := dao.RemoveGuild(ctx, discord.GuildID(id))
+	if err != nil {
+		return "", err
 	}
+
+	return l.T("remove_guild.done", guild.ID, guild.Description, summary.Format(l)), nil
 }
 
 func (b *Bot) handleAddGuild(e *gateway.GuildCreateEvent) {
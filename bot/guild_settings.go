@@ -0,0 +1,103 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/jxsl13/twstatus-bot/i18n"
+)
+
+type GuildSettingsParams struct {
+	Timezone          *string         `discord:"timezone"`
+	ManagerRole       *discord.RoleID `discord:"manager-role"`
+	RemoveManagerRole *bool           `discord:"remove-manager-role"`
+	Language          *string         `discord:"language"`
+}
+
+// guildSettings shows the current settings of the guild in case no options are provided.
+func (b *Bot) guildSettings(ctx context.Context, data cmdroute.CommandData) (resp *api.InteractionResponseData) {
+	var params GuildSettingsParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	dao, closer, err := b.TxDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer func() {
+		// the cached language is reloaded after the transaction was committed
+		b.languages.Delete(data.Event.GuildID)
+	}()
+	defer func() {
+		err = closer(err)
+		if err != nil {
+			resp = errorResponse(ctx, err)
+		}
+	}()
+
+	settings, err := dao.GetGuildSettings(ctx, data.Event.GuildID)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	changed := false
+	if params.Timezone != nil {
+		loc, err := time.LoadLocation(*params.Timezone)
+		if err != nil {
+			return errorResponse(ctx, i18n.WrapError(err, "error.unknown_time_zone", *params.Timezone))
+		}
+		settings.Location = loc
+		changed = true
+	}
+
+	if params.ManagerRole != nil || (params.RemoveManagerRole != nil && *params.RemoveManagerRole) {
+		// bot managers may use this command, which must not allow them to grant
+		// the bot manager role to others.
+		ok, err := b.isAdministrator(data.Event)
+		if err != nil {
+			return errorResponse(ctx, err)
+		}
+		if !ok {
+			return ErrAccessForbidden(ctx)
+		}
+
+		if params.ManagerRole != nil {
+			settings.ManagerRoleID = *params.ManagerRole
+		} else {
+			settings.ManagerRoleID = 0
+		}
+		changed = true
+	}
+
+	if params.Language != nil {
+		if *params.Language == autoLanguage {
+			settings.Language = ""
+		} else {
+			lang, ok := i18n.Supported(*params.Language)
+			if !ok {
+				return errorResponse(ctx, i18n.NewError("error.unsupported_language", *params.Language))
+			}
+			settings.Language = lang
+		}
+		changed = true
+	}
+
+	if changed {
+		err = dao.SetGuildSettings(ctx, settings)
+		if err != nil {
+			return errorResponse(ctx, err)
+		}
+	}
+
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(settings.Format(i18n.FromContext(ctx))),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
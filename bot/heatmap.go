@@ -0,0 +1,258 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/diamondburned/arikawa/v3/utils/sendpart"
+	"github.com/jxsl13/twstatus-bot/dao"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+)
+
+const (
+	heatmapWidth  = 1000
+	heatmapHeight = 340
+)
+
+type HeatmapParams struct {
+	Address *string `discord:"address"`
+}
+
+type AddWeeklyHeatmapParams struct {
+	Address     *string            `discord:"address"`
+	PostChannel *discord.ChannelID `discord:"post-channel"`
+}
+
+type RemoveWeeklyHeatmapParams struct {
+	Address *string `discord:"address"`
+}
+
+// heatmapAddresses returns the tracked server with the given address or all
+// tracked servers of the channel in case the address is empty.
+func heatmapAddresses(ctx context.Context, dao *dao.DAO, target model.ChannelTarget, address string) ([]string, error) {
+	if address != "" {
+		tracking, err := dao.GetTrackingByAddress(ctx, target.GuildID, target.ChannelID, address)
+		if err != nil {
+			return nil, err
+		}
+		return []string{tracking.Address}, nil
+	}
+
+	trackings, err := dao.ListTrackingsByChannelID(ctx, target.GuildID, target.ChannelID)
+	if err != nil {
+		return nil, err
+	}
+	if len(trackings) == 0 {
+		return nil, i18n.NewError("error.no_tracked_servers", target.ChannelID.Mention())
+	}
+
+	addresses := make([]string, 0, len(trackings))
+	for _, t := range trackings {
+		addresses = append(addresses, t.Address)
+	}
+	return addresses, nil
+}
+
+// renderHeatmap renders the activity heatmap of the given weekly heatmap configuration.
+func renderHeatmap(ctx context.Context, dao *dao.DAO, w model.WeeklyHeatmap, now time.Time) (content string, files []sendpart.File, err error) {
+	addresses, err := heatmapAddresses(ctx, dao, w.ChannelTarget, w.Address)
+	if err != nil {
+		return "", nil, err
+	}
+
+	heatmap, err := dao.GetActivityHeatmap(ctx, addresses, now, w.Location)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// the chart font only contains ASCII glyphs, the localized text is part of the content
+	title := fmt.Sprintf("%d servers - last %d weeks", len(addresses), model.HeatmapWeeks)
+	if w.Address != "" {
+		title = fmt.Sprintf("%s - last %d weeks", w.Address, model.HeatmapWeeks)
+	}
+	hm := heatmap.Chart(title)
+
+	buf := &bytes.Buffer{}
+	err = hm.WritePNG(buf, heatmapWidth, heatmapHeight)
+	if err != nil {
+		return "", nil, err
+	}
+
+	l := i18n.FromContext(ctx)
+	content = l.T("heatmap.content", w.Subject(l), model.HeatmapWeeks, heatmap.Format(l))
+	return content, []sendpart.File{
+		{
+			Name:   "heatmap.png",
+			Reader: buf,
+		},
+	}, nil
+}
+
+// heatmap replaces the deferred response with the activity heatmap as soon as it is rendered.
+func (b *Bot) heatmap(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+	return b.deferEdit(ctx, data.Event, func(ctx context.Context) *api.InteractionResponseData {
+		return b.activityHeatmap(ctx, data)
+	})
+}
+
+func (b *Bot) activityHeatmap(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+	var params HeatmapParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	dao, closer, err := b.ConnDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer closer()
+
+	settings, err := dao.GetGuildSettings(ctx, data.Event.GuildID)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	w := model.WeeklyHeatmap{
+		ChannelTarget: model.ChannelTarget{
+			GuildID:   data.Event.GuildID,
+			ChannelID: optionalChannelID(data),
+		},
+		Location: settings.Location,
+	}
+	if params.Address != nil {
+		w.Address = *params.Address
+	}
+
+	content, files, err := renderHeatmap(ctx, dao, w, time.Now())
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(content),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+		Files:           files,
+	}
+}
+
+func (b *Bot) listWeeklyHeatmaps(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+	dao, closer, err := b.ConnDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer closer()
+
+	heatmaps, err := dao.ListWeeklyHeatmaps(ctx, data.Event.GuildID)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(heatmaps.Format(i18n.FromContext(ctx))),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
+
+func (b *Bot) addWeeklyHeatmap(ctx context.Context, data cmdroute.CommandData) (resp *api.InteractionResponseData) {
+	var params AddWeeklyHeatmapParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	dao, closer, err := b.TxDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer func() {
+		err = closer(err)
+		if err != nil {
+			resp = errorResponse(ctx, err)
+		}
+	}()
+
+	w := model.WeeklyHeatmap{
+		ChannelTarget: model.ChannelTarget{
+			GuildID:   data.Event.GuildID,
+			ChannelID: optionalChannelID(data),
+		},
+		PostChannelID: data.Event.ChannelID,
+	}
+	if params.Address != nil {
+		w.Address = *params.Address
+	}
+	if params.PostChannel != nil {
+		w.PostChannelID = *params.PostChannel
+	}
+
+	// validate that the servers are tracked
+	_, err = heatmapAddresses(ctx, dao, w.ChannelTarget, w.Address)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	err = dao.AddWeeklyHeatmap(ctx, w)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	l := i18n.FromContext(ctx)
+	msg := l.T("weekly_heatmap.added", w.Format(l))
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(msg),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
+
+func (b *Bot) removeWeeklyHeatmap(ctx context.Context, data cmdroute.CommandData) (resp *api.InteractionResponseData) {
+	var params RemoveWeeklyHeatmapParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	dao, closer, err := b.TxDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer func() {
+		err = closer(err)
+		if err != nil {
+			resp = errorResponse(ctx, err)
+		}
+	}()
+
+	w := model.WeeklyHeatmap{
+		ChannelTarget: model.ChannelTarget{
+			GuildID:   data.Event.GuildID,
+			ChannelID: optionalChannelID(data),
+		},
+	}
+	if params.Address != nil {
+		w.Address = *params.Address
+	}
+
+	err = dao.RemoveWeeklyHeatmap(ctx, w)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	l := i18n.FromContext(ctx)
+	msg := l.T("weekly_heatmap.removed", w.Subject(l))
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(msg),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
@@ -8,39 +8,51 @@ import (
 	"github.com/diamondburned/arikawa/v3/api/cmdroute"
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/jxsl13/twstatus-bot/i18n"
 )
 
-var (
-	helpLines = []string{
-		"**Usage:**",
-		"This bot requires you to initially choose a channel to post the status updates to.",
-		"You can do this by using the `/add-channel` command.",
-		"Afterwards you have to add tracking of individual servers to the specified channel.",
-		"You can do this by using the `/add-tracking address:<ipv4:port or [ipv6]:port>` command.",
-		"Lastly, you need to start the bot for the specified channel by using the `/start` command.",
-		"In case that you want to stop the bot for a specific channel, use the `/stop` command.",
-		"",
-		"**Commands:**",
-		"`/add-channel` - adds a channel to the list of channels that are being updated",
-		"`/add-tracking` - adds a server to the list of tracked servers for the specified channel",
-		"If you want to remove a specific tracking, just manually delete the message that was created by the bot.",
-		"`/start` - starts the bot for the specified channel",
-		"`/stop` - stops the bot for the specified channel",
-		"`/list-channels` - lists all channels that are registered for the currend Discord server",
-		"`/list-flags` - list all flags that are available for the `/add-flag-mapping`command",
-		"`/add-flag-mapping` - allows to ad a custom emoji for any player flag.",
-		"",
-		"**Notifications:**",
-		"You can use the following reactions to get notified when the number of players on a server is greater or equal to the specified threshold.",
-		"Use the following reactions: :one:, :two:, :three:, :four:, :five:, :six:, :seven:, :eight:, :nine:, :keycap_ten:",
-		"If you specify :one: as the threshold, you will get notified when there is at least one player on the server.",
+// the help text exceeds the message size limit of discord
+var helpPages = func() map[discord.Language][]string {
+	pages := make(map[discord.Language][]string)
+	for _, c := range i18n.Catalogs() {
+		pages[c.Language] = splitLines(i18n.New(string(c.Language)).Help(), 2000)
 	}
-	helpText = strings.Join(helpLines, "\n")
-)
+	return pages
+}()
+
+// splitLines joins lines into pages of at most limit characters.
+func splitLines(lines []string, limit int) []string {
+	pages := make([]string, 0, 2)
+	var sb strings.Builder
+	for _, line := range lines {
+		if sb.Len() > 0 && sb.Len()+1+len(line) > limit {
+			pages = append(pages, sb.String())
+			sb.Reset()
+		}
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(line)
+	}
+	if sb.Len() > 0 {
+		pages = append(pages, sb.String())
+	}
+	return pages
+}
 
 func (b *Bot) help(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+	pages := helpPages[i18n.FromContext(ctx).Language()]
+
+	// remaining pages are sent as embeds, which allow for longer descriptions
+	embeds := make([]discord.Embed, 0, len(pages)-1)
+	for _, page := range pages[1:] {
+		embeds = append(embeds, discord.Embed{
+			Description: page,
+		})
+	}
 	return &api.InteractionResponseData{
-		Content: option.NewNullableString(helpText),
+		Content: option.NewNullableString(pages[0]),
+		Embeds:  &embeds,
 		Flags:   discord.EphemeralMessage,
 	}
 }
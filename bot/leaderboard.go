@@ -0,0 +1,82 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+)
+
+const (
+	defaultPlaytimePeriod = "month"
+	leaderboardEntries    = 15
+)
+
+type LeaderboardParams struct {
+	Period  *string `discord:"period"`
+	Address *string `discord:"address"`
+	Clans   *bool   `discord:"clans"`
+}
+
+// leaderboard lists the players or clans with the most playtime on the tracked servers of the guild.
+func (b *Bot) leaderboard(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+	var params LeaderboardParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	period := defaultPlaytimePeriod
+	if params.Period != nil {
+		period = *params.Period
+	}
+	since, until, err := model.ParsePlaytimePeriod(period, time.Now())
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	var (
+		address string
+		clans   = params.Clans != nil && *params.Clans
+	)
+	if params.Address != nil {
+		address = *params.Address
+	}
+
+	dao, closer, err := b.ConnDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer closer()
+
+	entries, err := dao.GetPlaytimeLeaderboard(ctx, data.Event.GuildID, address, clans, since, until, leaderboardEntries)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	l := i18n.FromContext(ctx)
+	subject := l.T("leaderboard.all_servers")
+	if address != "" {
+		subject = fmt.Sprintf("`%s`", address)
+	}
+	title := l.T("leaderboard.players", subject, period)
+	if clans {
+		title = l.T("leaderboard.clans", subject, period)
+	}
+
+	lb := model.PlaytimeLeaderboard{
+		Title:   title,
+		Entries: entries,
+	}
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(lb.Format(l)),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
@@ -0,0 +1,64 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
+)
+
+// value of the language option of /guild-settings that resets the language to the one of the guild
+const autoLanguage = "auto"
+
+// localize is a middleware that adds the localizer of the guild to the context of the interaction.
+// Outside of guilds the language of the user is used.
+func (b *Bot) localize(next cmdroute.InteractionHandler) cmdroute.InteractionHandler {
+	return cmdroute.InteractionHandlerFunc(func(ctx context.Context, e *discord.InteractionEvent) *api.InteractionResponse {
+		var l i18n.Localizer
+		if e.GuildID.IsValid() {
+			l = b.localizer(ctx, e.GuildID, e.GuildLocale)
+		} else {
+			l = i18n.New(string(e.Locale))
+		}
+		return next.HandleInteraction(i18n.NewContext(ctx, l), e)
+	})
+}
+
+// localizer returns the localizer of the language that is configured for the guild.
+// In case the guild did not configure any language, the preferred language of the guild is used.
+func (b *Bot) localizer(ctx context.Context, guildID discord.GuildID, preferred string) i18n.Localizer {
+	lang, ok := b.languages.Load(guildID)
+	if !ok {
+		dao, closer, err := b.ConnDAO(ctx)
+		if err != nil {
+			b.l.Errorf("failed to get language of guild %s: %v", guildID, err)
+			return i18n.New(preferred)
+		}
+		defer closer()
+
+		settings, err := dao.GetGuildSettings(ctx, guildID)
+		if err != nil {
+			b.l.Errorf("failed to get language of guild %s: %v", guildID, err)
+			return i18n.New(preferred)
+		}
+		lang = settings.Language
+		b.languages.Store(guildID, lang)
+	}
+
+	if lang != "" {
+		return i18n.New(string(lang))
+	}
+	return i18n.New(preferred)
+}
+
+// guildLocalizer returns the localizer of a guild for messages that are not a response to an interaction.
+func (b *Bot) guildLocalizer(ctx context.Context, guildID discord.GuildID) i18n.Localizer {
+	var preferred string
+	guild, err := b.state.Cabinet.Guild(guildID)
+	if err == nil {
+		preferred = guild.PreferredLocale
+	}
+	return b.localizer(ctx, guildID, preferred)
+}
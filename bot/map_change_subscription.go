@@ -0,0 +1,144 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+)
+
+type SubscribeMapParams struct {
+	Address string `discord:"address"`
+	Map     string `discord:"map"`
+	DM      *bool  `discord:"dm"`
+}
+
+type UnsubscribeMapParams struct {
+	Address string `discord:"address"`
+	Map     string `discord:"map"`
+}
+
+func (b *Bot) listMapSubscriptions(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+	dao, closer, err := b.ConnDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer closer()
+
+	subscriptions, err := dao.ListMapChangeSubscriptions(
+		ctx,
+		data.Event.GuildID,
+		optionalChannelID(data),
+		data.Event.SenderID(),
+	)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(subscriptions.Format(i18n.FromContext(ctx))),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
+
+func (b *Bot) subscribeMap(ctx context.Context, data cmdroute.CommandData) (resp *api.InteractionResponseData) {
+	var params SubscribeMapParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	err = model.ValidateMapPattern(params.Map)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	dao, closer, err := b.TxDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer func() {
+		err = closer(err)
+		if err != nil {
+			resp = errorResponse(ctx, err)
+		}
+	}()
+
+	tracking, err := dao.GetTrackingByAddress(ctx, data.Event.GuildID, optionalChannelID(data), params.Address)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	s := model.MapChangeSubscription{
+		MessageUserTarget: model.MessageUserTarget{
+			MessageTarget: tracking.MessageTarget,
+			UserID:        data.Event.SenderID(),
+		},
+		Address: tracking.Address,
+		Pattern: params.Map,
+		DM:      params.DM != nil && *params.DM,
+	}
+
+	err = dao.AddMapChangeSubscription(ctx, s)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	l := i18n.FromContext(ctx)
+	msg := l.T("map_subscription.added", s.Format(l))
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(msg),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
+
+func (b *Bot) unsubscribeMap(ctx context.Context, data cmdroute.CommandData) (resp *api.InteractionResponseData) {
+	var params UnsubscribeMapParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	dao, closer, err := b.TxDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer func() {
+		err = closer(err)
+		if err != nil {
+			resp = errorResponse(ctx, err)
+		}
+	}()
+
+	tracking, err := dao.GetTrackingByAddress(ctx, data.Event.GuildID, optionalChannelID(data), params.Address)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	s := model.MapChangeSubscription{
+		MessageUserTarget: model.MessageUserTarget{
+			MessageTarget: tracking.MessageTarget,
+			UserID:        data.Event.SenderID(),
+		},
+		Address: tracking.Address,
+		Pattern: params.Map,
+	}
+
+	err = dao.RemoveMapChangeSubscription(ctx, s)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	msg := i18n.FromContext(ctx).T("map_subscription.removed", s.Pattern, s.Address)
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(msg),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
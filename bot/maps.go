@@ -0,0 +1,76 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+)
+
+const (
+	defaultMapsRange = "7d"
+	// keeps the response below the message size limit of discord
+	listMaps = 8
+)
+
+type MapsParams struct {
+	Address string  `discord:"address"`
+	Range   *string `discord:"range"`
+}
+
+// maps lists the recently played and the most popular maps of a tracked server.
+func (b *Bot) maps(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+	var params MapsParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	rangeName := defaultMapsRange
+	if params.Range != nil {
+		rangeName = *params.Range
+	}
+	duration, err := model.ParseHistoryRange(rangeName)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	dao, closer, err := b.ConnDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer closer()
+
+	tracking, err := dao.GetTrackingByAddress(ctx, data.Event.GuildID, optionalChannelID(data), params.Address)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	recent, err := dao.ListMapSessions(ctx, tracking.Address, listMaps)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	popular, err := dao.GetPopularMaps(ctx, tracking.Address, time.Now().Add(-duration), listMaps)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	l := i18n.FromContext(ctx)
+	msg := l.T("maps.summary",
+		tracking.Address,
+		recent.Format(l),
+		rangeName,
+		popular.Format(l),
+	)
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(msg),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
@@ -1,9 +1,56 @@
 package bot
 
 import (
+	"errors"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/gateway"
 )
 
+// discord refuses to delete messages in bulk that are older than two weeks,
+// the margin accounts for the time it takes to send the request.
+const bulkDeleteMaxAge = 14*24*time.Hour - time.Hour
+
+// deleteMessages deletes the messages of a channel and returns the number of deleted messages.
+// Recent messages are deleted in bulk, older messages one by one.
+func (b *Bot) deleteMessages(channelID discord.ChannelID, messageIDs []discord.MessageID, reason api.AuditLogReason) (int, error) {
+	var (
+		oldest  = time.Now().Add(-bulkDeleteMaxAge)
+		recent  = make([]discord.MessageID, 0, len(messageIDs))
+		old     = make([]discord.MessageID, 0, len(messageIDs))
+		deleted = 0
+		errs    []error
+	)
+	for _, id := range messageIDs {
+		if id.Time().After(oldest) {
+			recent = append(recent, id)
+		} else {
+			old = append(old, id)
+		}
+	}
+
+	err := b.state.DeleteMessages(channelID, recent, reason)
+	if err != nil && !ErrIsNotFound(err) {
+		errs = append(errs, err)
+	} else if err == nil {
+		deleted += len(recent)
+	}
+
+	for _, id := range old {
+		err = b.state.DeleteMessage(channelID, id, reason)
+		if err != nil {
+			if !ErrIsNotFound(err) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		deleted++
+	}
+	return deleted, errors.Join(errs...)
+}
+
 func (b *Bot) handleMessageDeletion(e *gateway.MessageDeleteEvent) {
 	dao, closer, err := b.TxDAO(b.ctx)
 	if err != nil {
@@ -23,4 +70,9 @@ func (b *Bot) handleMessageDeletion(e *gateway.MessageDeleteEvent) {
 		b.l.Errorf("failed to remove tracking of guild %s and message id: %s: %v", e.GuildID, e.ID, err)
 	}
 
+	err = dao.RemoveTopServersTrackingByMessageID(b.ctx, e.GuildID, e.ID)
+	if err != nil {
+		b.l.Errorf("failed to remove top servers tracking of guild %s and message id: %s: %v", e.GuildID, e.ID, err)
+	}
+
 }
@@ -0,0 +1,90 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// managerCommands change the state of a guild, which is why they are restricted to
+// administrators and members with the bot manager role of the guild.
+// All other user commands only read data and are available to everyone.
+// Discord cannot restrict commands to a role that is stored in the database, which is why
+// these commands are visible to everyone and checked by the managerOnly middleware.
+var managerCommands = map[string]bool{
+	"add-channel":              true,
+	"remove-channel":           true,
+	"add-flag-mapping":         true,
+	"remove-flag-mapping":      true,
+	"add-tracking":             true,
+	"add-role-notification":    true,
+	"remove-role-notification": true,
+	"add-status-alert":         true,
+	"remove-status-alert":      true,
+	"list-webhooks":            true, // contains the urls of the endpoints
+	"add-webhook":              true,
+	"remove-webhook":           true,
+	"add-weekly-heatmap":       true,
+	"remove-weekly-heatmap":    true,
+	"set-weekly-digest":        true,
+	"remove-weekly-digest":     true,
+	"add-top-servers":          true,
+	"add-discovery-rule":       true,
+	"remove-discovery-rule":    true,
+	"guild-settings":           true, // the bot manager role is additionally restricted to administrators
+	"start":                    true,
+	"stop":                     true,
+}
+
+// managerOnly is a middleware that rejects manager commands of users that are neither
+// administrators nor have the bot manager role of the guild.
+func (b *Bot) managerOnly(next cmdroute.InteractionHandler) cmdroute.InteractionHandler {
+	return cmdroute.InteractionHandlerFunc(func(ctx context.Context, e *discord.InteractionEvent) *api.InteractionResponse {
+		data, ok := e.Data.(*discord.CommandInteraction)
+		if !ok || !managerCommands[data.Name] {
+			return next.HandleInteraction(ctx, e)
+		}
+
+		ok, err := b.isManager(ctx, e)
+		if err != nil {
+			return &api.InteractionResponse{
+				Type: api.MessageInteractionWithSource,
+				Data: errorResponse(ctx, err),
+			}
+		}
+		if !ok {
+			return &api.InteractionResponse{
+				Type: api.MessageInteractionWithSource,
+				Data: ErrAccessForbidden(ctx),
+			}
+		}
+		return next.HandleInteraction(ctx, e)
+	})
+}
+
+// isManager returns true in case the sender of the interaction is an administrator
+// or has the bot manager role of the guild.
+func (b *Bot) isManager(ctx context.Context, e *discord.InteractionEvent) (bool, error) {
+	if !e.GuildID.IsValid() || e.Member == nil {
+		return false, nil
+	}
+
+	ok, err := b.isAdministrator(e)
+	if err != nil || ok {
+		return ok, err
+	}
+
+	dao, closer, err := b.ConnDAO(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer closer()
+
+	settings, err := dao.GetGuildSettings(ctx, e.GuildID)
+	if err != nil {
+		return false, err
+	}
+	return settings.IsManager(e.Member), nil
+}
@@ -1,40 +1,102 @@
 package bot
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"log"
+	"strconv"
 
-	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
 	d "github.com/jxsl13/twstatus-bot/dao"
+	"github.com/jxsl13/twstatus-bot/i18n"
 	"github.com/jxsl13/twstatus-bot/model"
 )
 
-func (b *Bot) handleAddPlayerCountNotificationRequest(e *gateway.MessageReactionAddEvent) {
-	val, found := model.ReactionPlayerCountNotificationMap[e.Emoji.APIString()]
-	if !found {
-		return
+const (
+	notifyComponentPrefix  = "notify"
+	detailsComponentPrefix = "details"
+	// value of the notify select menu that removes the notification request
+	notifyOffValue = "0"
+	// total character limit of all embeds of a message
+	maxEmbedsLength = 6000
+)
+
+// statusComponents are attached to every status message.
+// Offline servers cannot be joined, which is why the join button is omitted for them.
+func statusComponents(l i18n.Localizer, status model.ServerStatus) discord.ContainerComponents {
+	options := make([]discord.SelectOption, 0, len(model.PlayerCountNotificationThresholds)+1)
+	for _, t := range model.PlayerCountNotificationThresholds {
+		label := l.T("notify.at_least", t)
+		if t == 1 {
+			label = l.T("notify.at_least_one")
+		}
+		options = append(options, discord.SelectOption{
+			Label: label,
+			Value: strconv.Itoa(t),
+		})
+	}
+	options = append(options, discord.SelectOption{
+		Label:       l.T("notify.off"),
+		Value:       notifyOffValue,
+		Description: l.T("notify.off_description"),
+	})
+
+	components := discord.ContainerComponents{
+		&discord.ActionRowComponent{
+			&discord.StringSelectComponent{
+				CustomID:    componentID(notifyComponentPrefix),
+				Placeholder: l.T("notify.placeholder"),
+				Options:     options,
+			},
+		},
+	}
+	if status.Address == "" {
+		return components
+	}
+
+	return append(components, &discord.ActionRowComponent{
+		&discord.ButtonComponent{
+			Style: discord.LinkButtonStyle(model.ConnectURL(status.Address)),
+			Label: l.T("button.join"),
+		},
+		&discord.ButtonComponent{
+			Style:    discord.SecondaryButtonStyle(),
+			CustomID: componentID(detailsComponentPrefix, status.Address),
+			Label:    l.T("button.details"),
+		},
+	})
+}
+
+// notifyComponent requests a notification for the user when the server of the status message
+// reaches the selected number of players. A user has at most one request per message.
+func (b *Bot) notifyComponent(ctx context.Context, data cmdroute.ComponentData, _ string) *api.InteractionResponse {
+	selection, ok := data.ComponentInteraction.(*discord.StringSelectInteraction)
+	if !ok || len(selection.Values) != 1 || data.Event.Message == nil {
+		return componentErrorResponse(ctx, fmt.Errorf("invalid notification request: %s", data.ID()))
+	}
+	threshold, err := strconv.Atoi(selection.Values[0])
+	if err != nil {
+		return componentErrorResponse(ctx, fmt.Errorf("invalid threshold: %w", err))
 	}
 
 	userTarget := model.MessageUserTarget{
-		UserID: e.UserID,
+		UserID: data.Event.SenderID(),
 		MessageTarget: model.MessageTarget{
 			ChannelTarget: model.ChannelTarget{
-				GuildID:   e.GuildID,
-				ChannelID: e.ChannelID,
+				GuildID:   data.Event.GuildID,
+				ChannelID: data.Event.ChannelID,
 			},
-			MessageID: e.MessageID,
+			MessageID: data.Event.Message.ID,
 		},
 	}
 
-	n := model.PlayerCountNotificationRequest{
-		MessageUserTarget: userTarget,
-		Threshold:         val,
-	}
-
-	dao, closer, err := b.TxDAO(b.ctx)
+	dao, closer, err := b.TxDAO(ctx)
 	if err != nil {
-		b.l.Errorf("failed to get transaction queries for player count notification: %v", err)
-		return
+		return componentErrorResponse(ctx, err)
 	}
 	defer func() {
 		err = closer(err)
@@ -43,81 +105,89 @@ func (b *Bot) handleAddPlayerCountNotificationRequest(e *gateway.MessageReaction
 		}
 	}()
 
-	pcn, err := dao.GetPlayerCountNotificationRequest(b.ctx, userTarget)
-	if err != nil {
-		// not found, just insert
+	var (
+		l   = i18n.FromContext(ctx)
+		msg string
+	)
+	if selection.Values[0] == notifyOffValue {
+		var n model.PlayerCountNotificationRequest
+		n, err = dao.GetPlayerCountNotificationRequest(ctx, userTarget)
 		if errors.Is(err, d.ErrNotFound) {
-			err = dao.SetPlayerCountNotificationRequest(b.ctx, n)
-			if err != nil {
-				b.l.Errorf("failed to set player count notification(%s -> %s): %v", n.MessageTarget, n.UserID, err)
-				return
-			}
-			log.Printf("added %d player count notification for user %s and message %s", n.Threshold, e.UserID, n.MessageTarget)
-			return
-		} else {
-			b.l.Errorf("failed to get player count notification(%s -> %s): %v", n.MessageTarget, n.UserID, err)
-			return
+			err = nil
+			return ephemeralComponentResponse(l.T("notify.not_requested"))
+		} else if err != nil {
+			return componentErrorResponse(ctx, err)
 		}
-	}
 
-	// already exists, update
-	if pcn.Threshold == n.Threshold {
-		return
+		err = dao.RemovePlayerCountNotificationRequest(ctx, n)
+		if err != nil {
+			return componentErrorResponse(ctx, err)
+		}
+		log.Printf("removed %d player count notification for user %s and message %s", n.Threshold, n.UserID, n.MessageTarget)
+		msg = l.T("notify.removed")
+	} else {
+		n := model.PlayerCountNotificationRequest{
+			MessageUserTarget: userTarget,
+			Threshold:         threshold,
+		}
+		err = dao.SetPlayerCountNotificationRequest(ctx, n)
+		if err != nil {
+			return componentErrorResponse(ctx, i18n.WrapError(err, "error.message_not_tracked"))
+		}
+		log.Printf("added %d player count notification for user %s and message %s", n.Threshold, n.UserID, n.MessageTarget)
+		msg = l.T("notify.added", threshold)
 	}
 
-	prevEmoji, ok := model.ReactionPlayerCountNotificationReverseMap[pcn.Threshold]
-	if !ok {
-		panic("failed to get emoji for player count notification: map must contain value to emoji mapping")
-	}
+	return ephemeralComponentResponse(msg)
+}
 
-	err = b.state.DeleteUserReaction(e.ChannelID, e.MessageID, e.UserID, prevEmoji)
+// detailsComponent shows the full player list of the server, which may be truncated in the status message.
+func (b *Bot) detailsComponent(ctx context.Context, data cmdroute.ComponentData, address string) *api.InteractionResponse {
+	dao, closer, err := b.ConnDAO(ctx)
 	if err != nil {
-		b.l.Errorf("failed to delete previous reaction: %v", err)
-		return
+		return componentErrorResponse(ctx, err)
 	}
+	defer closer()
 
-	err = dao.SetPlayerCountNotificationRequest(b.ctx, n)
+	status, err := dao.GetServerStatus(ctx, address, data.Event.ChannelID)
 	if err != nil {
-		b.l.Errorf("failed to set player count notification(%s -> %s): %v", n.MessageTarget, n.UserID, err)
-		return
+		return componentErrorResponse(ctx, err)
 	}
 
-	log.Printf("added player count notification for user %s and message %s", e.UserID, n.MessageTarget)
-}
-
-func (b *Bot) handleRemovePlayerCountNotificationRequest(e *gateway.MessageReactionRemoveEvent) {
-	val, found := model.ReactionPlayerCountNotificationMap[e.Emoji.APIString()]
-	if !found || b.userID == e.UserID {
-		return
-	}
-
-	userTarget := model.MessageUserTarget{
-		UserID: e.UserID,
-		MessageTarget: model.MessageTarget{
-			ChannelTarget: model.ChannelTarget{
-				GuildID:   e.GuildID,
-				ChannelID: e.ChannelID,
-			},
-			MessageID: e.MessageID,
-		},
+	var (
+		header = status.Header()
+		lines  = status.ClientLines()
+		embeds = make([]discord.Embed, 0, 2)
+		length = len(header)
+	)
+	for _, page := range splitLines(lines, 4096) {
+		if length+len(page) > maxEmbedsLength {
+			break
+		}
+		embeds = append(embeds, discord.Embed{Description: page})
+		length += len(page)
 	}
-
-	n := model.PlayerCountNotificationRequest{
-		MessageUserTarget: userTarget,
-		Threshold:         val,
+	if len(lines) == 0 {
+		embeds = append(embeds, discord.Embed{Description: i18n.FromContext(ctx).T("status.no_players")})
 	}
 
-	dao, closer, err := b.ConnDAO(b.ctx)
-	if err != nil {
-		b.l.Errorf("failed to get connection queries for player count notification: %v", err)
-		return
+	return &api.InteractionResponse{
+		Type: api.MessageInteractionWithSource,
+		Data: &api.InteractionResponseData{
+			Content:         option.NewNullableString(fmt.Sprintf("%s\n%s", header, status.Details(i18n.FromContext(ctx)))),
+			Embeds:          &embeds,
+			Flags:           discord.EphemeralMessage,
+			AllowedMentions: &api.AllowedMentions{ /* none */ },
+		},
 	}
-	defer closer()
+}
 
-	err = dao.RemovePlayerCountNotificationRequest(b.ctx, n)
-	if err != nil {
-		b.l.Errorf("failed to remove player count notification(%s -> %s): %v", n.MessageTarget, n.UserID, err)
-		return
+func ephemeralComponentResponse(msg string) *api.InteractionResponse {
+	return &api.InteractionResponse{
+		Type: api.MessageInteractionWithSource,
+		Data: &api.InteractionResponseData{
+			Content: option.NewNullableString(msg),
+			Flags:   discord.EphemeralMessage,
+		},
 	}
-	log.Printf("removed %d player count notification for user %s and message %s", val, e.UserID, n.MessageTarget)
 }
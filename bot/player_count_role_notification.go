@@ -0,0 +1,140 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+)
+
+const defaultRoleNotificationCooldown = time.Hour
+
+type AddRoleNotificationParams struct {
+	Role      discord.RoleID `discord:"role"`
+	Address   string         `discord:"address"`
+	Threshold int            `discord:"threshold"`
+	Cooldown  *int           `discord:"cooldown"` // minutes
+}
+
+type RemoveRoleNotificationParams struct {
+	Role    discord.RoleID `discord:"role"`
+	Address string         `discord:"address"`
+}
+
+func (b *Bot) listRoleNotifications(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+	dao, closer, err := b.ConnDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer closer()
+
+	notifications, err := dao.ListPlayerCountRoleNotifications(
+		ctx,
+		data.Event.GuildID,
+		optionalChannelID(data),
+	)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(notifications.Format(i18n.FromContext(ctx))),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
+
+func (b *Bot) addRoleNotification(ctx context.Context, data cmdroute.CommandData) (resp *api.InteractionResponseData) {
+	var params AddRoleNotificationParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	cooldown := defaultRoleNotificationCooldown
+	if params.Cooldown != nil {
+		cooldown = time.Duration(*params.Cooldown) * time.Minute
+	}
+
+	dao, closer, err := b.TxDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer func() {
+		err = closer(err)
+		if err != nil {
+			resp = errorResponse(ctx, err)
+		}
+	}()
+
+	tracking, err := dao.GetTrackingByAddress(ctx, data.Event.GuildID, optionalChannelID(data), params.Address)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	n := model.PlayerCountRoleNotification{
+		MessageTarget: tracking.MessageTarget,
+		Address:       tracking.Address,
+		RoleID:        params.Role,
+		Threshold:     params.Threshold,
+		Cooldown:      cooldown,
+	}
+
+	err = dao.SetPlayerCountRoleNotification(ctx, n)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	l := i18n.FromContext(ctx)
+	msg := l.T("role_notification.added", n.Format(l))
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(msg),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
+
+func (b *Bot) removeRoleNotification(ctx context.Context, data cmdroute.CommandData) (resp *api.InteractionResponseData) {
+	var params RemoveRoleNotificationParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	dao, closer, err := b.TxDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer func() {
+		err = closer(err)
+		if err != nil {
+			resp = errorResponse(ctx, err)
+		}
+	}()
+
+	tracking, err := dao.GetTrackingByAddress(ctx, data.Event.GuildID, optionalChannelID(data), params.Address)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	err = dao.RemovePlayerCountRoleNotification(ctx, model.PlayerCountRoleNotification{
+		MessageTarget: tracking.MessageTarget,
+		Address:       tracking.Address,
+		RoleID:        params.Role,
+	})
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	msg := i18n.FromContext(ctx).T("role_notification.removed", params.Role.Mention(), tracking.Address)
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(msg),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
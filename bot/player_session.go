@@ -0,0 +1,88 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/markdown"
+)
+
+const (
+	seenSessions = 5
+	listSessions = 15
+)
+
+type SeenParams struct {
+	Name string `discord:"name"`
+}
+
+type SessionsParams struct {
+	Address string `discord:"address"`
+}
+
+// seen shows when and where a player was last online on any server tracked by the guild.
+func (b *Bot) seen(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+	var params SeenParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	dao, closer, err := b.ConnDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer closer()
+
+	sessions, err := dao.GetLastPlayerSessions(ctx, data.Event.GuildID, params.Name, seenSessions)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	l := i18n.FromContext(ctx)
+	msg := l.T("player_session.not_seen", markdown.Escape(params.Name))
+	if len(sessions) > 0 {
+		msg = sessions.Format(l)
+	}
+
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(msg),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
+
+// sessions lists the most recent player sessions of a tracked server.
+func (b *Bot) sessions(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+	var params SessionsParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	dao, closer, err := b.ConnDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer closer()
+
+	tracking, err := dao.GetTrackingByAddress(ctx, data.Event.GuildID, optionalChannelID(data), params.Address)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	sessions, err := dao.ListPlayerSessions(ctx, tracking.Address, listSessions)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(sessions.Format(i18n.FromContext(ctx))),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
@@ -0,0 +1,66 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/jxsl13/twstatus-bot/i18n"
+)
+
+type ServerInfoParams struct {
+	Address string `discord:"address"`
+}
+
+// serverInfo previews the current status of any server of the master server the same way
+// as the status messages of tracked servers.
+func (b *Bot) serverInfo(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+	var params ServerInfoParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	dao, closer, err := b.ConnDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer closer()
+
+	status, err := dao.GetServerStatus(ctx, params.Address, data.Event.ChannelID)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	var (
+		content string
+		embeds  = []discord.Embed{}
+		l       = i18n.FromContext(ctx)
+	)
+	if b.useEmbeds {
+		content = status.Header()
+		embeds = status.ToEmbeds(l)
+	} else {
+		content = status.Message(l)
+	}
+	content = fmt.Sprintf("%s\n%s", content, status.Details(l))
+
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(content),
+		Embeds:          &embeds,
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+		Components: &discord.ContainerComponents{
+			&discord.ActionRowComponent{
+				&discord.ButtonComponent{
+					Style:    discord.SuccessButtonStyle(),
+					CustomID: componentID(trackServerComponentPrefix, status.Address),
+					Label:    l.T("button.track"),
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,209 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+)
+
+const (
+	searchServersComponentPrefix = "search-servers"
+	trackServerComponentPrefix   = "track-server"
+	// searches are kept in memory for paging
+	serverSearchExpiration = time.Hour
+)
+
+var errSearchExpired = i18n.NewError("error.search_expired")
+
+type cachedServerSearch struct {
+	Search model.ServerSearch
+	Until  time.Time
+}
+
+type SearchServersParams struct {
+	Name       *string `discord:"name"`
+	Gametype   *string `discord:"gametype"`
+	Map        *string `discord:"map"`
+	Version    *string `discord:"version"`
+	Region     *string `discord:"region"`
+	Passworded *bool   `discord:"passworded"`
+	MinPlayers *int    `discord:"min-players"`
+}
+
+func (p *SearchServersParams) ToSearch() model.ServerSearch {
+	value := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return strings.TrimSpace(*s)
+	}
+	search := model.ServerSearch{
+		Name:       value(p.Name),
+		Gametype:   value(p.Gametype),
+		Map:        value(p.Map),
+		Version:    value(p.Version),
+		Region:     value(p.Region),
+		Passworded: p.Passworded,
+	}
+	if p.MinPlayers != nil {
+		search.MinPlayers = *p.MinPlayers
+	}
+	return search
+}
+
+// searchServers searches all servers of the master server, not only tracked servers.
+// The search is cached in order to show other pages of the result.
+func (b *Bot) searchServers(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+	var params SearchServersParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	searchID := data.Event.ID.String()
+	search := params.ToSearch()
+	b.searches.Store(searchID, cachedServerSearch{
+		Search: search,
+		Until:  time.Now().Add(serverSearchExpiration),
+	})
+
+	resp, err := b.searchServersPage(ctx, searchID, search, 0)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	return resp
+}
+
+// searchServersComponent shows another page of a cached search.
+// The arguments contain the id of the search and the page.
+func (b *Bot) searchServersComponent(ctx context.Context, data cmdroute.ComponentData, args string) *api.InteractionResponse {
+	searchID, pageArg, found := strings.Cut(args, componentIDSeparator)
+	if !found {
+		return componentErrorResponse(ctx, fmt.Errorf("invalid component id: %s", data.ID()))
+	}
+	page, err := strconv.Atoi(pageArg)
+	if err != nil {
+		return componentErrorResponse(ctx, fmt.Errorf("invalid page: %w", err))
+	}
+
+	cached, ok := b.searches.Load(searchID)
+	if !ok || time.Now().After(cached.Until) {
+		return componentErrorResponse(ctx, errSearchExpired)
+	}
+
+	resp, err := b.searchServersPage(ctx, searchID, cached.Search, page)
+	if err != nil {
+		return componentErrorResponse(ctx, err)
+	}
+	return &api.InteractionResponse{
+		Type: api.UpdateMessage,
+		Data: resp,
+	}
+}
+
+// searchServersPage searches the servers and returns the requested page of the result.
+// Servers may appear or disappear between two pages, so the page is clamped to the available pages.
+func (b *Bot) searchServersPage(ctx context.Context, searchID string, search model.ServerSearch, page int) (*api.InteractionResponseData, error) {
+	dao, closer, err := b.ConnDAO(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer closer()
+
+	servers, err := dao.SearchServers(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	page, found := servers.Page(page)
+	numPages := servers.NumPages()
+
+	l := i18n.FromContext(ctx)
+	footer := l.T("search.footer", page+1, numPages, len(servers))
+	if len(servers) == model.MaxFoundServers {
+		footer = l.T("search.footer_more", page+1, numPages, len(servers))
+	}
+	embed := discord.Embed{
+		Title:       l.T("search.title"),
+		Description: search.Format(l),
+		Fields:      make([]discord.EmbedField, 0, len(found)),
+		Footer: &discord.EmbedFooter{
+			Text: footer,
+		},
+	}
+	if len(found) == 0 {
+		embed.Description += "\n\n" + l.T("search.none")
+	}
+
+	trackButtons := make(discord.ActionRowComponent, 0, len(found))
+	for i, server := range found {
+		embed.Fields = append(embed.Fields, server.ToEmbedField(l, i+1))
+		trackButtons = append(trackButtons, &discord.ButtonComponent{
+			Style:    discord.SuccessButtonStyle(),
+			CustomID: componentID(trackServerComponentPrefix, server.Address),
+			Label:    l.T("button.track_n", i+1),
+		})
+	}
+
+	pageID := func(p int) discord.ComponentID {
+		return componentID(searchServersComponentPrefix, searchID, strconv.Itoa(p))
+	}
+	components := discord.ContainerComponents{
+		&discord.ActionRowComponent{
+			&discord.ButtonComponent{
+				Style:    discord.SecondaryButtonStyle(),
+				CustomID: pageID(page - 1),
+				Label:    l.T("button.previous"),
+				Disabled: page == 0,
+			},
+			&discord.ButtonComponent{
+				Style:    discord.SecondaryButtonStyle(),
+				CustomID: pageID(page + 1),
+				Label:    l.T("button.next"),
+				Disabled: page == numPages-1,
+			},
+		},
+	}
+	if len(trackButtons) > 0 {
+		components = append(components, &trackButtons)
+	}
+
+	return &api.InteractionResponseData{
+		Embeds:          &[]discord.Embed{embed},
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+		Components:      &components,
+	}, nil
+}
+
+// trackServerComponent tracks the server of the component id in the channel of the interaction.
+// Like /add-tracking it requires administrator permissions or the bot manager role.
+func (b *Bot) trackServerComponent(ctx context.Context, data cmdroute.ComponentData, address string) *api.InteractionResponse {
+	if !data.Event.GuildID.IsValid() {
+		return componentErrorResponse(ctx, i18n.NewError("error.track_in_guild_only"))
+	}
+
+	ok, err := b.isManager(ctx, data.Event)
+	if err != nil {
+		return componentErrorResponse(ctx, err)
+	}
+	if !ok {
+		return &api.InteractionResponse{
+			Type: api.MessageInteractionWithSource,
+			Data: ErrAccessForbidden(ctx),
+		}
+	}
+
+	return &api.InteractionResponse{
+		Type: api.MessageInteractionWithSource,
+		Data: b.trackServers(ctx, data.Event.GuildID, data.Event.ChannelID, []string{address}),
+	}
+}
@@ -0,0 +1,149 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+)
+
+const defaultStatusAlertDebounce = time.Minute
+
+type AddStatusAlertParams struct {
+	Address      string             `discord:"address"`
+	AlertChannel *discord.ChannelID `discord:"alert-channel"`
+	DM           *bool              `discord:"dm"`
+	Debounce     *int               `discord:"debounce"` // minutes
+}
+
+type RemoveStatusAlertParams struct {
+	Address string `discord:"address"`
+}
+
+func (b *Bot) listStatusAlerts(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+	dao, closer, err := b.ConnDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer closer()
+
+	alerts, err := dao.ListServerStatusAlerts(
+		ctx,
+		data.Event.GuildID,
+		optionalChannelID(data),
+	)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(alerts.Format(i18n.FromContext(ctx))),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
+
+func (b *Bot) addStatusAlert(ctx context.Context, data cmdroute.CommandData) (resp *api.InteractionResponseData) {
+	var params AddStatusAlertParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	debounce := defaultStatusAlertDebounce
+	if params.Debounce != nil {
+		debounce = time.Duration(*params.Debounce) * time.Minute
+	}
+
+	var (
+		alertChannelID discord.ChannelID
+		alertUserID    discord.UserID
+	)
+	if params.AlertChannel != nil {
+		alertChannelID = *params.AlertChannel
+	}
+	if params.DM != nil && *params.DM {
+		alertUserID = data.Event.SenderID()
+	}
+	if alertChannelID == 0 && alertUserID == 0 {
+		// neither channel nor dm provided -> alert the channel the command was used in
+		alertChannelID = data.Event.ChannelID
+	}
+
+	dao, closer, err := b.TxDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer func() {
+		err = closer(err)
+		if err != nil {
+			resp = errorResponse(ctx, err)
+		}
+	}()
+
+	tracking, err := dao.GetTrackingByAddress(ctx, data.Event.GuildID, optionalChannelID(data), params.Address)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	alert := model.ServerStatusAlertSetting{
+		Tracking:       tracking,
+		AlertChannelID: alertChannelID,
+		AlertUserID:    alertUserID,
+		Debounce:       debounce,
+	}
+
+	err = dao.SetServerStatusAlert(ctx, alert)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	l := i18n.FromContext(ctx)
+	msg := l.T("status_alert.added", alert.Format(l))
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(msg),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
+
+func (b *Bot) removeStatusAlert(ctx context.Context, data cmdroute.CommandData) (resp *api.InteractionResponseData) {
+	var params RemoveStatusAlertParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	dao, closer, err := b.TxDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer func() {
+		err = closer(err)
+		if err != nil {
+			resp = errorResponse(ctx, err)
+		}
+	}()
+
+	tracking, err := dao.GetTrackingByAddress(ctx, data.Event.GuildID, optionalChannelID(data), params.Address)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	err = dao.RemoveServerStatusAlert(ctx, tracking)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	msg := i18n.FromContext(ctx).T("status_alert.removed", tracking.Address)
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(msg),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
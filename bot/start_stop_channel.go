@@ -2,24 +2,24 @@ package bot
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/diamondburned/arikawa/v3/api"
 	"github.com/diamondburned/arikawa/v3/api/cmdroute"
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/jxsl13/twstatus-bot/i18n"
 )
 
 // optional channel id parameter
 func (b *Bot) startChannel(ctx context.Context, data cmdroute.CommandData) (resp *api.InteractionResponseData) {
 	dao, closer, err := b.TxDAO(ctx)
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 	defer func() {
 		err = closer(err)
 		if err != nil {
-			resp = errorResponse(err)
+			resp = errorResponse(ctx, err)
 		}
 	}()
 
@@ -29,10 +29,10 @@ func (b *Bot) startChannel(ctx context.Context, data cmdroute.CommandData) (resp
 		optionalChannelID(data),
 	)
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 
-	msg := fmt.Sprintf("Started channel: %s", channel)
+	msg := i18n.FromContext(ctx).T("channel.started", channel)
 	return &api.InteractionResponseData{
 		Content: option.NewNullableString(msg),
 		Flags:   discord.EphemeralMessage,
@@ -43,12 +43,12 @@ func (b *Bot) startChannel(ctx context.Context, data cmdroute.CommandData) (resp
 func (b *Bot) stopChannel(ctx context.Context, data cmdroute.CommandData) (resp *api.InteractionResponseData) {
 	dao, closer, err := b.TxDAO(ctx)
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 	defer func() {
 		err = closer(err)
 		if err != nil {
-			resp = errorResponse(err)
+			resp = errorResponse(ctx, err)
 		}
 	}()
 
@@ -57,10 +57,10 @@ func (b *Bot) stopChannel(ctx context.Context, data cmdroute.CommandData) (resp
 		optionalChannelID(data),
 	)
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 
-	msg := fmt.Sprintf("Stopped channel: %s", channel)
+	msg := i18n.FromContext(ctx).T("channel.stopped", channel)
 	return &api.InteractionResponseData{
 		Content: option.NewNullableString(msg),
 		Flags:   discord.EphemeralMessage,
@@ -0,0 +1,104 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/diamondburned/arikawa/v3/utils/sendpart"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+)
+
+const (
+	defaultHistoryRange = "24h"
+	chartWidth          = 1000
+	chartHeight         = 400
+)
+
+type StatsParams struct {
+	Address string  `discord:"address"`
+	Range   *string `discord:"range"`
+}
+
+// stats renders the player count history of a tracked server as png image.
+// The deferred response is replaced by the chart as soon as it is rendered.
+func (b *Bot) stats(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+	return b.deferEdit(ctx, data.Event, func(ctx context.Context) *api.InteractionResponseData {
+		return b.renderStats(ctx, data)
+	})
+}
+
+func (b *Bot) renderStats(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+	l := i18n.FromContext(ctx)
+
+	var params StatsParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	rangeName := defaultHistoryRange
+	if params.Range != nil {
+		rangeName = *params.Range
+	}
+	duration, err := model.ParseHistoryRange(rangeName)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	dao, closer, err := b.ConnDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer closer()
+
+	tracking, err := dao.GetTrackingByAddress(ctx, data.Event.GuildID, optionalChannelID(data), params.Address)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	// time axis labels are shown in the time zone of the user
+	settings, err := dao.GetUserNotificationSettings(ctx, data.Event.SenderID())
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	to := time.Now()
+	from := to.Add(-duration)
+	history, err := dao.GetServerHistory(ctx, tracking.Address, from)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	if len(history.Samples) == 0 {
+		return errorResponse(ctx, i18n.NewError("stats.no_history", tracking.Address))
+	}
+
+	// the chart font only contains ASCII glyphs, the localized text is part of the content
+	title := fmt.Sprintf("%s - last %s", tracking.Address, rangeName)
+	lc := history.LineChart(title, from, to, settings.Location)
+
+	buf := &bytes.Buffer{}
+	err = lc.WritePNG(buf, chartWidth, chartHeight)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	msg := l.T("stats.caption", tracking.Address, rangeName, settings.Location)
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(msg),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+		Files: []sendpart.File{
+			{
+				Name:   "stats.png",
+				Reader: buf,
+			},
+		},
+	}
+}
@@ -0,0 +1,171 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+)
+
+type TopServersParams struct {
+	Gametype *string `discord:"gametype"`
+	Count    *int    `discord:"count"`
+}
+
+func (p *TopServersParams) ToFilter() model.TopServersFilter {
+	filter := model.TopServersFilter{
+		Count: model.DefaultTopServers,
+	}
+	if p.Gametype != nil {
+		filter.Gametype = strings.TrimSpace(*p.Gametype)
+	}
+	if p.Count != nil {
+		filter.Count = max(1, min(*p.Count, model.MaxTopServers))
+	}
+	return filter
+}
+
+// topServers lists the most populated servers of the current server list of the master server.
+func (b *Bot) topServers(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+	var params TopServersParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	dao, closer, err := b.ConnDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer closer()
+
+	top, err := dao.GetTopServers(ctx, params.ToFilter())
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return &api.InteractionResponseData{
+		Embeds:          &[]discord.Embed{top.ToEmbed(i18n.FromContext(ctx))},
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
+
+// addTopServers sends an initial message to the channel which is updated
+// whenever the ranking of the most populated servers changes.
+func (b *Bot) addTopServers(ctx context.Context, data cmdroute.CommandData) (resp *api.InteractionResponseData) {
+	var params TopServersParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	var (
+		filter    = params.ToFilter()
+		channelID = optionalChannelID(data)
+		l         = i18n.FromContext(ctx)
+	)
+	msg, err := b.state.SendMessage(channelID, l.T("top_servers.initial", strings.ToLower(filter.Title(l))))
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = b.state.DeleteMessage(
+				channelID,
+				msg.ID,
+				api.AuditLogReason("failed to add top servers tracking"),
+			)
+		}
+	}()
+
+	dao, closer, err := b.TxDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer func() {
+		err = closer(err)
+		if err != nil {
+			resp = errorResponse(ctx, err)
+		}
+	}()
+
+	err = dao.AddTopServersTracking(ctx, model.TopServersTracking{
+		MessageTarget: model.MessageTarget{
+			ChannelTarget: model.ChannelTarget{
+				GuildID:   data.Event.GuildID,
+				ChannelID: channelID,
+			},
+			MessageID: msg.ID,
+		},
+		TopServersFilter: filter,
+	})
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(l.T("top_servers.added", strings.ToLower(filter.Title(l)), channelID)),
+		Flags:   discord.EphemeralMessage,
+	}
+}
+
+func (b *Bot) topServersUpdater(id int) {
+	log.Printf("goroutine %d starting async goroutine for top servers messages", id)
+
+loop:
+	for {
+		select {
+		case <-b.ctx.Done():
+			break loop
+		case t, ok := <-b.ts:
+			if !ok {
+				break loop
+			}
+			err := b.updateTopServersMessage(t)
+			if err != nil {
+				b.l.Errorf("goroutine %0d: failed to update top servers message %s: %v", id, t.MessageTarget, err)
+			}
+		}
+	}
+
+	log.Printf("goroutine %d: closed async goroutine for top servers messages", id)
+}
+
+func (b *Bot) updateTopServersMessage(t model.TopServersMessage) error {
+	l := b.guildLocalizer(b.ctx, t.GuildID)
+	_, err := b.state.EditMessageComplex(t.ChannelID, t.MessageID, api.EditMessageData{
+		Content:         option.NewNullableString(""),
+		Embeds:          &[]discord.Embed{t.ToEmbed(l)},
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	})
+	if err != nil && !ErrIsNotFound(err) {
+		// the ranking is not saved, which retries the edit with the next update
+		return err
+	}
+
+	dao, closer, daoErr := b.ConnDAO(b.ctx)
+	if daoErr != nil {
+		return daoErr
+	}
+	defer closer()
+
+	if err == nil {
+		return dao.SetTopServersRanking(b.ctx, t)
+	}
+
+	// message was somehow deleted without us noticing
+	err = dao.RemoveTopServersTrackingByMessageID(b.ctx, t.GuildID, t.MessageID)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("removed top servers tracking for %s (reason: 'message not found')", t.MessageTarget)
+	return nil
+}
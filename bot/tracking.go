@@ -10,6 +10,7 @@ import (
 	"github.com/diamondburned/arikawa/v3/api/cmdroute"
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/jxsl13/twstatus-bot/i18n"
 	"github.com/jxsl13/twstatus-bot/model"
 )
 
@@ -21,16 +22,22 @@ func (b *Bot) addTracking(ctx context.Context, data cmdroute.CommandData) (resp
 	var params AddTrackingParams
 	err := data.Options.Unmarshal(&params)
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 
-	channelID := optionalChannelID(data)
+	return b.trackServers(ctx, data.Event.GuildID, optionalChannelID(data), strings.Split(params.Address, ","))
+}
 
-	addresses := strings.Split(params.Address, ",")
+// trackServers sends an initial status message for every address to the channel and tracks the servers.
+func (b *Bot) trackServers(ctx context.Context, guildID discord.GuildID, channelID discord.ChannelID, addresses []string) (resp *api.InteractionResponseData) {
+	var (
+		err error
+		l   = i18n.FromContext(ctx)
+	)
 	for _, address := range addresses {
 		_, err = netip.ParseAddrPort(address)
 		if err != nil {
-			return errorResponse(fmt.Errorf("invalid address: %w", err))
+			return errorResponse(ctx, i18n.WrapError(err, "error.invalid_address", err))
 		}
 	}
 
@@ -48,21 +55,21 @@ func (b *Bot) addTracking(ctx context.Context, data cmdroute.CommandData) (resp
 		}
 	}()
 	for _, address := range addresses {
-		msg, err := b.state.SendMessage(channelID, fmt.Sprintf("initial message for %s tracking", address))
+		msg, err := b.state.SendMessage(channelID, l.T("tracking.initial", address))
 		if err != nil {
-			return errorResponse(err)
+			return errorResponse(ctx, err)
 		}
 		msgs = append(msgs, msg)
 	}
 
 	dao, closer, err := b.TxDAO(ctx)
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 	defer func() {
 		err = closer(err)
 		if err != nil {
-			resp = errorResponse(err)
+			resp = errorResponse(ctx, err)
 		}
 	}()
 
@@ -70,7 +77,7 @@ func (b *Bot) addTracking(ctx context.Context, data cmdroute.CommandData) (resp
 		err = dao.AddTracking(ctx, model.Tracking{
 			MessageTarget: model.MessageTarget{
 				ChannelTarget: model.ChannelTarget{
-					GuildID:   data.Event.GuildID,
+					GuildID:   guildID,
 					ChannelID: channelID,
 				},
 				MessageID: msg.ID,
@@ -78,16 +85,14 @@ func (b *Bot) addTracking(ctx context.Context, data cmdroute.CommandData) (resp
 			Address: addresses[idx],
 		})
 		if err != nil {
-			return errorResponse(err)
+			return errorResponse(ctx, err)
 		}
 	}
 
-	plural := ""
-	if len(addresses) != 1 {
-		plural = "es"
+	msg := l.T("tracking.added.other", len(addresses))
+	if len(addresses) == 1 {
+		msg = l.T("tracking.added.one")
 	}
-
-	msg := fmt.Sprintf("Added tracking for %d address%s", len(addresses), plural)
 	return &api.InteractionResponseData{
 		Content: option.NewNullableString(msg),
 		Flags:   discord.EphemeralMessage,
@@ -13,6 +13,7 @@ import (
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/utils/httputil"
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/jxsl13/twstatus-bot/i18n"
 	"github.com/jxsl13/twstatus-bot/model"
 	"github.com/jxsl13/twstatus-bot/servers"
 )
@@ -51,6 +52,11 @@ func (b *Bot) updateServers() (src, dst int, err error) {
 			b.l.DebugAnyf(servers, "failed to set servers (dto server list attached): %v", err)
 			return err
 		}
+
+		err = dao.AddServerHistory(b.ctx, b.history.Resolution)
+		if err != nil {
+			return err
+		}
 		return nil
 	}(serverList)
 	if err != nil {
@@ -79,53 +85,170 @@ db transaction took %s
 	return src, dst, nil
 }
 
+// serverChanges is everything that needs to be published after a server list update
+type serverChanges struct {
+	servers        map[model.MessageTarget]model.ChangedServerStatus
+	notifications  []model.PlayerCountNotificationMessage
+	alerts         []model.ServerStatusAlert
+	directMessages []model.DirectMessage
+	webhookEvents  []model.WebhookEvent
+	topServers     []model.TopServersMessage
+	discoveries    []model.DiscoveryAlert
+}
+
+func (b *Bot) getServerChanges() (changes serverChanges, err error) {
+	dao, closer, err := b.TxDAO(b.ctx)
+	if err != nil {
+		return changes, err
+	}
+	defer func() {
+		err = closer(err)
+	}()
+
+	servers, addresses, err := dao.ChangedServers(b.ctx)
+	if err != nil {
+		return changes, err
+	}
+
+	pcnm, err := dao.GetPlayerCountNotificationMessages(b.ctx, addresses)
+	if err != nil {
+		return changes, err
+	}
+
+	mcn, err := dao.GetMapChangeNotifications(b.ctx, servers)
+	if err != nil {
+		return changes, err
+	}
+	notifications, directMessages := model.MergeMapChangeNotifications(pcnm, mcn, func(guildID discord.GuildID) i18n.Localizer {
+		return b.guildLocalizer(b.ctx, guildID)
+	})
+
+	alerts, err := dao.UpdateServerStatusAlerts(b.ctx, servers)
+	if err != nil {
+		return changes, err
+	}
+
+	webhookEvents, err := dao.GetWebhookEvents(b.ctx, servers)
+	if err != nil {
+		return changes, err
+	}
+
+	topServers, err := dao.ChangedTopServersTrackings(b.ctx)
+	if err != nil {
+		return changes, err
+	}
+
+	discoveries, err := dao.DiscoverServers(b.ctx)
+	if err != nil {
+		return changes, err
+	}
+
+	err = dao.UpdatePlayerSessions(b.ctx, servers)
+	if err != nil {
+		return changes, err
+	}
+
+	err = dao.UpdateMapSessions(b.ctx, servers)
+	if err != nil {
+		return changes, err
+	}
+
+	now := time.Now()
+	interval := model.PlaytimeInterval(b.lastPlaytimeUpdate, now, b.pollingInterval)
+	err = dao.AddPlayerPlaytime(b.ctx, now, interval)
+	if err != nil {
+		return changes, err
+	}
+
+	err = dao.AddMapSessionPlayers(b.ctx, interval)
+	if err != nil {
+		return changes, err
+	}
+	b.lastPlaytimeUpdate = now
+
+	return serverChanges{
+		servers:        servers,
+		notifications:  notifications,
+		alerts:         alerts,
+		directMessages: directMessages,
+		webhookEvents:  webhookEvents,
+		topServers:     topServers,
+		discoveries:    discoveries,
+	}, nil
+}
+
 // returns a list of active changed addresses for notification purposes
 func (b *Bot) changedServers() error {
 	var updateProducer chan<- model.ChangedServerStatus = b.c
 
-	servers, notifications, err := func() (map[model.MessageTarget]model.ChangedServerStatus, []model.PlayerCountNotificationMessage, error) {
-		dao, closer, err := b.TxDAO(b.ctx)
-		if err != nil {
-			return nil, nil, err
+	changes, err := b.getServerChanges()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("%d server messages require an update", len(changes.servers))
+	for _, server := range changes.servers {
+		select {
+		case updateProducer <- server:
+			continue
+		case <-b.ctx.Done():
+			return b.ctx.Err()
 		}
-		defer func() {
-			err = closer(err)
-		}()
+	}
 
-		servers, addresses, err := dao.ChangedServers(b.ctx)
-		if err != nil {
-			return nil, nil, err
+	for _, v := range changes.notifications {
+		select {
+		case b.n <- v:
+			continue
+		case <-b.ctx.Done():
+			return b.ctx.Err()
 		}
 
-		pcnm, err := dao.GetPlayerCountNotificationMessages(b.ctx, addresses)
-		if err != nil {
-			return nil, nil, err
+	}
+
+	for _, a := range changes.alerts {
+		select {
+		case b.a <- a:
+			continue
+		case <-b.ctx.Done():
+			return b.ctx.Err()
 		}
+	}
 
-		return servers, pcnm, nil
-	}()
-	if err != nil {
-		return err
+	for _, dm := range changes.directMessages {
+		select {
+		case b.dm <- dm:
+			continue
+		case <-b.ctx.Done():
+			return b.ctx.Err()
+		}
 	}
 
-	log.Printf("%d server messages require an update", len(servers))
-	for _, server := range servers {
+	for _, e := range changes.webhookEvents {
 		select {
-		case updateProducer <- server:
+		case b.w <- e:
 			continue
 		case <-b.ctx.Done():
 			return b.ctx.Err()
 		}
 	}
 
-	for _, v := range notifications {
+	for _, t := range changes.topServers {
 		select {
-		case b.n <- v:
+		case b.ts <- t:
 			continue
 		case <-b.ctx.Done():
 			return b.ctx.Err()
 		}
+	}
 
+	for _, d := range changes.discoveries {
+		select {
+		case b.d <- d:
+			continue
+		case <-b.ctx.Done():
+			return b.ctx.Err()
+		}
 	}
 
 	return nil
@@ -137,6 +260,7 @@ func (b *Bot) updateDiscordMessage(change model.ChangedServerStatus) (err error)
 		embeds  []discord.Embed = []discord.Embed{}
 		status                  = change.Curr
 		target                  = change.Target
+		l                       = b.guildLocalizer(b.ctx, target.GuildID)
 	)
 	waitUntil, found := b.conflictMap.Load(target)
 	expired := !found || waitUntil.Until.After(time.Now())
@@ -148,16 +272,26 @@ func (b *Bot) updateDiscordMessage(change model.ChangedServerStatus) (err error)
 
 	if b.useEmbeds {
 		// new message format
-		content = change.Content()
-		embeds = status.ToEmbeds()
+		content = change.Content(l)
+		embeds = status.ToEmbeds(l)
 	} else {
 		// legacy message format
-		content = status.String()
+		content = status.Message(l)
+	}
+
+	if b.history.ForecastHint && status.Address != "" {
+		if m, ok := b.forecasts.Load(status.Address); ok {
+			if hint := m.BusyHint(l, time.Now(), float64(status.NumPlayers)); hint != "" {
+				content += "\n-# " + hint
+			}
+		}
 	}
 
+	components := statusComponents(l, status)
 	data := api.EditMessageData{
-		Content: option.NewNullableString(content),
-		Embeds:  &embeds,
+		Content:    option.NewNullableString(content),
+		Embeds:     &embeds,
+		Components: &components,
 	}
 
 	_, err = b.state.EditMessageComplex(
@@ -233,17 +367,17 @@ func (b *Bot) updateDiscordMessage(change model.ChangedServerStatus) (err error)
 
 func (b *Bot) updateServerListCommand(ctx context.Context, data cmdroute.CommandData) (resp *api.InteractionResponseData) {
 	if !b.IsSuperAdmin(data) {
-		return ErrAccessForbidden()
+		return ErrAccessForbidden(ctx)
 	}
 
 	start := time.Now()
 	src, dst, err := b.updateServers()
 	if err != nil {
-		return errorResponse(err)
+		return errorResponse(ctx, err)
 	}
 	dur := time.Since(start)
 
-	msg := fmt.Sprintf("Updated %d source to %d target servers in %s", src, dst, dur)
+	msg := i18n.FromContext(ctx).T("update.done", src, dst, dur)
 	return &api.InteractionResponseData{
 		Content: option.NewNullableString(msg),
 		Flags:   discord.EphemeralMessage,
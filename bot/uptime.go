@@ -0,0 +1,57 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+)
+
+type UptimeParams struct {
+	Address string `discord:"address"`
+}
+
+// uptime shows the availability and the most recent outages of a tracked server.
+func (b *Bot) uptime(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+	var params UptimeParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	dao, closer, err := b.ConnDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer closer()
+
+	tracking, err := dao.GetTrackingByAddress(ctx, data.Event.GuildID, optionalChannelID(data), params.Address)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	now := time.Now()
+	longest, err := model.ParseHistoryRange(model.HistoryRanges[len(model.HistoryRanges)-1])
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	history, err := dao.GetServerHistory(ctx, tracking.Address, now.Add(-longest))
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	if len(history.Samples) == 0 {
+		return errorResponse(ctx, i18n.NewError("error.no_history", tracking.Address))
+	}
+
+	report := model.NewUptimeReport(history, now)
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(report.Format(i18n.FromContext(ctx))),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
@@ -0,0 +1,93 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+)
+
+type NotificationSettingsParams struct {
+	Timezone   *string `discord:"timezone"`
+	QuietStart *string `discord:"quiet-start"`
+	QuietEnd   *string `discord:"quiet-end"`
+	QuietDays  *string `discord:"quiet-days"`
+}
+
+// notificationSettings shows the current settings of the user in case no options are provided.
+func (b *Bot) notificationSettings(ctx context.Context, data cmdroute.CommandData) (resp *api.InteractionResponseData) {
+	var params NotificationSettingsParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	dao, closer, err := b.TxDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer func() {
+		err = closer(err)
+		if err != nil {
+			resp = errorResponse(ctx, err)
+		}
+	}()
+
+	userID := data.Event.SenderID()
+	settings, err := dao.GetUserNotificationSettings(ctx, userID)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	changed := false
+	if params.Timezone != nil {
+		loc, err := time.LoadLocation(*params.Timezone)
+		if err != nil {
+			return errorResponse(ctx, i18n.WrapError(err, "error.unknown_time_zone", *params.Timezone))
+		}
+		settings.Location = loc
+		changed = true
+	}
+
+	if params.QuietStart != nil {
+		settings.QuietStart, err = model.ParseClock(*params.QuietStart)
+		if err != nil {
+			return errorResponse(ctx, err)
+		}
+		changed = true
+	}
+
+	if params.QuietEnd != nil {
+		settings.QuietEnd, err = model.ParseClock(*params.QuietEnd)
+		if err != nil {
+			return errorResponse(ctx, err)
+		}
+		changed = true
+	}
+
+	if params.QuietDays != nil {
+		settings.QuietDays, err = model.ParseWeekdays(*params.QuietDays)
+		if err != nil {
+			return errorResponse(ctx, err)
+		}
+		changed = true
+	}
+
+	if changed {
+		err = dao.SetUserNotificationSettings(ctx, settings)
+		if err != nil {
+			return errorResponse(ctx, err)
+		}
+	}
+
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(settings.Format(i18n.FromContext(ctx))),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
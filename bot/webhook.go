@@ -0,0 +1,142 @@
+package bot
+
+import (
+	"context"
+	"errors"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/webhook"
+)
+
+type AddWebhookParams struct {
+	URL    string  `discord:"url"`
+	Secret *string `discord:"secret"`
+	Events *string `discord:"events"`
+}
+
+type RemoveWebhookParams struct {
+	URL string `discord:"url"`
+}
+
+func (b *Bot) listWebhooks(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+	dao, closer, err := b.ConnDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer closer()
+
+	webhooks, err := dao.ListWebhooks(ctx, data.Event.GuildID)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(webhooks.Format(i18n.FromContext(ctx))),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
+
+func (b *Bot) addWebhook(ctx context.Context, data cmdroute.CommandData) (resp *api.InteractionResponseData) {
+	var params AddWebhookParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	u, err := webhook.ParseURL(params.URL)
+	if errors.Is(err, webhook.ErrForbiddenURL) {
+		return errorResponse(ctx, i18n.WrapError(err, "error.webhook_forbidden_url"))
+	} else if err != nil {
+		return errorResponse(ctx, i18n.WrapError(err, "error.webhook_invalid_url", params.URL))
+	}
+
+	var events []model.ServerEventType
+	if params.Events != nil {
+		events, err = model.ParseServerEventTypes(*params.Events)
+		if err != nil {
+			return errorResponse(ctx, err)
+		}
+	}
+
+	generated := params.Secret == nil
+	secret := ""
+	if generated {
+		secret, err = webhook.NewSecret()
+		if err != nil {
+			return errorResponse(ctx, err)
+		}
+	} else {
+		secret = *params.Secret
+	}
+
+	dao, closer, err := b.TxDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer func() {
+		err = closer(err)
+		if err != nil {
+			resp = errorResponse(ctx, err)
+		}
+	}()
+
+	w := model.Webhook{
+		GuildID: data.Event.GuildID,
+		URL:     u.String(),
+		Secret:  secret,
+		Events:  events,
+	}
+	err = dao.AddWebhook(ctx, w)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	l := i18n.FromContext(ctx)
+	msg := l.T("webhook.added", w.Format(l))
+	if generated {
+		msg += "\n" + l.T("webhook.secret", secret)
+	}
+	msg += "\n" + l.T("webhook.signature", webhook.HeaderTimestamp, webhook.HeaderSignature)
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(msg),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
+
+func (b *Bot) removeWebhook(ctx context.Context, data cmdroute.CommandData) (resp *api.InteractionResponseData) {
+	var params RemoveWebhookParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	dao, closer, err := b.TxDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer func() {
+		err = closer(err)
+		if err != nil {
+			resp = errorResponse(ctx, err)
+		}
+	}()
+
+	err = dao.RemoveWebhook(ctx, data.Event.GuildID, params.URL)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	msg := i18n.FromContext(ctx).T("webhook.removed", params.URL)
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(msg),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
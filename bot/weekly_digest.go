@@ -0,0 +1,200 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/jxsl13/twstatus-bot/dao"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+)
+
+const defaultDigestTime = "18:00"
+
+type SetWeeklyDigestParams struct {
+	Day         string             `discord:"day"`
+	Time        *string            `discord:"time"`
+	Timezone    *string            `discord:"timezone"`
+	PostChannel *discord.ChannelID `discord:"post-channel"`
+}
+
+// renderDigest summarizes the tracked servers of the digest's channel during the week before now.
+func renderDigest(ctx context.Context, dao *dao.DAO, d model.WeeklyDigest, now time.Time) (string, error) {
+	addresses, err := heatmapAddresses(ctx, dao, d.ChannelTarget, "")
+	if err != nil {
+		return "", err
+	}
+
+	digest, err := dao.GetDigest(ctx, d.ChannelID, addresses, now, d.Location)
+	if err != nil {
+		return "", err
+	}
+	return digest.Format(i18n.FromContext(ctx)), nil
+}
+
+// weeklyDigest shows the digest of the last seven days without waiting for the schedule.
+func (b *Bot) weeklyDigest(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+	dao, closer, err := b.ConnDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer closer()
+
+	settings, err := dao.GetGuildSettings(ctx, data.Event.GuildID)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	d := model.WeeklyDigest{
+		ChannelTarget: model.ChannelTarget{
+			GuildID:   data.Event.GuildID,
+			ChannelID: optionalChannelID(data),
+		},
+		Location: settings.Location,
+	}
+
+	content, err := renderDigest(ctx, dao, d, time.Now())
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(content),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
+
+func (b *Bot) listWeeklyDigests(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+	dao, closer, err := b.ConnDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer closer()
+
+	digests, err := dao.ListWeeklyDigests(ctx, data.Event.GuildID)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(digests.Format(i18n.FromContext(ctx))),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
+
+func (b *Bot) setWeeklyDigest(ctx context.Context, data cmdroute.CommandData) (resp *api.InteractionResponseData) {
+	var params SetWeeklyDigestParams
+	err := data.Options.Unmarshal(&params)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	weekday, err := model.ParseWeekday(params.Day)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	clock := defaultDigestTime
+	if params.Time != nil {
+		clock = *params.Time
+	}
+	timeOfDay, err := model.ParseClock(clock)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	dao, closer, err := b.TxDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer func() {
+		err = closer(err)
+		if err != nil {
+			resp = errorResponse(ctx, err)
+		}
+	}()
+
+	// the schedule uses the time zone of the guild unless specified otherwise
+	settings, err := dao.GetGuildSettings(ctx, data.Event.GuildID)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	loc := settings.Location
+	if params.Timezone != nil {
+		loc, err = time.LoadLocation(*params.Timezone)
+		if err != nil {
+			return errorResponse(ctx, i18n.WrapError(err, "error.unknown_time_zone", *params.Timezone))
+		}
+	}
+
+	d := model.WeeklyDigest{
+		ChannelTarget: model.ChannelTarget{
+			GuildID:   data.Event.GuildID,
+			ChannelID: optionalChannelID(data),
+		},
+		PostChannelID: data.Event.ChannelID,
+		Weekday:       weekday,
+		TimeOfDay:     timeOfDay,
+		Location:      loc,
+	}
+	if params.PostChannel != nil {
+		d.PostChannelID = *params.PostChannel
+	}
+
+	// validate that the channel tracks servers
+	_, err = heatmapAddresses(ctx, dao, d.ChannelTarget, "")
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	err = dao.SetWeeklyDigest(ctx, d)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	l := i18n.FromContext(ctx)
+	msg := l.T("weekly_digest.set", d.Format(l))
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(msg),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
+
+func (b *Bot) removeWeeklyDigest(ctx context.Context, data cmdroute.CommandData) (resp *api.InteractionResponseData) {
+	dao, closer, err := b.TxDAO(ctx)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+	defer func() {
+		err = closer(err)
+		if err != nil {
+			resp = errorResponse(ctx, err)
+		}
+	}()
+
+	d := model.WeeklyDigest{
+		ChannelTarget: model.ChannelTarget{
+			GuildID:   data.Event.GuildID,
+			ChannelID: optionalChannelID(data),
+		},
+	}
+
+	err = dao.RemoveWeeklyDigest(ctx, d)
+	if err != nil {
+		return errorResponse(ctx, err)
+	}
+
+	msg := i18n.FromContext(ctx).T("weekly_digest.removed", d.ChannelID)
+	return &api.InteractionResponseData{
+		Content:         option.NewNullableString(msg),
+		Flags:           discord.EphemeralMessage,
+		AllowedMentions: &api.AllowedMentions{ /* none */ },
+	}
+}
@@ -0,0 +1,121 @@
+package chart
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+var (
+	colorBackground = color.RGBA{0x2B, 0x2D, 0x31, 0xFF}
+	colorGrid       = color.RGBA{0x3F, 0x41, 0x47, 0xFF}
+	colorAxis       = color.RGBA{0x80, 0x84, 0x8E, 0xFF}
+	colorText       = color.RGBA{0xDB, 0xDE, 0xE1, 0xFF}
+	colorLine       = color.RGBA{0x58, 0x65, 0xF2, 0xFF}
+	colorMarker     = color.RGBA{0xFE, 0xE7, 0x5C, 0xFF}
+	colorHighlight  = color.RGBA{0x5C, 0x32, 0x36, 0xFF}
+)
+
+// canvas is a minimal raster drawing surface.
+type canvas struct {
+	img *image.RGBA
+}
+
+func newCanvas(width, height int) *canvas {
+	c := &canvas{
+		img: image.NewRGBA(image.Rect(0, 0, width, height)),
+	}
+	c.fillRect(c.img.Rect, colorBackground)
+	return c
+}
+
+func (c *canvas) set(x, y int, col color.RGBA) {
+	// SetRGBA ignores pixels outside of the image
+	c.img.SetRGBA(x, y, col)
+}
+
+func (c *canvas) fillRect(r image.Rectangle, col color.RGBA) {
+	draw.Draw(c.img, r, &image.Uniform{C: col}, image.Point{}, draw.Src)
+}
+
+func (c *canvas) hLine(x0, x1, y int, col color.RGBA) {
+	if x0 > x1 {
+		x0, x1 = x1, x0
+	}
+	for x := x0; x <= x1; x++ {
+		c.set(x, y, col)
+	}
+}
+
+// vLine draws a vertical line, every dash pixels the line is interrupted
+// for dash pixels. A dash of 0 draws a solid line.
+func (c *canvas) vLine(x, y0, y1 int, col color.RGBA, dash int) {
+	if y0 > y1 {
+		y0, y1 = y1, y0
+	}
+	for y := y0; y <= y1; y++ {
+		if dash > 0 && ((y-y0)/dash)%2 == 1 {
+			continue
+		}
+		c.set(x, y, col)
+	}
+}
+
+// line draws a line with a width of two pixels using Bresenham's algorithm.
+func (c *canvas) line(x0, y0, x1, y1 int, col color.RGBA) {
+	dx := abs(x1 - x0)
+	dy := -abs(y1 - y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+
+	e := dx + dy
+	for {
+		c.set(x0, y0, col)
+		c.set(x0, y0+1, col)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+// text draws s with its top left corner at x, y.
+func (c *canvas) text(x, y int, s string, col color.RGBA, scale int) {
+	for _, r := range s {
+		g := glyph(r)
+		for gx, column := range g {
+			for gy := 0; gy < glyphHeight; gy++ {
+				if column&(1<<gy) == 0 {
+					continue
+				}
+				px, py := x+gx*scale, y+gy*scale
+				for sx := 0; sx < scale; sx++ {
+					for sy := 0; sy < scale; sy++ {
+						c.set(px+sx, py+sy, col)
+					}
+				}
+			}
+		}
+		x += glyphAdvance * scale
+	}
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
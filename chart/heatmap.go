@@ -0,0 +1,155 @@
+package chart
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+)
+
+const (
+	heatmapLegendWidth  = 160
+	heatmapLegendHeight = glyphHeight
+)
+
+// heatmapColors is the color scale of a heatmap from the lowest to the highest value.
+var heatmapColors = []color.RGBA{
+	{0x38, 0x3A, 0x40, 0xFF},
+	{0x58, 0x65, 0xF2, 0xFF},
+	{0xFE, 0xE7, 0x5C, 0xFF},
+}
+
+// Heatmap is a grid of colored cells.
+type Heatmap struct {
+	Title        string
+	RowLabels    []string
+	ColumnLabels []string
+	// Values[row][column], NaN for missing values
+	Values [][]float64
+	// description of the values
+	Legend string
+}
+
+// WritePNG renders the heatmap as png image.
+func (hm *Heatmap) WritePNG(w io.Writer, width, height int) error {
+	err := png.Encode(w, hm.Render(width, height))
+	if err != nil {
+		return fmt.Errorf("failed to encode heatmap: %w", err)
+	}
+	return nil
+}
+
+// Render draws the heatmap.
+func (hm *Heatmap) Render(width, height int) *image.RGBA {
+	c := newCanvas(width, height)
+	plot := image.Rect(marginLeft, marginTop, width-marginRight, height-marginBottom)
+
+	rows, columns := len(hm.Values), 0
+	maxValue := 0.0
+	for _, row := range hm.Values {
+		columns = max(columns, len(row))
+		for _, v := range row {
+			if !math.IsNaN(v) {
+				maxValue = math.Max(maxValue, v)
+			}
+		}
+	}
+
+	c.text(plot.Min.X, (marginTop-glyphHeight*2)/2, hm.Title, colorText, 2)
+	if rows == 0 || columns == 0 {
+		return c.img
+	}
+
+	cellWidth, cellHeight := plot.Dx()/columns, plot.Dy()/rows
+	for r, row := range hm.Values {
+		y := plot.Min.Y + r*cellHeight
+		if r < len(hm.RowLabels) {
+			label := hm.RowLabels[r]
+			c.text(plot.Min.X-6-textWidth(label, 1), y+(cellHeight-glyphHeight)/2, label, colorText, 1)
+		}
+
+		for col, v := range row {
+			x := plot.Min.X + col*cellWidth
+			cell := image.Rect(x+1, y+1, x+cellWidth-1, y+cellHeight-1)
+			if math.IsNaN(v) {
+				c.fillRect(cell, colorGrid)
+				continue
+			}
+
+			fill := scaleColor(v, maxValue)
+			c.fillRect(cell, fill)
+
+			label := formatValue(v)
+			if textWidth(label, 1) < cell.Dx()-2 {
+				c.text(
+					cell.Min.X+(cell.Dx()-textWidth(label, 1))/2,
+					cell.Min.Y+(cell.Dy()-glyphHeight)/2,
+					label,
+					contrastColor(fill),
+					1,
+				)
+			}
+		}
+	}
+
+	for col, label := range hm.ColumnLabels {
+		if col >= columns {
+			break
+		}
+		x := plot.Min.X + col*cellWidth + (cellWidth-textWidth(label, 1))/2
+		c.text(x, plot.Min.Y+rows*cellHeight+6, label, colorText, 1)
+	}
+
+	// color scale below the column labels
+	ly := height - heatmapLegendHeight - 6
+	lx := plot.Max.X - heatmapLegendWidth
+	maxLabel := formatValue(maxValue)
+	lx -= textWidth(maxLabel, 1) + 6
+	for i := 0; i < heatmapLegendWidth; i++ {
+		col := scaleColor(float64(i), heatmapLegendWidth-1)
+		c.vLine(lx+i, ly, ly+heatmapLegendHeight-1, col, 0)
+	}
+	c.text(lx+heatmapLegendWidth+6, ly, maxLabel, colorText, 1)
+	c.text(lx-6-textWidth("0", 1), ly, "0", colorText, 1)
+	if hm.Legend != "" {
+		c.text(lx-18-textWidth(hm.Legend, 1), ly, hm.Legend, colorText, 1)
+	}
+
+	return c.img
+}
+
+// scaleColor interpolates the color of v on the heatmap color scale.
+func scaleColor(v, max float64) color.RGBA {
+	if max <= 0 {
+		return heatmapColors[0]
+	}
+	pos := math.Min(1, math.Max(0, v/max)) * float64(len(heatmapColors)-1)
+	i := int(pos)
+	if i >= len(heatmapColors)-1 {
+		return heatmapColors[len(heatmapColors)-1]
+	}
+	frac := pos - float64(i)
+	from, to := heatmapColors[i], heatmapColors[i+1]
+	lerp := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a) + (float64(b)-float64(a))*frac))
+	}
+	return color.RGBA{lerp(from.R, to.R), lerp(from.G, to.G), lerp(from.B, to.B), 0xFF}
+}
+
+// contrastColor returns a text color that is readable on the given background.
+func contrastColor(bg color.RGBA) color.RGBA {
+	luminance := 0.299*float64(bg.R) + 0.587*float64(bg.G) + 0.114*float64(bg.B)
+	if luminance > 150 {
+		return colorBackground
+	}
+	return colorText
+}
+
+func formatValue(v float64) string {
+	if v < 10 {
+		return fmt.Sprintf("%.1f", v)
+	}
+	return fmt.Sprintf("%.0f", v)
+}
@@ -0,0 +1,41 @@
+package chart_test
+
+import (
+	"bytes"
+	"image/png"
+	"math"
+	"testing"
+
+	"github.com/jxsl13/twstatus-bot/chart"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeatmapWritePNG(t *testing.T) {
+	values := make([][]float64, 7)
+	for d := range values {
+		values[d] = make([]float64, 24)
+		for h := range values[d] {
+			values[d][h] = float64(d*h) / 4
+		}
+	}
+	values[0][0] = math.NaN()
+
+	hm := chart.Heatmap{
+		Title:        "127.0.0.1:8303 - last 4 weeks",
+		RowLabels:    []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"},
+		ColumnLabels: []string{"00", "01", "02"},
+		Values:       values,
+		Legend:       "avg. players",
+	}
+
+	var buf bytes.Buffer
+	err := hm.WritePNG(&buf, 1000, 340)
+	require.NoError(t, err)
+
+	img, err := png.Decode(&buf)
+	require.NoError(t, err)
+	require.Equal(t, 1000, img.Bounds().Dx())
+
+	empty := chart.Heatmap{}
+	require.NotNil(t, empty.Render(200, 100))
+}
@@ -0,0 +1,282 @@
+package chart
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"math"
+	"time"
+)
+
+const (
+	marginLeft   = 44
+	marginRight  = 16
+	marginTop    = 36
+	marginBottom = 40
+
+	maxTicks = 8
+	// map changes are drawn without labels in case there are too many of them
+	maxMarkerLabels  = 12
+	maxMarkerLabel   = 20
+	markerLabelLines = 3
+)
+
+// Point is a single value at a point in time.
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// Marker is a labeled point in time, e.g. a map change.
+type Marker struct {
+	Time  time.Time
+	Label string
+}
+
+// Span is a time range, e.g. a period in which a server was offline.
+type Span struct {
+	From time.Time
+	To   time.Time
+}
+
+// LineChart is a time series chart.
+type LineChart struct {
+	Title string
+	From  time.Time
+	To    time.Time
+	// time zone of the time axis labels, defaults to UTC
+	Location *time.Location
+
+	// consecutive points are connected, gaps in the data start a new segment
+	Segments [][]Point
+	Markers  []Marker
+	// highlighted time ranges
+	Spans []Span
+
+	// lower bound for the upper end of the y axis
+	MinMax float64
+
+	MarkerLegend string
+	SpanLegend   string
+}
+
+// WritePNG renders the chart as png image.
+func (lc *LineChart) WritePNG(w io.Writer, width, height int) error {
+	err := png.Encode(w, lc.Render(width, height))
+	if err != nil {
+		return fmt.Errorf("failed to encode chart: %w", err)
+	}
+	return nil
+}
+
+// Render draws the chart.
+func (lc *LineChart) Render(width, height int) *image.RGBA {
+	c := newCanvas(width, height)
+	plot := image.Rect(marginLeft, marginTop, width-marginRight, height-marginBottom)
+
+	loc := lc.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	from, to := lc.From, lc.To
+	if !to.After(from) {
+		to = from.Add(time.Hour)
+	}
+	xOf := func(t time.Time) int {
+		frac := float64(t.Sub(from)) / float64(to.Sub(from))
+		return plot.Min.X + int(math.Round(frac*float64(plot.Dx())))
+	}
+
+	yMax := lc.MinMax
+	for _, segment := range lc.Segments {
+		for _, p := range segment {
+			yMax = math.Max(yMax, p.Value)
+		}
+	}
+	yStep := niceStep(yMax)
+	yMax = math.Max(yStep, math.Ceil(yMax/yStep)*yStep)
+	yOf := func(v float64) int {
+		return plot.Max.Y - int(math.Round(v/yMax*float64(plot.Dy())))
+	}
+
+	// highlighted ranges below everything else
+	for _, s := range lc.Spans {
+		if !s.To.After(from) || !s.From.Before(to) {
+			continue
+		}
+		x0, x1 := xOf(maxTime(s.From, from)), xOf(minTime(s.To, to))
+		if x1 == x0 {
+			x1++
+		}
+		c.fillRect(image.Rect(x0, plot.Min.Y, x1, plot.Max.Y), colorHighlight)
+	}
+
+	// horizontal grid and y axis labels
+	for v := 0.0; v <= yMax; v += yStep {
+		y := yOf(v)
+		c.hLine(plot.Min.X, plot.Max.X, y, colorGrid)
+		label := fmt.Sprintf("%g", v)
+		c.text(plot.Min.X-6-textWidth(label, 1), y-glyphHeight/2, label, colorText, 1)
+	}
+
+	// vertical grid and time axis labels
+	step, format := timeStep(to.Sub(from))
+	for _, t := range timeTicks(from, to, step, loc) {
+		x := xOf(t)
+		c.vLine(x, plot.Min.Y, plot.Max.Y, colorGrid, 0)
+		label := t.In(loc).Format(format)
+		c.text(x-textWidth(label, 1)/2, plot.Max.Y+6, label, colorText, 1)
+	}
+
+	c.hLine(plot.Min.X, plot.Max.X, plot.Max.Y, colorAxis)
+	c.vLine(plot.Min.X, plot.Min.Y, plot.Max.Y, colorAxis, 0)
+
+	markers := make([]Marker, 0, len(lc.Markers))
+	for _, m := range lc.Markers {
+		if m.Time.Before(from) || m.Time.After(to) {
+			continue
+		}
+		markers = append(markers, m)
+	}
+	for i, m := range markers {
+		x := xOf(m.Time)
+		c.vLine(x, plot.Min.Y, plot.Max.Y, colorMarker, 3)
+		if len(markers) > maxMarkerLabels {
+			continue
+		}
+
+		label := truncate(m.Label, maxMarkerLabel)
+		lx := x + 3
+		if lx+textWidth(label, 1) > plot.Max.X {
+			// keep labels at the right border inside of the plot
+			lx = x - 3 - textWidth(label, 1)
+		}
+		// alternate the label height in order to avoid overlapping labels
+		ly := plot.Min.Y + 3 + (i%markerLabelLines)*(glyphHeight+3)
+		c.text(lx, ly, label, colorMarker, 1)
+	}
+
+	for _, segment := range lc.Segments {
+		if len(segment) == 1 {
+			p := segment[0]
+			c.line(xOf(p.Time), yOf(p.Value), xOf(p.Time), yOf(p.Value), colorLine)
+			continue
+		}
+		for i := 1; i < len(segment); i++ {
+			p0, p1 := segment[i-1], segment[i]
+			c.line(xOf(p0.Time), yOf(p0.Value), xOf(p1.Time), yOf(p1.Value), colorLine)
+		}
+	}
+
+	c.text(plot.Min.X, (marginTop-glyphHeight*2)/2, lc.Title, colorText, 2)
+
+	// legend below the time axis labels
+	lx := plot.Max.X
+	ly := height - glyphHeight - 6
+	if lc.SpanLegend != "" {
+		lx -= textWidth(lc.SpanLegend, 1)
+		c.text(lx, ly, lc.SpanLegend, colorText, 1)
+		lx -= 4 + 10
+		c.fillRect(image.Rect(lx, ly, lx+10, ly+glyphHeight), colorHighlight)
+		lx -= 16
+	}
+	if lc.MarkerLegend != "" {
+		lx -= textWidth(lc.MarkerLegend, 1)
+		c.text(lx, ly, lc.MarkerLegend, colorText, 1)
+		lx -= 6
+		c.vLine(lx, ly-1, ly+glyphHeight, colorMarker, 3)
+	}
+
+	return c.img
+}
+
+// niceStep returns a step size for the y axis that results in at most maxTicks ticks.
+func niceStep(max float64) float64 {
+	for _, step := range []float64{1, 2, 5, 10, 20, 25, 50, 100, 200, 250, 500} {
+		if max/step <= maxTicks {
+			return step
+		}
+	}
+	return math.Ceil(max/maxTicks/1000) * 1000
+}
+
+// timeStep returns the distance between two time axis ticks and the format of their labels.
+func timeStep(d time.Duration) (time.Duration, string) {
+	for _, step := range []time.Duration{
+		time.Hour,
+		2 * time.Hour,
+		3 * time.Hour,
+		6 * time.Hour,
+		12 * time.Hour,
+	} {
+		if d/step <= maxTicks {
+			return step, "15:04"
+		}
+	}
+	for _, days := range []time.Duration{1, 2, 5, 7, 14, 30} {
+		step := days * 24 * time.Hour
+		if d/step <= maxTicks {
+			if days == 1 {
+				return step, "Mon 02"
+			}
+			return step, "Jan 02"
+		}
+	}
+	return 365 * 24 * time.Hour, "2006"
+}
+
+// timeTicks returns all ticks between from and to which are aligned to
+// the local midnight of the given location.
+func timeTicks(from, to time.Time, step time.Duration, loc *time.Location) []time.Time {
+	local := from.In(loc)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+
+	ticks := make([]time.Time, 0, maxTicks+1)
+	if step < 24*time.Hour {
+		hours := int(step / time.Hour)
+		for h := 0; ; h += hours {
+			t := time.Date(midnight.Year(), midnight.Month(), midnight.Day(), h, 0, 0, 0, loc)
+			if t.After(to) {
+				return ticks
+			}
+			if !t.Before(from) {
+				ticks = append(ticks, t)
+			}
+		}
+	}
+
+	days := int(step / (24 * time.Hour))
+	for d := 0; ; d += days {
+		t := time.Date(midnight.Year(), midnight.Month(), midnight.Day()+d, 0, 0, 0, 0, loc)
+		if t.After(to) {
+			return ticks
+		}
+		if !t.Before(from) {
+			ticks = append(ticks, t)
+		}
+	}
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-2]) + ".."
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
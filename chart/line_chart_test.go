@@ -0,0 +1,51 @@
+package chart_test
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/jxsl13/twstatus-bot/chart"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLineChartWritePNG(t *testing.T) {
+	to := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	from := to.Add(-24 * time.Hour)
+
+	points := make([]chart.Point, 0, 24*60)
+	for ts := from; ts.Before(to); ts = ts.Add(time.Minute) {
+		points = append(points, chart.Point{Time: ts, Value: float64(ts.Hour() % 16)})
+	}
+
+	lc := chart.LineChart{
+		Title:    "127.0.0.1:8303 - last 24h",
+		From:     from,
+		To:       to,
+		Segments: [][]chart.Point{points[:600], points[700:]},
+		Markers: []chart.Marker{
+			{Time: from.Add(3 * time.Hour), Label: "ctf5"},
+			{Time: to.Add(-time.Minute), Label: "a very long map name that is cut"},
+		},
+		Spans: []chart.Span{
+			{From: from.Add(10 * time.Hour), To: from.Add(700 * time.Minute)},
+		},
+		MinMax:       1,
+		MarkerLegend: "map change",
+		SpanLegend:   "offline",
+	}
+
+	var buf bytes.Buffer
+	err := lc.WritePNG(&buf, 800, 300)
+	require.NoError(t, err)
+
+	img, err := png.Decode(&buf)
+	require.NoError(t, err)
+	require.Equal(t, 800, img.Bounds().Dx())
+	require.Equal(t, 300, img.Bounds().Dy())
+
+	// empty charts must not panic
+	empty := chart.LineChart{From: from, To: to}
+	require.NotNil(t, empty.Render(200, 100))
+}
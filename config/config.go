@@ -25,6 +25,13 @@ type Config struct {
 	PollInterval        time.Duration `koanf:"poll.interval" short:"p" description:"Poll interval for DDNet's http master server"`
 	LegacyMessageFormat bool          `koanf:"legacy.format" short:"l" description:"Use legacy message format. If disabled, rich text embeddings will be used."`
 
+	HistoryResolution      time.Duration `koanf:"history.resolution" description:"Minimum duration between two player count history samples of a tracked server. 0 records a sample every poll."`
+	HistoryRetention       time.Duration `koanf:"history.retention" description:"Duration for which raw player count history samples are kept. Older samples are only available as hourly and daily aggregates."`
+	HistoryHourlyRetention time.Duration `koanf:"history.hourly.retention" description:"Duration for which hourly player count aggregates are kept. Daily aggregates are kept forever."`
+	SessionRetention       time.Duration `koanf:"session.retention" description:"Duration for which player and map sessions of tracked servers are kept."`
+	DiscoveryRetention     time.Duration `koanf:"discovery.retention" description:"Duration after which servers that are no longer listed on the master server are forgotten and alerted again as new servers."`
+	ForecastHint           bool          `koanf:"forecast.hint" description:"Add a hint to status messages in case a tracked server is usually busy within the next hours."`
+
 	PostgresHostname string     `koanf:"postgres.hostname" short:"H" description:"Postgres host" validate:"required"`
 	PostgresPort     uint16     `koanf:"postgres.port" short:"P" description:"Postgres port" validate:"required"`
 	PostgresUser     string     `koanf:"postgres.user" short:"U" description:"Postgres user" validate:"required"`
@@ -66,6 +73,24 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.HistoryResolution < 0 {
+		return errors.New("history resolution must not be negative")
+	}
+	if c.HistoryRetention < 48*time.Hour {
+		// daily aggregates are computed from the raw samples of the previous day
+		return errors.New("history retention must be at least 48h")
+	}
+	if c.HistoryHourlyRetention < c.HistoryRetention {
+		return errors.New("hourly history retention must not be shorter than the history retention")
+	}
+	if c.SessionRetention < time.Hour {
+		return errors.New("session retention must be at least 1h")
+	}
+	if c.DiscoveryRetention < time.Hour {
+		// servers briefly missing from the master server must not be alerted as new servers
+		return errors.New("discovery retention must be at least 1h")
+	}
+
 	v := validator.New()
 	err = v.Struct(c)
 	if err != nil {
@@ -5,7 +5,9 @@ import (
 	"fmt"
 
 	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
 	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/sqlc"
 	"github.com/jxsl13/twstatus-bot/utils"
 )
 
@@ -234,3 +236,105 @@ func (dao *DAO) ExistsServer(ctx context.Context, address string) (found bool, e
 
 	return addr[0] == address, nil
 }
+
+// FindPlayers searches all clients on all servers of the master server by name.
+func (dao *DAO) FindPlayers(ctx context.Context, name string, match model.PlayerMatch, maxEntries int) (model.FoundPlayers, error) {
+	rows, err := dao.q.FindPlayers(ctx, sqlc.FindPlayersParams{
+		Name:       name,
+		MaxMatch:   int16(match),
+		MaxEntries: int32(maxEntries),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find players named %q: %w", name, err)
+	}
+
+	result := make(model.FoundPlayers, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, model.NewFoundPlayerFromSQLC(row))
+	}
+	return result, nil
+}
+
+// SearchServers returns the servers of the master server that match the search, most players first.
+func (dao *DAO) SearchServers(ctx context.Context, search model.ServerSearch) (model.FoundServers, error) {
+	rows, err := dao.q.SearchServers(ctx, search.ToSQLC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to search servers: %w", err)
+	}
+
+	result := make(model.FoundServers, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, model.NewFoundServerFromSQLC(row))
+	}
+	return result, nil
+}
+
+// GetServerStatus returns the current status of any server of the master server.
+// Flags are mapped like in the status messages of the given channel.
+func (dao *DAO) GetServerStatus(ctx context.Context, address string, channelID discord.ChannelID) (server model.ServerStatus, err error) {
+	rows, err := dao.q.GetActiveServer(ctx, address)
+	if err != nil {
+		return model.ServerStatus{}, fmt.Errorf("failed to get server %s: %w", address, err)
+	}
+	if len(rows) == 0 {
+		return model.ServerStatus{}, i18n.WrapError(ErrNotFound, "error.server_not_listed", address)
+	}
+
+	row := rows[0]
+	server = model.ServerStatus{
+		Timestamp:    row.Timestamp.Time,
+		Address:      row.Address,
+		Name:         row.Name,
+		Gametype:     row.Gametype,
+		Passworded:   row.Passworded,
+		Map:          row.Map,
+		MapSha256Sum: row.MapSha256sum,
+		MapSize:      row.MapSize,
+		Version:      row.Version,
+		MaxClients:   row.MaxClients,
+		MaxPlayers:   row.MaxPlayers,
+		ScoreKind:    row.ScoreKind,
+	}
+	err = server.ProtocolsFromJSON([]byte(row.Protocols))
+	if err != nil {
+		return model.ServerStatus{}, err
+	}
+
+	clients, err := dao.q.ListActiveServerClients(ctx, sqlc.ListActiveServerClientsParams{
+		Address:   address,
+		ChannelID: int64(channelID),
+	})
+	if err != nil {
+		return model.ServerStatus{}, fmt.Errorf("failed to get clients of server %s: %w", address, err)
+	}
+	for _, c := range clients {
+		server.AddClientStatus(model.ClientStatus{
+			Name:      c.Name,
+			Clan:      c.Clan,
+			Country:   c.CountryID,
+			Score:     c.Score,
+			IsPlayer:  c.IsPlayer,
+			Team:      c.Team,
+			FlagAbbr:  c.Abbr,
+			FlagEmoji: c.FlagEmoji,
+		})
+	}
+	return server, nil
+}
+
+// AutocompleteServers returns servers of the master server whose address or name contains the search.
+func (dao *DAO) AutocompleteServers(ctx context.Context, search string) ([]model.AutocompleteChoice, error) {
+	rows, err := dao.q.AutocompleteServers(ctx, sqlc.AutocompleteServersParams{
+		Search:     search,
+		MaxEntries: model.MaxAutocompleteChoices,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to autocomplete servers: %w", err)
+	}
+
+	choices := make([]model.AutocompleteChoice, 0, len(rows))
+	for _, row := range rows {
+		choices = append(choices, model.NewServerChoiceFromSQLC(row))
+	}
+	return choices, nil
+}
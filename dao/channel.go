@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
 	"github.com/jxsl13/twstatus-bot/model"
 	"github.com/jxsl13/twstatus-bot/sqlc"
 )
@@ -20,7 +21,7 @@ func (dao *DAO) GetChannel(ctx context.Context, guildId discord.GuildID, channel
 	}
 
 	if len(runnings) == 0 {
-		return model.Channel{}, fmt.Errorf("%w: channel %d", ErrNotFound, channelID)
+		return model.Channel{}, i18n.WrapError(ErrNotFound, "error.channel_not_found", channelID.Mention())
 	}
 
 	running := runnings[0]
@@ -53,7 +54,7 @@ func (dao *DAO) AddChannel(ctx context.Context, channel model.Channel) (err erro
 	err = dao.q.AddGuildChannel(ctx, channel.ToSQLC())
 	if err != nil {
 		if IsUniqueConstraintErr(err) {
-			return fmt.Errorf("%w: channel %s", ErrAlreadyExists, channel)
+			return i18n.WrapError(ErrAlreadyExists, "error.channel_exists", channel.ID.Mention())
 		}
 		return fmt.Errorf("failed to insert channel %d: %w", channel.ID, err)
 	}
@@ -70,3 +71,15 @@ func (dao *DAO) RemoveChannel(ctx context.Context, guildID discord.GuildID, chan
 	}
 	return nil
 }
+
+// GetChannelRemovalSummary counts everything that is deleted together with the channel.
+func (dao *DAO) GetChannelRemovalSummary(ctx context.Context, channelID discord.ChannelID) (model.RemovalSummary, error) {
+	rows, err := dao.q.GetChannelRemovalSummary(ctx, int64(channelID))
+	if err != nil {
+		return model.RemovalSummary{}, fmt.Errorf("failed to summarize removal of channel %d: %w", channelID, err)
+	}
+	if len(rows) == 0 {
+		return model.RemovalSummary{}, nil
+	}
+	return model.NewChannelRemovalSummaryFromSQLC(rows[0]), nil
+}
@@ -0,0 +1,103 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+func (dao *DAO) ListDiscoveryRules(ctx context.Context, guildID discord.GuildID) (model.DiscoveryRules, error) {
+	rows, err := dao.q.ListDiscoveryRules(ctx, int64(guildID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list discovery rules: %w", err)
+	}
+
+	result := make(model.DiscoveryRules, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, model.NewDiscoveryRuleFromSQLC(row))
+	}
+	return result, nil
+}
+
+func (dao *DAO) AddDiscoveryRule(ctx context.Context, rule model.DiscoveryRule) error {
+	err := dao.q.AddDiscoveryRule(ctx, rule.ToAddSQLC())
+	if err != nil {
+		return fmt.Errorf("failed to add discovery rule for %s: %w", rule.Filter(i18n.English), err)
+	}
+	return nil
+}
+
+func (dao *DAO) RemoveDiscoveryRule(ctx context.Context, guildID discord.GuildID, id int64) error {
+	err := dao.q.RemoveDiscoveryRule(ctx, sqlc.RemoveDiscoveryRuleParams{
+		GuildID: int64(guildID),
+		ID:      id,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove discovery rule %d: %w", id, err)
+	}
+	return nil
+}
+
+// DiscoverServers remembers all servers of the master server and returns alerts for servers
+// that were never seen before and match a discovery rule.
+// No alerts are returned when the known servers are initialized.
+func (dao *DAO) DiscoverServers(ctx context.Context) ([]model.DiscoveryAlert, error) {
+	found, err := dao.q.HasKnownServers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check known servers: %w", err)
+	}
+	initialized := len(found) > 0 && found[0]
+
+	err = dao.q.TouchKnownServers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update known servers: %w", err)
+	}
+
+	addresses, err := dao.q.AddKnownServers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add known servers: %w", err)
+	}
+	if !initialized || len(addresses) == 0 {
+		return nil, nil
+	}
+
+	rules, err := dao.q.ListAllDiscoveryRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list discovery rules: %w", err)
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	rows, err := dao.q.ListDiscoveredServers(ctx, addresses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list discovered servers: %w", err)
+	}
+
+	servers := make(model.FoundServers, 0, len(rows))
+	for _, row := range rows {
+		servers = append(servers, model.NewFoundServerFromSQLC(sqlc.SearchServersRow(row)))
+	}
+
+	result := make(model.DiscoveryRules, 0, len(rules))
+	for _, row := range rules {
+		result = append(result, model.NewDiscoveryRuleFromSQLC(row))
+	}
+	return model.NewDiscoveryAlerts(result, servers), nil
+}
+
+// PruneKnownServers forgets servers that were not listed on the master server for longer than the retention.
+// Such servers are alerted again as new servers once they reappear.
+func (dao *DAO) PruneKnownServers(ctx context.Context, now time.Time, retention time.Duration) error {
+	err := dao.q.PruneKnownServers(ctx, pgtype.Timestamptz{Time: now.Add(-retention), Valid: true})
+	if err != nil {
+		return fmt.Errorf("failed to prune known servers: %w", err)
+	}
+	return nil
+}
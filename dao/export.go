@@ -0,0 +1,68 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+// GetExport returns the player count history, player sessions and map sessions of a server
+// in the range [from, to].
+func (dao *DAO) GetExport(ctx context.Context, address string, from, to time.Time) (_ model.Export, err error) {
+	history, err := dao.GetServerHistory(ctx, address, from)
+	if err != nil {
+		return model.Export{}, err
+	}
+
+	var (
+		since = pgtype.Timestamptz{Time: from, Valid: true}
+		until = pgtype.Timestamptz{Time: to, Valid: true}
+	)
+
+	sessionRows, err := dao.q.ExportPlayerSessions(ctx, sqlc.ExportPlayerSessionsParams{
+		Address: address,
+		Until:   until,
+		Since:   since,
+	})
+	if err != nil {
+		return model.Export{}, fmt.Errorf("failed to get sessions of %s: %w", address, err)
+	}
+
+	sessions := make(model.PlayerSessions, 0, len(sessionRows))
+	for _, row := range sessionRows {
+		sessions = append(sessions, model.PlayerSession{
+			Address:  row.Address,
+			Name:     row.Name,
+			Clan:     row.Clan,
+			JoinedAt: row.JoinedAt.Time,
+			LeftAt:   row.LeftAt.Time,
+		})
+	}
+
+	mapRows, err := dao.q.ExportMapSessions(ctx, sqlc.ExportMapSessionsParams{
+		Address: address,
+		Until:   until,
+		Since:   since,
+	})
+	if err != nil {
+		return model.Export{}, fmt.Errorf("failed to get map sessions of %s: %w", address, err)
+	}
+
+	maps := make(model.MapSessions, 0, len(mapRows))
+	for _, row := range mapRows {
+		maps = append(maps, model.NewMapSessionFromSQLC(sqlc.ListMapSessionsRow(row)))
+	}
+
+	return model.Export{
+		Address:     address,
+		From:        from,
+		To:          to,
+		History:     history,
+		Sessions:    sessions,
+		MapSessions: maps,
+	}, nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
 	"github.com/jxsl13/twstatus-bot/model"
 	"github.com/jxsl13/twstatus-bot/sqlc"
 )
@@ -62,7 +63,7 @@ func (dao *DAO) GetFlagMapping(
 		return model.FlagMapping{}, fmt.Errorf("failed to query flag mapping: %w", err)
 	}
 	if len(fm) == 0 {
-		return model.FlagMapping{}, fmt.Errorf("%w: flag mapping", ErrNotFound)
+		return model.FlagMapping{}, i18n.WrapError(ErrNotFound, "error.flag_mapping_not_found")
 	}
 	mapping := fm[0]
 	return model.FlagMapping{
@@ -86,7 +87,7 @@ func (dao *DAO) RemoveFlagMapping(
 		return err
 	}
 	if len(flag) == 0 {
-		return fmt.Errorf("%w: flag %s", ErrNotFound, abbr)
+		return i18n.WrapError(ErrNotFound, "error.flag_not_found", abbr)
 	}
 	f := flag[0]
 	return dao.q.RemoveFlagMapping(ctx, sqlc.RemoveFlagMappingParams{
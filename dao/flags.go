@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/jxsl13/twstatus-bot/i18n"
 	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/sqlc"
 )
 
 func (dao *DAO) ListFlags(ctx context.Context) (_ []model.Flag, err error) {
@@ -29,7 +31,7 @@ func (dao *DAO) GetFlag(ctx context.Context, flagId int16) (_ model.Flag, err er
 		return model.Flag{}, fmt.Errorf("failed to query flag: %w", err)
 	}
 	if len(fs) == 0 {
-		return model.Flag{}, fmt.Errorf("%w: flag with id %d", ErrNotFound, flagId)
+		return model.Flag{}, i18n.WrapError(ErrNotFound, "error.flag_not_found", flagId)
 	}
 	flag := fs[0]
 
@@ -46,7 +48,7 @@ func (dao *DAO) GetFlagByAbbr(ctx context.Context, abbr string) (_ model.Flag, e
 		return model.Flag{}, fmt.Errorf("failed to query flag: %w", err)
 	}
 	if len(fs) == 0 {
-		return model.Flag{}, fmt.Errorf("%w: flag with abbr %s", ErrNotFound, abbr)
+		return model.Flag{}, i18n.WrapError(ErrNotFound, "error.flag_not_found", abbr)
 	}
 	flag := fs[0]
 	return model.Flag{
@@ -55,3 +57,20 @@ func (dao *DAO) GetFlagByAbbr(ctx context.Context, abbr string) (_ model.Flag, e
 		Emoji: flag.Emoji,
 	}, nil
 }
+
+// AutocompleteFlags returns the flags whose abbreviation contains the search.
+func (dao *DAO) AutocompleteFlags(ctx context.Context, search string) ([]model.AutocompleteChoice, error) {
+	fs, err := dao.q.AutocompleteFlags(ctx, sqlc.AutocompleteFlagsParams{
+		Search:     search,
+		MaxEntries: model.MaxAutocompleteChoices,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to autocomplete flags: %w", err)
+	}
+
+	choices := make([]model.AutocompleteChoice, 0, len(fs))
+	for _, flag := range fs {
+		choices = append(choices, model.NewFlagChoiceFromSQLC(flag))
+	}
+	return choices, nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
 	"github.com/jxsl13/twstatus-bot/model"
 )
 
@@ -27,7 +28,7 @@ func (dao *DAO) AddGuild(ctx context.Context, guild model.Guild) (err error) {
 	err = dao.q.AddGuild(ctx, guild.ToSQLC())
 	if err != nil {
 		if IsUniqueConstraintErr(err) {
-			return fmt.Errorf("%w: guild %d", ErrAlreadyExists, guild.ID)
+			return i18n.WrapError(ErrAlreadyExists, "error.guild_exists", guild.ID)
 		}
 		return fmt.Errorf("failed to insert guild %d: %w", guild.ID, err)
 	}
@@ -40,7 +41,7 @@ func (dao *DAO) GetGuild(ctx context.Context, guildID discord.GuildID) (guild mo
 		return model.Guild{}, fmt.Errorf("failed to query guild: %w", err)
 	}
 	if len(gs) == 0 {
-		return model.Guild{}, fmt.Errorf("%w: guild %d", ErrNotFound, guildID)
+		return model.Guild{}, i18n.WrapError(ErrNotFound, "error.guild_not_found", guildID)
 	}
 	g := gs[0]
 	return model.Guild{
@@ -62,3 +63,15 @@ func (dao *DAO) RemoveGuild(ctx context.Context, guildID discord.GuildID) (guild
 	}
 	return guild, err
 }
+
+// GetGuildRemovalSummary counts everything that is deleted together with the guild.
+func (dao *DAO) GetGuildRemovalSummary(ctx context.Context, guildID discord.GuildID) (model.RemovalSummary, error) {
+	rows, err := dao.q.GetGuildRemovalSummary(ctx, int64(guildID))
+	if err != nil {
+		return model.RemovalSummary{}, fmt.Errorf("failed to summarize removal of guild %d: %w", guildID, err)
+	}
+	if len(rows) == 0 {
+		return model.RemovalSummary{}, nil
+	}
+	return model.NewGuildRemovalSummaryFromSQLC(rows[0]), nil
+}
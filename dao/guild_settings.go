@@ -0,0 +1,29 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/model"
+)
+
+// GetGuildSettings returns the default settings in case the guild did not configure any.
+func (dao *DAO) GetGuildSettings(ctx context.Context, guildID discord.GuildID) (model.GuildSettings, error) {
+	rows, err := dao.q.GetGuildSettings(ctx, int64(guildID))
+	if err != nil {
+		return model.GuildSettings{}, fmt.Errorf("failed to get settings of guild %s: %w", guildID, err)
+	}
+	if len(rows) == 0 {
+		return model.DefaultGuildSettings(guildID), nil
+	}
+	return model.NewGuildSettingsFromSQLC(rows[0])
+}
+
+func (dao *DAO) SetGuildSettings(ctx context.Context, s model.GuildSettings) error {
+	err := dao.q.SetGuildSettings(ctx, s.ToSetSQLC())
+	if err != nil {
+		return fmt.Errorf("failed to set settings of guild %s: %w", s.GuildID, err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+func (dao *DAO) ListMapChangeSubscriptions(
+	ctx context.Context,
+	guildID discord.GuildID,
+	channelID discord.ChannelID,
+	userID discord.UserID,
+) (
+	_ model.MapChangeSubscriptions,
+	err error,
+) {
+	rows, err := dao.q.ListMapChangeSubscriptions(ctx, sqlc.ListMapChangeSubscriptionsParams{
+		GuildID:   int64(guildID),
+		ChannelID: int64(channelID),
+		UserID:    int64(userID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query map change subscriptions: %w", err)
+	}
+
+	result := make(model.MapChangeSubscriptions, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, model.MapChangeSubscription{
+			MessageUserTarget: model.MessageUserTarget{
+				MessageTarget: model.MessageTarget{
+					ChannelTarget: model.ChannelTarget{
+						GuildID:   guildID,
+						ChannelID: channelID,
+					},
+					MessageID: discord.MessageID(row.MessageID),
+				},
+				UserID: userID,
+			},
+			Address: row.Address,
+			Pattern: row.Pattern,
+			DM:      row.Dm,
+		})
+	}
+	return result, nil
+}
+
+func (dao *DAO) AddMapChangeSubscription(ctx context.Context, s model.MapChangeSubscription) (err error) {
+	err = dao.q.AddMapChangeSubscription(ctx, s.ToAddSQLC())
+	if err != nil {
+		return fmt.Errorf("failed to add map change subscription for %s: %w", s.Address, err)
+	}
+	return nil
+}
+
+func (dao *DAO) RemoveMapChangeSubscription(ctx context.Context, s model.MapChangeSubscription) (err error) {
+	err = dao.q.RemoveMapChangeSubscription(ctx, s.ToRemoveSQLC())
+	if err != nil {
+		return fmt.Errorf("failed to remove map change subscription for %s: %w", s.Address, err)
+	}
+	return nil
+}
+
+// GetMapChangeNotifications returns all subscriptions that match the new map
+// of tracked servers which changed their map.
+func (dao *DAO) GetMapChangeNotifications(
+	ctx context.Context,
+	changes map[model.MessageTarget]model.ChangedServerStatus,
+) (
+	_ []model.MapChangeNotification,
+	err error,
+) {
+	var (
+		messageIDs = make([]int64, 0, len(changes))
+		mapChanges = make(map[int64]model.MapChange, len(changes))
+	)
+	for target, change := range changes {
+		if change.Offline || change.Prev.Address == "" || change.Prev.Map == change.Curr.Map {
+			continue
+		}
+		messageIDs = append(messageIDs, int64(target.MessageID))
+		mapChanges[int64(target.MessageID)] = model.MapChange{
+			Address: change.Curr.Address,
+			Name:    change.Curr.Name,
+			Map:     change.Curr.Map,
+		}
+	}
+
+	if len(messageIDs) == 0 {
+		return []model.MapChangeNotification{}, nil
+	}
+
+	rows, err := dao.q.GetMapChangeSubscriptions(ctx, messageIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query map change subscriptions: %w", err)
+	}
+
+	userIDs := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		userIDs = append(userIDs, row.UserID)
+	}
+
+	// map changes are not deferred, because the map is likely to change again
+	quiet, err := dao.quietUsers(ctx, userIDs, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.MapChangeNotification, 0, len(rows))
+	for _, row := range rows {
+		mc := mapChanges[row.MessageID]
+		if !model.MatchMapPattern(row.Pattern, mc.Map) || quiet[discord.UserID(row.UserID)] {
+			continue
+		}
+
+		result = append(result, model.MapChangeNotification{
+			MapChange: mc,
+			MessageUserTarget: model.MessageUserTarget{
+				MessageTarget: model.MessageTarget{
+					ChannelTarget: model.ChannelTarget{
+						GuildID:   discord.GuildID(row.GuildID),
+						ChannelID: discord.ChannelID(row.ChannelID),
+					},
+					MessageID: discord.MessageID(row.MessageID),
+				},
+				UserID: discord.UserID(row.UserID),
+			},
+			PrevMessageID: discord.MessageID(row.PrevMessageID),
+			DM:            row.Dm,
+		})
+	}
+	return result, nil
+}
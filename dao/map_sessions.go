@@ -0,0 +1,128 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+// UpdateMapSessions ends the sessions of servers that changed their map or went offline
+// and starts sessions for their current map.
+func (dao *DAO) UpdateMapSessions(ctx context.Context, changes map[model.MessageTarget]model.ChangedServerStatus) error {
+	current := model.NewMapSessionChanges(changes)
+	if len(current) == 0 {
+		return nil
+	}
+
+	var (
+		addresses = make([]string, 0, len(current))
+		maps      = make([]string, 0, len(current))
+	)
+	for _, s := range current {
+		addresses = append(addresses, s.Address)
+		maps = append(maps, s.Map)
+	}
+
+	err := dao.q.EndMapSessions(ctx, sqlc.EndMapSessionsParams{
+		Addresses: addresses,
+		Maps:      maps,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to end %d map sessions: %w", len(current), err)
+	}
+
+	err = dao.q.StartMapSessions(ctx, sqlc.StartMapSessionsParams{
+		Addresses: addresses,
+		Maps:      maps,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start %d map sessions: %w", len(current), err)
+	}
+	return nil
+}
+
+// AddMapSessionPlayers updates the peak player count of the current map sessions and
+// credits the given duration to them for every player.
+func (dao *DAO) AddMapSessionPlayers(ctx context.Context, d time.Duration) error {
+	servers, err := dao.ActiveServers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get active servers: %w", err)
+	}
+
+	current := model.NewMapSessionPlayers(servers)
+	if len(current) == 0 {
+		return nil
+	}
+
+	params := sqlc.AddMapSessionPlayersParams{
+		Seconds:   int32(d / time.Second),
+		Addresses: make([]string, 0, len(current)),
+		Maps:      make([]string, 0, len(current)),
+		Players:   make([]int16, 0, len(current)),
+	}
+	for _, s := range current {
+		params.Addresses = append(params.Addresses, s.Address)
+		params.Maps = append(params.Maps, s.Map)
+		params.Players = append(params.Players, int16(s.PeakPlayers))
+	}
+
+	err = dao.q.AddMapSessionPlayers(ctx, params)
+	if err != nil {
+		return fmt.Errorf("failed to add players to %d map sessions: %w", len(current), err)
+	}
+	return nil
+}
+
+// PruneMapSessions ends sessions of servers that are no longer tracked by any running channel
+// and removes sessions that ended before the retention period.
+func (dao *DAO) PruneMapSessions(ctx context.Context, now time.Time, retention time.Duration) error {
+	err := dao.q.CloseStaleMapSessions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to end stale map sessions: %w", err)
+	}
+
+	err = dao.q.PruneMapSessions(ctx, pgtype.Timestamptz{Time: now.Add(-retention), Valid: true})
+	if err != nil {
+		return fmt.Errorf("failed to prune map sessions: %w", err)
+	}
+	return nil
+}
+
+// ListMapSessions returns the most recent map sessions of a server.
+func (dao *DAO) ListMapSessions(ctx context.Context, address string, limit int) (model.MapSessions, error) {
+	rows, err := dao.q.ListMapSessions(ctx, sqlc.ListMapSessionsParams{
+		Address:     address,
+		MaxSessions: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list map sessions of %s: %w", address, err)
+	}
+
+	result := make(model.MapSessions, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, model.NewMapSessionFromSQLC(row))
+	}
+	return result, nil
+}
+
+// GetPopularMaps returns the maps of a server with the most player time since the given point in time.
+func (dao *DAO) GetPopularMaps(ctx context.Context, address string, since time.Time, limit int) (model.MapPopularities, error) {
+	rows, err := dao.q.GetPopularMaps(ctx, sqlc.GetPopularMapsParams{
+		Address:    address,
+		Since:      pgtype.Timestamptz{Time: since, Valid: true},
+		MaxEntries: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get popular maps of %s: %w", address, err)
+	}
+
+	result := make(model.MapPopularities, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, model.NewMapPopularityFromSQLC(row))
+	}
+	return result, nil
+}
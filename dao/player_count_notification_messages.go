@@ -3,10 +3,12 @@ package dao
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/jxsl13/twstatus-bot/model"
 	"github.com/jxsl13/twstatus-bot/sqlc"
+	"github.com/jxsl13/twstatus-bot/utils"
 )
 
 func (dao *DAO) AddPlayerCountNotificationMessage(ctx context.Context, channelID discord.ChannelID, messageID discord.MessageID) error {
@@ -24,11 +26,50 @@ func (dao *DAO) RemovePlayerCountNotificationMessage(ctx context.Context, channe
 }
 
 func (dao *DAO) GetPlayerCountNotificationMessages(ctx context.Context, addresses []string) ([]model.PlayerCountNotificationMessage, error) {
+	now := time.Now()
+
+	// notifications that were deferred due to quiet hours are reevaluated
+	// once the quiet hours are over
+	deferred, err := dao.dueDeferredAddresses(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	addresses = utils.MergeSliceUnique(addresses, deferred)
 
 	gpcnmr, err := dao.q.GetPlayerCountNotificationMessages(ctx, addresses)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query player count notification messages: %w", err)
 	}
 
-	return model.NewPlayerCountNotificationMessages(gpcnmr), nil
+	gpcnmr, err = dao.deferQuietPlayerCountNotifications(ctx, gpcnmr, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// roles are pinged again once the player count dropped below the threshold
+	err = dao.q.ResetPlayerCountRoleNotifications(ctx, addresses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reset player count role notifications: %w", err)
+	}
+
+	gpcrnmr, err := dao.q.GetPlayerCountRoleNotificationMessages(ctx, addresses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query player count role notification messages: %w", err)
+	}
+
+	return model.NewPlayerCountNotificationMessages(gpcnmr, gpcrnmr), nil
+}
+
+// MarkPlayerCountRoleNotified starts the cooldown of a role notification after it was sent.
+func (dao *DAO) MarkPlayerCountRoleNotified(ctx context.Context, target model.ChannelTarget, mr model.MessageRoleID) error {
+	err := dao.q.MarkPlayerCountRoleNotified(ctx, sqlc.MarkPlayerCountRoleNotifiedParams{
+		GuildID:   int64(target.GuildID),
+		ChannelID: int64(target.ChannelID),
+		MessageID: int64(mr.MessageID),
+		RoleID:    int64(mr.RoleID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark player count role notification as notified: %w", err)
+	}
+	return nil
 }
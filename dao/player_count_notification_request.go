@@ -2,9 +2,9 @@ package dao
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
 	"github.com/jxsl13/twstatus-bot/model"
 	"github.com/jxsl13/twstatus-bot/sqlc"
 )
@@ -29,7 +29,7 @@ func (dao *DAO) GetPlayerCountNotificationRequest(
 		return model.PlayerCountNotificationRequest{}, err
 	}
 	if len(ns) == 0 {
-		return model.PlayerCountNotificationRequest{}, fmt.Errorf("%w: player count notification", ErrNotFound)
+		return model.PlayerCountNotificationRequest{}, i18n.WrapError(ErrNotFound, "error.notification_request_not_found")
 	}
 	n := ns[0]
 	return model.PlayerCountNotificationRequest{
@@ -48,32 +48,11 @@ func (dao *DAO) GetPlayerCountNotificationRequest(
 
 }
 
-func (dao *DAO) SetPlayerCountNotificationRequestList(ctx context.Context, notifications []model.PlayerCountNotificationRequest) (err error) {
-
-	for _, n := range notifications {
-		err = dao.q.SetPlayerCountNotificationRequest(ctx, sqlc.SetPlayerCountNotificationRequestParams{
-			GuildID:   int64(n.GuildID),
-			ChannelID: int64(n.ChannelID),
-			MessageID: int64(n.MessageID),
-			UserID:    int64(n.UserID),
-			Threshold: int16(n.Threshold),
-		})
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
 func (dao *DAO) SetPlayerCountNotificationRequest(ctx context.Context, n model.PlayerCountNotificationRequest) (err error) {
 	return dao.q.SetPlayerCountNotificationRequest(ctx, n.ToSetSQLC())
 
 }
 
-func (dao *DAO) RemovePlayerCountNotificationRequests(ctx context.Context) (err error) {
-	return dao.q.RemovePlayerCountNotificationRequests(ctx)
-}
-
 func (dao *DAO) RemovePlayerCountNotificationRequest(ctx context.Context, n model.PlayerCountNotificationRequest) (err error) {
 	return dao.q.RemovePlayerCountNotificationRequest(ctx, n.ToRemoveSQLC())
 
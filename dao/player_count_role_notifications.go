@@ -0,0 +1,63 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+func (dao *DAO) ListPlayerCountRoleNotifications(
+	ctx context.Context,
+	guildID discord.GuildID,
+	channelID discord.ChannelID,
+) (
+	_ model.PlayerCountRoleNotifications,
+	err error,
+) {
+	rows, err := dao.q.ListPlayerCountRoleNotifications(ctx, sqlc.ListPlayerCountRoleNotificationsParams{
+		GuildID:   int64(guildID),
+		ChannelID: int64(channelID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query player count role notifications: %w", err)
+	}
+
+	result := make(model.PlayerCountRoleNotifications, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, model.PlayerCountRoleNotification{
+			MessageTarget: model.MessageTarget{
+				ChannelTarget: model.ChannelTarget{
+					GuildID:   guildID,
+					ChannelID: channelID,
+				},
+				MessageID: discord.MessageID(row.MessageID),
+			},
+			Address:      row.Address,
+			RoleID:       discord.RoleID(row.RoleID),
+			Threshold:    int(row.Threshold),
+			Cooldown:     time.Duration(row.Cooldown) * time.Second,
+			LastNotified: row.LastNotified.Time,
+		})
+	}
+	return result, nil
+}
+
+func (dao *DAO) SetPlayerCountRoleNotification(ctx context.Context, n model.PlayerCountRoleNotification) (err error) {
+	err = dao.q.SetPlayerCountRoleNotification(ctx, n.ToSetSQLC())
+	if err != nil {
+		return fmt.Errorf("failed to set player count role notification for %s: %w", n.Address, err)
+	}
+	return nil
+}
+
+func (dao *DAO) RemovePlayerCountRoleNotification(ctx context.Context, n model.PlayerCountRoleNotification) (err error) {
+	err = dao.q.RemovePlayerCountRoleNotification(ctx, n.ToRemoveSQLC())
+	if err != nil {
+		return fmt.Errorf("failed to remove player count role notification for %s: %w", n.Address, err)
+	}
+	return nil
+}
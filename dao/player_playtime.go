@@ -0,0 +1,100 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+// AddPlayerPlaytime credits the given duration to all players that are currently
+// playing on servers which are tracked by running channels.
+func (dao *DAO) AddPlayerPlaytime(ctx context.Context, now time.Time, d time.Duration) error {
+	servers, err := dao.ActiveServers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get active servers: %w", err)
+	}
+
+	playtimes := model.NewPlaytimes(servers, d)
+	if len(playtimes) == 0 {
+		return nil
+	}
+
+	params := sqlc.AddPlayerPlaytimeParams{
+		Day:       pgtype.Date{Time: now.UTC(), Valid: true},
+		Seconds:   int32(d / time.Second),
+		Addresses: make([]string, 0, len(playtimes)),
+		Names:     make([]string, 0, len(playtimes)),
+		Clans:     make([]string, 0, len(playtimes)),
+	}
+	for _, p := range playtimes {
+		params.Addresses = append(params.Addresses, p.Address)
+		params.Names = append(params.Names, p.Name)
+		params.Clans = append(params.Clans, p.Clan)
+	}
+
+	err = dao.q.AddPlayerPlaytime(ctx, params)
+	if err != nil {
+		return fmt.Errorf("failed to add playtime of %d players: %w", len(playtimes), err)
+	}
+	return nil
+}
+
+// GetPlaytimeLeaderboard returns the players or clans with the most playtime in the range [since, until)
+// on the given server or all servers that are tracked by the guild in case the address is empty.
+func (dao *DAO) GetPlaytimeLeaderboard(
+	ctx context.Context,
+	guildID discord.GuildID,
+	address string,
+	clans bool,
+	since, until time.Time,
+	limit int,
+) (
+	_ []model.PlaytimeEntry,
+	err error,
+) {
+	var (
+		from = pgtype.Date{Time: since, Valid: true}
+		to   = pgtype.Date{Time: until, Valid: true}
+	)
+
+	if clans {
+		rows, err := dao.q.GetClanPlaytimeLeaderboard(ctx, sqlc.GetClanPlaytimeLeaderboardParams{
+			GuildID:    int64(guildID),
+			Address:    address,
+			Since:      from,
+			Until:      to,
+			MaxEntries: int32(limit),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get clan playtime leaderboard: %w", err)
+		}
+
+		result := make([]model.PlaytimeEntry, 0, len(rows))
+		for _, row := range rows {
+			result = append(result, model.NewClanPlaytimeEntryFromSQLC(row))
+		}
+		return result, nil
+	}
+
+	rows, err := dao.q.GetPlayerPlaytimeLeaderboard(ctx, sqlc.GetPlayerPlaytimeLeaderboardParams{
+		GuildID:    int64(guildID),
+		Address:    address,
+		Since:      from,
+		Until:      to,
+		MaxEntries: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player playtime leaderboard: %w", err)
+	}
+
+	result := make([]model.PlaytimeEntry, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, model.NewPlayerPlaytimeEntryFromSQLC(row))
+	}
+	return result, nil
+}
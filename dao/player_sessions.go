@@ -0,0 +1,114 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+// UpdatePlayerSessions opens sessions of players that joined and closes sessions
+// of players that left the changed servers.
+func (dao *DAO) UpdatePlayerSessions(ctx context.Context, changes map[model.MessageTarget]model.ChangedServerStatus) error {
+	sc := model.NewPlayerSessionChanges(changes)
+
+	if len(sc.Left) > 0 {
+		params := sqlc.ClosePlayerSessionsParams{
+			Addresses: make([]string, 0, len(sc.Left)),
+			Names:     make([]string, 0, len(sc.Left)),
+		}
+		for _, s := range sc.Left {
+			params.Addresses = append(params.Addresses, s.Address)
+			params.Names = append(params.Names, s.Name)
+		}
+		err := dao.q.ClosePlayerSessions(ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to close %d player sessions: %w", len(sc.Left), err)
+		}
+	}
+
+	if len(sc.Joined) > 0 {
+		params := sqlc.OpenPlayerSessionsParams{
+			Addresses: make([]string, 0, len(sc.Joined)),
+			Names:     make([]string, 0, len(sc.Joined)),
+			Clans:     make([]string, 0, len(sc.Joined)),
+		}
+		for _, s := range sc.Joined {
+			params.Addresses = append(params.Addresses, s.Address)
+			params.Names = append(params.Names, s.Name)
+			params.Clans = append(params.Clans, s.Clan)
+		}
+		err := dao.q.OpenPlayerSessions(ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to open %d player sessions: %w", len(sc.Joined), err)
+		}
+	}
+	return nil
+}
+
+// PrunePlayerSessions closes sessions of servers that are no longer tracked by any running channel
+// and removes sessions that ended before the retention period.
+func (dao *DAO) PrunePlayerSessions(ctx context.Context, now time.Time, retention time.Duration) error {
+	err := dao.q.CloseStalePlayerSessions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to close stale player sessions: %w", err)
+	}
+
+	err = dao.q.PrunePlayerSessions(ctx, pgtype.Timestamptz{Time: now.Add(-retention), Valid: true})
+	if err != nil {
+		return fmt.Errorf("failed to prune player sessions: %w", err)
+	}
+	return nil
+}
+
+// GetLastPlayerSessions returns the most recent sessions of a player on servers that are tracked by the guild.
+// The name is matched case insensitively.
+func (dao *DAO) GetLastPlayerSessions(ctx context.Context, guildID discord.GuildID, name string, limit int) (model.PlayerSessions, error) {
+	rows, err := dao.q.GetLastPlayerSessions(ctx, sqlc.GetLastPlayerSessionsParams{
+		Name:        name,
+		GuildID:     int64(guildID),
+		MaxSessions: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions of player %q: %w", name, err)
+	}
+
+	result := make(model.PlayerSessions, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, model.PlayerSession{
+			Address:  row.Address,
+			Name:     row.Name,
+			Clan:     row.Clan,
+			JoinedAt: row.JoinedAt.Time,
+			LeftAt:   row.LeftAt.Time,
+		})
+	}
+	return result, nil
+}
+
+// ListPlayerSessions returns the most recent sessions of a server.
+func (dao *DAO) ListPlayerSessions(ctx context.Context, address string, limit int) (model.PlayerSessions, error) {
+	rows, err := dao.q.ListPlayerSessions(ctx, sqlc.ListPlayerSessionsParams{
+		Address:     address,
+		MaxSessions: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions of %s: %w", address, err)
+	}
+
+	result := make(model.PlayerSessions, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, model.PlayerSession{
+			Address:  row.Address,
+			Name:     row.Name,
+			Clan:     row.Clan,
+			JoinedAt: row.JoinedAt.Time,
+			LeftAt:   row.LeftAt.Time,
+		})
+	}
+	return result, nil
+}
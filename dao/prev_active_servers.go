@@ -200,3 +200,17 @@ func (dao *DAO) removePrevActiveClients(ctx context.Context, messageIds []discor
 	}
 	return nil
 }
+
+// ResetPrevActiveServers forces the status messages to be updated by the next change detection.
+func (dao *DAO) ResetPrevActiveServers(ctx context.Context, messageIds []discord.MessageID) (err error) {
+	err = dao.removePrevActiveServers(ctx, messageIds)
+	if err != nil {
+		return err
+	}
+
+	err = dao.removePrevActiveClients(ctx, messageIds)
+	if err != nil {
+		return fmt.Errorf("failed to delete previous active clients: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+const (
+	hourlyBucketSize = time.Hour
+	dailyBucketSize  = 24 * time.Hour
+)
+
+// AddServerHistory adds a player count sample of every tracked server based on the
+// current master server state. Servers that have a sample which is more recent than
+// the resolution are skipped.
+func (dao *DAO) AddServerHistory(ctx context.Context, resolution time.Duration) error {
+	err := dao.q.AddServerHistory(ctx, resolution.Seconds())
+	if err != nil {
+		return fmt.Errorf("failed to add server history: %w", err)
+	}
+	return nil
+}
+
+// RollupServerHistory aggregates the raw samples of all complete hours and days that were
+// not aggregated yet into hourly and daily buckets. Buckets that were missed while the bot
+// was not running are aggregated as long as their raw samples were not pruned.
+func (dao *DAO) RollupServerHistory(ctx context.Context, now time.Time) error {
+	for _, size := range []time.Duration{hourlyBucketSize, dailyBucketSize} {
+		// buckets are aligned to UTC, the current bucket is aggregated once it is complete
+		until := now.UTC().Truncate(size)
+		err := dao.q.RollupServerHistory(ctx, sqlc.RollupServerHistoryParams{
+			BucketSize: int32(size / time.Second),
+			Until:      pgtype.Timestamptz{Time: until, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to roll up server history into buckets of %s: %w", size, err)
+		}
+	}
+	return nil
+}
+
+// PruneServerHistory removes raw samples and hourly aggregates that exceed their retention.
+// Daily aggregates are kept forever.
+func (dao *DAO) PruneServerHistory(ctx context.Context, now time.Time, retention, hourlyRetention time.Duration) error {
+	err := dao.q.PruneServerHistory(ctx, pgtype.Timestamptz{Time: now.Add(-retention), Valid: true})
+	if err != nil {
+		return fmt.Errorf("failed to prune server history: %w", err)
+	}
+
+	err = dao.q.PruneServerHistoryRollups(ctx, sqlc.PruneServerHistoryRollupsParams{
+		BucketSize: int32(hourlyBucketSize / time.Second),
+		Bucket:     pgtype.Timestamptz{Time: now.Add(-hourlyRetention), Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to prune hourly server history: %w", err)
+	}
+	return nil
+}
+
+// GetServerHistory returns the player count history of a server since the given point in time.
+// Aggregated samples are used for the time ranges in which raw samples were already pruned.
+func (dao *DAO) GetServerHistory(ctx context.Context, address string, since time.Time) (_ model.ServerHistory, err error) {
+	ts := pgtype.Timestamptz{Time: since, Valid: true}
+
+	raw, err := dao.q.GetServerHistory(ctx, sqlc.GetServerHistoryParams{
+		Address:   address,
+		Timestamp: ts,
+	})
+	if err != nil {
+		return model.ServerHistory{}, fmt.Errorf("failed to get server history of %s: %w", address, err)
+	}
+
+	rollups := make([][]sqlc.ServerHistoryRollup, 0, 2)
+	for _, size := range []time.Duration{dailyBucketSize, hourlyBucketSize} {
+		rows, err := dao.q.GetServerHistoryRollups(ctx, sqlc.GetServerHistoryRollupsParams{
+			Address:    address,
+			BucketSize: int32(size / time.Second),
+			Bucket:     ts,
+		})
+		if err != nil {
+			return model.ServerHistory{}, fmt.Errorf("failed to get server history of %s in buckets of %s: %w", address, size, err)
+		}
+		rollups = append(rollups, rows)
+	}
+
+	return model.NewServerHistoryFromSQLC(address, rollups[0], rollups[1], raw), nil
+}
+
+// GetActivityHeatmap returns the average player count per weekday and hour of the given servers
+// during the last weeks.
+func (dao *DAO) GetActivityHeatmap(ctx context.Context, addresses []string, now time.Time, loc *time.Location) (model.ActivityHeatmap, error) {
+	since := now.Add(-model.HeatmapWeeks * 7 * 24 * time.Hour)
+	rows, err := dao.q.ListServerHistoryRollups(ctx, sqlc.ListServerHistoryRollupsParams{
+		Addresses:  addresses,
+		BucketSize: int32(hourlyBucketSize / time.Second),
+		Bucket:     pgtype.Timestamptz{Time: since, Valid: true},
+	})
+	if err != nil {
+		return model.ActivityHeatmap{}, fmt.Errorf("failed to get hourly server history: %w", err)
+	}
+	return model.NewActivityHeatmap(rows, loc), nil
+}
+
+// GetForecastModels trains a forecast model for every given server with the hourly aggregates of the last weeks.
+func (dao *DAO) GetForecastModels(ctx context.Context, addresses []string, now time.Time) (map[string]model.ForecastModel, error) {
+	since := now.Add(-model.ForecastWeeks * 7 * 24 * time.Hour)
+	rows, err := dao.q.ListServerHistoryRollups(ctx, sqlc.ListServerHistoryRollupsParams{
+		Addresses:  addresses,
+		BucketSize: int32(hourlyBucketSize / time.Second),
+		Bucket:     pgtype.Timestamptz{Time: since, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hourly server history: %w", err)
+	}
+	return model.NewForecastModels(rows, now), nil
+}
+
+// GetForecast predicts the player count of a server for the given number of hours.
+func (dao *DAO) GetForecast(ctx context.Context, address string, now time.Time, hours int) (model.Forecast, error) {
+	models, err := dao.GetForecastModels(ctx, []string{address}, now)
+	if err != nil {
+		return model.Forecast{}, err
+	}
+
+	// the most recent sample is the current player count
+	history, err := dao.GetServerHistory(ctx, address, now.Add(-time.Hour))
+	if err != nil {
+		return model.Forecast{}, err
+	}
+	current := 0.0
+	if n := len(history.Samples); n > 0 && history.Samples[n-1].Online {
+		current = history.Samples[n-1].NumPlayers
+	}
+
+	forecast := model.Forecast{
+		Address: address,
+		Current: current,
+	}
+	if m, ok := models[address]; ok {
+		forecast.Points = m.Forecast(now, current, hours)
+		forecast.BusyThreshold = m.BusyThreshold()
+	}
+	return forecast, nil
+}
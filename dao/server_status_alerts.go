@@ -0,0 +1,211 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+func (dao *DAO) ListServerStatusAlerts(
+	ctx context.Context,
+	guildID discord.GuildID,
+	channelID discord.ChannelID,
+) (
+	_ model.ServerStatusAlertSettings,
+	err error,
+) {
+	rows, err := dao.q.ListServerStatusAlerts(ctx, sqlc.ListServerStatusAlertsParams{
+		GuildID:   int64(guildID),
+		ChannelID: int64(channelID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server status alerts: %w", err)
+	}
+
+	result := make(model.ServerStatusAlertSettings, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, model.ServerStatusAlertSetting{
+			Tracking: model.Tracking{
+				MessageTarget: model.MessageTarget{
+					ChannelTarget: model.ChannelTarget{
+						GuildID:   guildID,
+						ChannelID: channelID,
+					},
+					MessageID: discord.MessageID(row.MessageID),
+				},
+				Address: row.Address,
+			},
+			AlertChannelID: discord.ChannelID(fromNullableID(row.AlertChannelID)),
+			AlertUserID:    discord.UserID(fromNullableID(row.AlertUserID)),
+			Debounce:       time.Duration(row.Debounce) * time.Second,
+		})
+	}
+	return result, nil
+}
+
+func (dao *DAO) SetServerStatusAlert(ctx context.Context, s model.ServerStatusAlertSetting) (err error) {
+	err = dao.q.SetServerStatusAlert(ctx, s.ToSetSQLC())
+	if err != nil {
+		return fmt.Errorf("failed to set server status alert for %s: %w", s.Address, err)
+	}
+	return nil
+}
+
+func (dao *DAO) RemoveServerStatusAlert(ctx context.Context, t model.Tracking) (err error) {
+	err = dao.q.RemoveServerStatusAlert(ctx, sqlc.RemoveServerStatusAlertParams{
+		GuildID:   int64(t.GuildID),
+		ChannelID: int64(t.ChannelID),
+		MessageID: int64(t.MessageID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove server status alert for %s: %w", t.Address, err)
+	}
+	return nil
+}
+
+// UpdateServerStatusAlerts keeps track of tracked servers that went offline
+// and returns all alerts that must be sent out.
+// Offline alerts are only returned once the server has been offline for
+// longer than the configured debounce duration. Recovery alerts are only
+// returned for servers that were previously alerted as offline.
+func (dao *DAO) UpdateServerStatusAlerts(
+	ctx context.Context,
+	changes map[model.MessageTarget]model.ChangedServerStatus,
+) (
+	alerts []model.ServerStatusAlert,
+	err error,
+) {
+	ids, err := dao.q.ListAllServerStatusAlerts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server status alerts: %w", err)
+	}
+
+	byMessageID := make(map[int64]model.ChangedServerStatus, len(changes))
+	for target, change := range changes {
+		byMessageID[int64(target.MessageID)] = change
+	}
+
+	now := time.Now()
+	for _, id := range ids {
+		change, ok := byMessageID[id]
+		if !ok {
+			continue
+		}
+
+		if change.Offline {
+			err = dao.q.AddServerStatusAlertState(ctx, sqlc.AddServerStatusAlertStateParams{
+				MessageID:    id,
+				Name:         change.Prev.Name,
+				Map:          change.Prev.Map,
+				NumPlayers:   int16(change.Prev.NumPlayers),
+				MaxPlayers:   change.Prev.MaxPlayers,
+				OfflineSince: pgtype.Timestamptz{Time: now, Valid: true},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to add server status alert state: %w", err)
+			}
+			continue
+		}
+
+		if change.Prev.Address != "" {
+			// server was online before and is still online
+			continue
+		}
+
+		// server is back online
+		rows, err := dao.q.RemoveServerStatusAlertState(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to remove server status alert state: %w", err)
+		}
+		for _, row := range rows {
+			if !row.Alerted {
+				// recovered within the debounce duration
+				continue
+			}
+			alerts = append(alerts, model.ServerStatusAlert{
+				ServerStatusAlertSetting: serverStatusAlertSetting(
+					row.GuildID,
+					row.ChannelID,
+					row.MessageID,
+					row.Address,
+					row.AlertChannelID,
+					row.AlertUserID,
+					row.Debounce,
+				),
+				Name:         change.Curr.Name,
+				Map:          row.Map,
+				NumPlayers:   int(row.NumPlayers),
+				MaxPlayers:   int(row.MaxPlayers),
+				OfflineSince: row.OfflineSince.Time,
+				Recovered:    true,
+				RecoveredAt:  now,
+			})
+		}
+	}
+
+	due, err := dao.q.ListDueServerStatusAlerts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query due server status alerts: %w", err)
+	}
+
+	for _, row := range due {
+		err = dao.q.MarkServerStatusAlerted(ctx, row.MessageID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to mark server status alert as alerted: %w", err)
+		}
+
+		alerts = append(alerts, model.ServerStatusAlert{
+			ServerStatusAlertSetting: serverStatusAlertSetting(
+				row.GuildID,
+				row.ChannelID,
+				row.MessageID,
+				row.Address,
+				row.AlertChannelID,
+				row.AlertUserID,
+				row.Debounce,
+			),
+			Name:         row.Name,
+			Map:          row.Map,
+			NumPlayers:   int(row.NumPlayers),
+			MaxPlayers:   int(row.MaxPlayers),
+			OfflineSince: row.OfflineSince.Time,
+		})
+	}
+
+	return alerts, nil
+}
+
+func serverStatusAlertSetting(
+	guildID, channelID, messageID int64,
+	address string,
+	alertChannelID, alertUserID *int64,
+	debounce int32,
+) model.ServerStatusAlertSetting {
+	return model.ServerStatusAlertSetting{
+		Tracking: model.Tracking{
+			MessageTarget: model.MessageTarget{
+				ChannelTarget: model.ChannelTarget{
+					GuildID:   discord.GuildID(guildID),
+					ChannelID: discord.ChannelID(channelID),
+				},
+				MessageID: discord.MessageID(messageID),
+			},
+			Address: address,
+		},
+		AlertChannelID: discord.ChannelID(fromNullableID(alertChannelID)),
+		AlertUserID:    discord.UserID(fromNullableID(alertUserID)),
+		Debounce:       time.Duration(debounce) * time.Second,
+	}
+}
+
+func fromNullableID(id *int64) int64 {
+	if id == nil {
+		return 0
+	}
+	return *id
+}
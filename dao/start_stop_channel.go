@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
 	"github.com/jxsl13/twstatus-bot/model"
 	"github.com/jxsl13/twstatus-bot/sqlc"
 )
@@ -16,7 +17,7 @@ func (dao *DAO) StartChannel(ctx context.Context, guildID discord.GuildID, chann
 	}
 
 	if channel.Running {
-		return c, fmt.Errorf("channel %s is already active", channel)
+		return c, i18n.NewError("error.channel_running", channel.ID.Mention())
 	}
 
 	err = dao.q.StartChannel(ctx, sqlc.StartChannelParams{
@@ -36,7 +37,7 @@ func (dao *DAO) StopChannel(ctx context.Context, guildID discord.GuildID, channe
 	}
 
 	if !channel.Running {
-		return c, fmt.Errorf("channel %s is already inactive", channel)
+		return c, i18n.NewError("error.channel_stopped", channel.ID.Mention())
 	}
 
 	err = dao.q.StopChannel(ctx, sqlc.StopChannelParams{
@@ -0,0 +1,104 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+// GetTopServers returns the most populated servers of the master server that match the filter.
+func (dao *DAO) GetTopServers(ctx context.Context, filter model.TopServersFilter) (model.TopServers, error) {
+	servers, err := dao.SearchServers(ctx, filter.Search())
+	if err != nil {
+		return model.TopServers{}, fmt.Errorf("failed to get top servers: %w", err)
+	}
+	return model.NewTopServers(filter, servers), nil
+}
+
+func (dao *DAO) AddTopServersTracking(ctx context.Context, t model.TopServersTracking) error {
+	err := dao.q.AddTopServersTracking(ctx, t.ToAddSQLC())
+	if err != nil {
+		return fmt.Errorf("failed to add top servers tracking: %w", err)
+	}
+	return nil
+}
+
+func (dao *DAO) ListTopServersMessageIDsByChannelID(ctx context.Context, guildID discord.GuildID, channelID discord.ChannelID) ([]discord.MessageID, error) {
+	ids, err := dao.q.ListTopServersMessageIDsByChannelID(ctx, sqlc.ListTopServersMessageIDsByChannelIDParams{
+		GuildID:   int64(guildID),
+		ChannelID: int64(channelID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list top servers messages of channel %s: %w", channelID, err)
+	}
+
+	result := make([]discord.MessageID, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, discord.MessageID(id))
+	}
+	return result, nil
+}
+
+func (dao *DAO) RemoveTopServersTrackingByMessageID(ctx context.Context, guildID discord.GuildID, messageID discord.MessageID) error {
+	err := dao.q.RemoveTopServersTrackingByMessageId(ctx, sqlc.RemoveTopServersTrackingByMessageIdParams{
+		GuildID:   int64(guildID),
+		MessageID: int64(messageID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove top servers tracking by message id: %w", err)
+	}
+	return nil
+}
+
+// ChangedTopServersTrackings recomputes the ranking of all top servers messages of running channels
+// and returns the messages whose ranking changed since they were last published.
+// The new ranking must be saved with SetTopServersRanking once the message was edited.
+func (dao *DAO) ChangedTopServersTrackings(ctx context.Context) ([]model.TopServersMessage, error) {
+	rows, err := dao.q.ListTopServersTrackings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list top servers trackings: %w", err)
+	}
+
+	// many messages usually share the same gametype
+	searches := make(map[string]model.FoundServers, len(rows))
+	result := make([]model.TopServersMessage, 0, len(rows))
+	for _, row := range rows {
+		t := model.NewTopServersTrackingFromSQLC(row)
+
+		servers, ok := searches[t.Gametype]
+		if !ok {
+			servers, err = dao.SearchServers(ctx, t.Search())
+			if err != nil {
+				return nil, err
+			}
+			searches[t.Gametype] = servers
+		}
+
+		top := model.NewTopServers(t.TopServersFilter, servers)
+		ranking := top.Ranking()
+		if ranking == t.Ranking {
+			continue
+		}
+
+		result = append(result, model.TopServersMessage{
+			MessageTarget: t.MessageTarget,
+			TopServers:    top,
+		})
+	}
+	return result, nil
+}
+
+// SetTopServersRanking saves the ranking that was published in the top servers message.
+func (dao *DAO) SetTopServersRanking(ctx context.Context, t model.TopServersMessage) error {
+	err := dao.q.SetTopServersRanking(ctx, sqlc.SetTopServersRankingParams{
+		MessageID: int64(t.MessageID),
+		Ranking:   t.Ranking(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set ranking of top servers message %s: %w", t.MessageTarget, err)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
 	"github.com/jxsl13/twstatus-bot/model"
 	"github.com/jxsl13/twstatus-bot/sqlc"
 )
@@ -56,6 +57,31 @@ func (dao *DAO) ListTrackingsByChannelID(ctx context.Context, guildID discord.Gu
 	return result, nil
 }
 
+func (dao *DAO) GetTrackingByAddress(ctx context.Context, guildID discord.GuildID, channelID discord.ChannelID, address string) (tracking model.Tracking, err error) {
+	ts, err := dao.q.GetTrackingByAddress(ctx, sqlc.GetTrackingByAddressParams{
+		GuildID:   int64(guildID),
+		ChannelID: int64(channelID),
+		Address:   address,
+	})
+	if err != nil {
+		return model.Tracking{}, fmt.Errorf("failed to get tracking: %w", err)
+	}
+	if len(ts) == 0 {
+		return model.Tracking{}, i18n.WrapError(ErrNotFound, "error.tracking_not_found", address, channelID.Mention())
+	}
+	t := ts[0]
+	return model.Tracking{
+		MessageTarget: model.MessageTarget{
+			ChannelTarget: model.ChannelTarget{
+				GuildID:   guildID,
+				ChannelID: channelID,
+			},
+			MessageID: discord.MessageID(t.MessageID),
+		},
+		Address: t.Address,
+	}, nil
+}
+
 func (dao *DAO) AddTracking(ctx context.Context, tracking model.Tracking) (err error) {
 	cs, err := dao.q.GetChannel(ctx, sqlc.GetChannelParams{
 		GuildID:   int64(tracking.GuildID),
@@ -65,7 +91,7 @@ func (dao *DAO) AddTracking(ctx context.Context, tracking model.Tracking) (err e
 		return fmt.Errorf("failed to get channel: %w", err)
 	}
 	if len(cs) == 0 {
-		return fmt.Errorf("channel %s is not known", tracking.ChannelID)
+		return i18n.NewError("error.channel_not_added", tracking.ChannelID.Mention())
 	}
 
 	// also allow tracking servers that are currently offline
@@ -77,7 +103,7 @@ func (dao *DAO) AddTracking(ctx context.Context, tracking model.Tracking) (err e
 	})
 	if err != nil {
 		if IsUniqueConstraintErr(err) {
-			return fmt.Errorf("%w: tracking %s", ErrAlreadyExists, tracking.Address)
+			return i18n.WrapError(ErrAlreadyExists, "error.tracking_exists", tracking.Address)
 		}
 		return fmt.Errorf("failed to insert tracking for %s: %w", tracking.Address, err)
 	}
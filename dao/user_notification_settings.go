@@ -0,0 +1,136 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+	"github.com/jxsl13/twstatus-bot/utils"
+)
+
+// GetUserNotificationSettings returns the default settings in case the user did not configure any.
+func (dao *DAO) GetUserNotificationSettings(ctx context.Context, userID discord.UserID) (model.UserNotificationSettings, error) {
+	rows, err := dao.q.GetUserNotificationSettings(ctx, int64(userID))
+	if err != nil {
+		return model.UserNotificationSettings{}, fmt.Errorf("failed to get notification settings of user %s: %w", userID, err)
+	}
+	if len(rows) == 0 {
+		return model.DefaultUserNotificationSettings(userID), nil
+	}
+	return model.NewUserNotificationSettingsFromSQLC(rows[0])
+}
+
+func (dao *DAO) SetUserNotificationSettings(ctx context.Context, s model.UserNotificationSettings) error {
+	err := dao.q.SetUserNotificationSettings(ctx, s.ToSetSQLC())
+	if err != nil {
+		return fmt.Errorf("failed to set notification settings of user %s: %w", s.UserID, err)
+	}
+	return nil
+}
+
+// quietUsers returns the subset of users that are currently within their quiet hours.
+func (dao *DAO) quietUsers(ctx context.Context, userIDs []int64, now time.Time) (map[discord.UserID]bool, error) {
+	result := make(map[discord.UserID]bool)
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	rows, err := dao.q.ListUserNotificationSettings(ctx, utils.Unique(userIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user notification settings: %w", err)
+	}
+
+	for _, row := range rows {
+		s, err := model.NewUserNotificationSettingsFromSQLC(row)
+		if err != nil {
+			return nil, err
+		}
+		if s.IsQuiet(now) {
+			result[s.UserID] = true
+		}
+	}
+	return result, nil
+}
+
+// deferQuietPlayerCountNotifications removes the notifications of users that are within their
+// quiet hours. Those notifications are reevaluated once the quiet hours are over.
+func (dao *DAO) deferQuietPlayerCountNotifications(
+	ctx context.Context,
+	rows []sqlc.GetPlayerCountNotificationMessagesRow,
+	now time.Time,
+) (
+	[]sqlc.GetPlayerCountNotificationMessagesRow,
+	error,
+) {
+	userIDs := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		userIDs = append(userIDs, row.UserID)
+	}
+
+	quiet, err := dao.quietUsers(ctx, userIDs, now)
+	if err != nil {
+		return nil, err
+	}
+	if len(quiet) == 0 {
+		return rows, nil
+	}
+
+	result := make([]sqlc.GetPlayerCountNotificationMessagesRow, 0, len(rows))
+	for _, row := range rows {
+		if !quiet[discord.UserID(row.UserID)] {
+			result = append(result, row)
+			continue
+		}
+
+		err = dao.q.AddDeferredPlayerCountNotification(ctx, sqlc.AddDeferredPlayerCountNotificationParams{
+			UserID:    row.UserID,
+			MessageID: row.ReqMessageID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to defer player count notification: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// dueDeferredAddresses returns the addresses of deferred notifications whose quiet hours are over.
+// The deferred notifications are removed, the player count condition is reevaluated by the caller.
+func (dao *DAO) dueDeferredAddresses(ctx context.Context, now time.Time) ([]string, error) {
+	rows, err := dao.q.ListDeferredPlayerCountNotifications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deferred player count notifications: %w", err)
+	}
+	if len(rows) == 0 {
+		return []string{}, nil
+	}
+
+	userIDs := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		userIDs = append(userIDs, row.UserID)
+	}
+
+	quiet, err := dao.quietUsers(ctx, userIDs, now)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if quiet[discord.UserID(row.UserID)] {
+			continue
+		}
+
+		err = dao.q.RemoveDeferredPlayerCountNotification(ctx, sqlc.RemoveDeferredPlayerCountNotificationParams{
+			UserID:    row.UserID,
+			MessageID: row.MessageID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to remove deferred player count notification: %w", err)
+		}
+		addresses = append(addresses, row.Address)
+	}
+	return utils.Unique(addresses), nil
+}
@@ -0,0 +1,134 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+// number of delivery log entries that are kept per webhook
+const webhookDeliveryLogSize = 100
+
+func (dao *DAO) ListWebhooks(ctx context.Context, guildID discord.GuildID) (_ model.Webhooks, err error) {
+	rows, err := dao.q.ListWebhooks(ctx, int64(guildID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	result := make(model.Webhooks, 0, len(rows))
+	for _, row := range rows {
+		w := model.NewWebhookFromSQLC(sqlc.Webhook{
+			WebhookID: row.WebhookID,
+			GuildID:   int64(guildID),
+			Url:       row.Url,
+			Events:    row.Events,
+		})
+		if row.LastDeliveredAt.Valid {
+			w.LastDelivery = model.WebhookDelivery{
+				WebhookID:   row.WebhookID,
+				StatusCode:  int(row.LastStatusCode),
+				Error:       row.LastError,
+				DeliveredAt: row.LastDeliveredAt.Time,
+			}
+		}
+		result = append(result, w)
+	}
+	return result, nil
+}
+
+func (dao *DAO) AddWebhook(ctx context.Context, w model.Webhook) error {
+	err := dao.q.AddWebhook(ctx, w.ToAddSQLC())
+	if err != nil {
+		return fmt.Errorf("failed to add webhook %s: %w", w.URL, err)
+	}
+	return nil
+}
+
+func (dao *DAO) RemoveWebhook(ctx context.Context, guildID discord.GuildID, url string) error {
+	err := dao.q.RemoveWebhook(ctx, sqlc.RemoveWebhookParams{
+		GuildID: int64(guildID),
+		Url:     url,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove webhook %s: %w", url, err)
+	}
+	return nil
+}
+
+// AddWebhookDelivery adds an entry to the delivery log and removes the oldest entries.
+func (dao *DAO) AddWebhookDelivery(ctx context.Context, d model.WebhookDelivery) error {
+	err := dao.q.AddWebhookDelivery(ctx, d.ToAddSQLC())
+	if err != nil {
+		return fmt.Errorf("failed to add webhook delivery: %w", err)
+	}
+
+	err = dao.q.PruneWebhookDeliveries(ctx, sqlc.PruneWebhookDeliveriesParams{
+		WebhookID: d.WebhookID,
+		Keep:      webhookDeliveryLogSize,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to prune webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+// GetWebhookEvents derives the server events of the changed servers and
+// returns them for every webhook of the corresponding guild that accepts the event.
+func (dao *DAO) GetWebhookEvents(
+	ctx context.Context,
+	changes map[model.MessageTarget]model.ChangedServerStatus,
+) (
+	_ []model.WebhookEvent,
+	err error,
+) {
+	if len(changes) == 0 {
+		return []model.WebhookEvent{}, nil
+	}
+
+	rows, err := dao.q.ListAllWebhooks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	if len(rows) == 0 {
+		return []model.WebhookEvent{}, nil
+	}
+
+	webhooks := make(map[discord.GuildID][]model.Webhook, len(rows))
+	for _, row := range rows {
+		w := model.NewWebhookFromSQLC(row)
+		webhooks[w.GuildID] = append(webhooks[w.GuildID], w)
+	}
+
+	// deterministic order of events
+	targets := make([]model.MessageTarget, 0, len(changes))
+	for target := range changes {
+		if _, ok := webhooks[target.GuildID]; ok {
+			targets = append(targets, target)
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].MessageID < targets[j].MessageID
+	})
+
+	now := time.Now()
+	result := make([]model.WebhookEvent, 0, len(targets))
+	for _, target := range targets {
+		for _, e := range model.NewServerEvents(changes[target], now) {
+			for _, w := range webhooks[target.GuildID] {
+				if !w.Accepts(e.Type) {
+					continue
+				}
+				result = append(result, model.WebhookEvent{
+					Webhook: w,
+					Event:   e,
+				})
+			}
+		}
+	}
+	return result, nil
+}
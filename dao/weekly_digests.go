@@ -0,0 +1,162 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+func (dao *DAO) ListWeeklyDigests(ctx context.Context, guildID discord.GuildID) (model.WeeklyDigests, error) {
+	rows, err := dao.q.ListWeeklyDigests(ctx, int64(guildID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list weekly digests: %w", err)
+	}
+
+	result := make(model.WeeklyDigests, 0, len(rows))
+	for _, row := range rows {
+		d, err := model.NewWeeklyDigestFromSQLC(row)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, d)
+	}
+	return result, nil
+}
+
+// ListDueWeeklyDigests returns all digests that were not posted since their last scheduled point in time
+// and that are not backing off after a failed attempt.
+func (dao *DAO) ListDueWeeklyDigests(ctx context.Context, now time.Time) (model.WeeklyDigests, error) {
+	rows, err := dao.q.ListAllWeeklyDigests(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list weekly digests: %w", err)
+	}
+
+	result := make(model.WeeklyDigests, 0, len(rows))
+	for _, row := range rows {
+		d, err := model.NewWeeklyDigestFromSQLC(row)
+		if err != nil {
+			return nil, err
+		}
+		if d.IsDue(now) {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
+func (dao *DAO) SetWeeklyDigest(ctx context.Context, d model.WeeklyDigest) error {
+	err := dao.q.SetWeeklyDigest(ctx, d.ToSetSQLC())
+	if err != nil {
+		return fmt.Errorf("failed to set weekly digest of channel %s: %w", d.ChannelID, err)
+	}
+	return nil
+}
+
+func (dao *DAO) RemoveWeeklyDigest(ctx context.Context, d model.WeeklyDigest) error {
+	err := dao.q.RemoveWeeklyDigest(ctx, d.ToRemoveSQLC())
+	if err != nil {
+		return fmt.Errorf("failed to remove weekly digest of channel %s: %w", d.ChannelID, err)
+	}
+	return nil
+}
+
+func (dao *DAO) MarkWeeklyDigestPosted(ctx context.Context, d model.WeeklyDigest, postedAt time.Time) error {
+	err := dao.q.MarkWeeklyDigestPosted(ctx, sqlc.MarkWeeklyDigestPostedParams{
+		ChannelID:    int64(d.ChannelID),
+		LastPostedAt: pgtype.Timestamptz{Time: postedAt, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark weekly digest of channel %s as posted: %w", d.ChannelID, err)
+	}
+	return nil
+}
+
+// MarkWeeklyDigestFailed increases the backoff until the digest is attempted to be posted again.
+func (dao *DAO) MarkWeeklyDigestFailed(ctx context.Context, d model.WeeklyDigest, attemptedAt time.Time) error {
+	err := dao.q.MarkWeeklyDigestFailed(ctx, sqlc.MarkWeeklyDigestFailedParams{
+		ChannelID:     int64(d.ChannelID),
+		LastAttemptAt: pgtype.Timestamptz{Time: attemptedAt, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark weekly digest of channel %s as failed: %w", d.ChannelID, err)
+	}
+	return nil
+}
+
+// GetDigest summarizes the given servers during the digest period before until.
+// Playtime is accumulated per UTC day, which is why only complete days before until are taken into account.
+func (dao *DAO) GetDigest(
+	ctx context.Context,
+	channelID discord.ChannelID,
+	addresses []string,
+	until time.Time,
+	loc *time.Location,
+) (
+	_ model.Digest,
+	err error,
+) {
+	var (
+		since = until.Add(-model.DigestPeriod)
+		from  = pgtype.Timestamptz{Time: since, Valid: true}
+		to    = pgtype.Timestamptz{Time: until, Valid: true}
+	)
+
+	hourly, err := dao.q.ListServerHistoryRollups(ctx, sqlc.ListServerHistoryRollupsParams{
+		Addresses:  addresses,
+		BucketSize: int32(hourlyBucketSize / time.Second),
+		Bucket:     from,
+	})
+	if err != nil {
+		return model.Digest{}, fmt.Errorf("failed to get hourly server history: %w", err)
+	}
+
+	maps, err := dao.q.GetDigestMaps(ctx, sqlc.GetDigestMapsParams{
+		Addresses: addresses,
+		Since:     from,
+		Until:     to,
+	})
+	if err != nil {
+		return model.Digest{}, fmt.Errorf("failed to get most played maps: %w", err)
+	}
+
+	players, err := dao.q.GetDigestPlaytimeLeaderboard(ctx, sqlc.GetDigestPlaytimeLeaderboardParams{
+		Addresses:  addresses,
+		Since:      pgtype.Date{Time: since.UTC(), Valid: true},
+		Until:      pgtype.Date{Time: until.UTC(), Valid: true},
+		MaxEntries: model.MaxDigestPlayers,
+	})
+	if err != nil {
+		return model.Digest{}, fmt.Errorf("failed to get playtime leaderboard: %w", err)
+	}
+
+	counts, err := dao.q.GetDigestPlayers(ctx, sqlc.GetDigestPlayersParams{
+		Addresses: addresses,
+		Since:     from,
+		Until:     to,
+	})
+	if err != nil {
+		return model.Digest{}, fmt.Errorf("failed to count new and returning players: %w", err)
+	}
+
+	digest := model.Digest{
+		ChannelID:  channelID,
+		From:       since,
+		To:         until,
+		Servers:    model.NewDigestServers(hourly, since, until, loc),
+		Maps:       model.NewDigestMaps(maps),
+		TopPlayers: make([]model.PlaytimeEntry, 0, len(players)),
+	}
+	for _, row := range players {
+		digest.TopPlayers = append(digest.TopPlayers, model.NewPlayerPlaytimeEntryFromSQLC(sqlc.GetPlayerPlaytimeLeaderboardRow(row)))
+	}
+	if len(counts) > 0 {
+		digest.NewPlayers = int(counts[0].NewPlayers)
+		digest.ReturningPlayers = int(counts[0].ReturningPlayers)
+	}
+	return digest, nil
+}
@@ -0,0 +1,109 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+func (dao *DAO) ListWeeklyHeatmaps(ctx context.Context, guildID discord.GuildID) (model.WeeklyHeatmaps, error) {
+	rows, err := dao.q.ListWeeklyHeatmaps(ctx, int64(guildID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list weekly heatmaps: %w", err)
+	}
+
+	result := make(model.WeeklyHeatmaps, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, model.WeeklyHeatmap{
+			ChannelTarget: model.ChannelTarget{
+				GuildID:   discord.GuildID(row.GuildID),
+				ChannelID: discord.ChannelID(row.ChannelID),
+			},
+			Address:       row.Address,
+			PostChannelID: discord.ChannelID(row.PostChannelID),
+			LastPostedAt:  row.LastPostedAt.Time,
+		})
+	}
+	return result, nil
+}
+
+// ListDueWeeklyHeatmaps returns all heatmaps that were not posted since the start
+// of the current week in the time zone of their guild and that are not backing off
+// after a failed attempt.
+func (dao *DAO) ListDueWeeklyHeatmaps(ctx context.Context, now time.Time) (model.WeeklyHeatmaps, error) {
+	rows, err := dao.q.ListAllWeeklyHeatmaps(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list weekly heatmaps: %w", err)
+	}
+
+	result := make(model.WeeklyHeatmaps, 0, len(rows))
+	for _, row := range rows {
+		loc, err := time.LoadLocation(row.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone of guild %d: %w", row.GuildID, err)
+		}
+		w := model.WeeklyHeatmap{
+			ChannelTarget: model.ChannelTarget{
+				GuildID:   discord.GuildID(row.GuildID),
+				ChannelID: discord.ChannelID(row.ChannelID),
+			},
+			Address:        row.Address,
+			PostChannelID:  discord.ChannelID(row.PostChannelID),
+			LastPostedAt:   row.LastPostedAt.Time,
+			LastAttemptAt:  row.LastAttemptAt.Time,
+			FailedAttempts: int(row.FailedAttempts),
+			Location:       loc,
+		}
+		if w.IsDue(now) {
+			result = append(result, w)
+		}
+	}
+	return result, nil
+}
+
+func (dao *DAO) AddWeeklyHeatmap(ctx context.Context, w model.WeeklyHeatmap) error {
+	err := dao.q.AddWeeklyHeatmap(ctx, w.ToAddSQLC())
+	if err != nil {
+		return fmt.Errorf("failed to add weekly heatmap of %s: %w", w.Subject(i18n.English), err)
+	}
+	return nil
+}
+
+func (dao *DAO) RemoveWeeklyHeatmap(ctx context.Context, w model.WeeklyHeatmap) error {
+	err := dao.q.RemoveWeeklyHeatmap(ctx, w.ToRemoveSQLC())
+	if err != nil {
+		return fmt.Errorf("failed to remove weekly heatmap of %s: %w", w.Subject(i18n.English), err)
+	}
+	return nil
+}
+
+func (dao *DAO) MarkWeeklyHeatmapPosted(ctx context.Context, w model.WeeklyHeatmap, postedAt time.Time) error {
+	err := dao.q.MarkWeeklyHeatmapPosted(ctx, sqlc.MarkWeeklyHeatmapPostedParams{
+		ChannelID:    int64(w.ChannelID),
+		Address:      w.Address,
+		LastPostedAt: pgtype.Timestamptz{Time: postedAt, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark weekly heatmap of %s as posted: %w", w.Subject(i18n.English), err)
+	}
+	return nil
+}
+
+// MarkWeeklyHeatmapFailed increases the backoff until the heatmap is attempted to be posted again.
+func (dao *DAO) MarkWeeklyHeatmapFailed(ctx context.Context, w model.WeeklyHeatmap, attemptedAt time.Time) error {
+	err := dao.q.MarkWeeklyHeatmapFailed(ctx, sqlc.MarkWeeklyHeatmapFailedParams{
+		ChannelID:     int64(w.ChannelID),
+		Address:       w.Address,
+		LastAttemptAt: pgtype.Timestamptz{Time: attemptedAt, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark weekly heatmap of %s as failed: %w", w.Subject(i18n.English), err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package i18n
+
+import (
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// LocalizeCommands adds the translated descriptions of the commands and their options
+// as well as the translated names of option choices of all catalogs to the commands.
+// Discord shows them depending on the language of the user.
+func LocalizeCommands(cmds []api.CreateCommandData) {
+	for i := range cmds {
+		cmd := &cmds[i]
+		for _, c := range catalogs {
+			if c.Language == Default {
+				continue
+			}
+
+			t := c.Commands[cmd.Name]
+			if t.Description != "" {
+				setLocale(&cmd.DescriptionLocalizations, c.Language, t.Description)
+			}
+
+			for _, o := range cmd.Options {
+				descriptions, choices := optionLocalizations(o)
+				if d, ok := t.Options[o.Name()]; ok && descriptions != nil {
+					setLocale(descriptions, c.Language, d)
+				}
+				for _, choice := range choices {
+					if name, ok := c.Choices[choice.Name]; ok {
+						setLocale(&choice.NameLocalizations, c.Language, name)
+					}
+				}
+			}
+		}
+	}
+}
+
+func setLocale(locales *discord.StringLocales, lang discord.Language, s string) {
+	if *locales == nil {
+		*locales = make(discord.StringLocales, len(catalogs)-1)
+	}
+	(*locales)[lang] = s
+}
+
+// optionLocalizations returns the description localizations and the choices of the option types used by the bot.
+func optionLocalizations(o discord.CommandOption) (*discord.StringLocales, []*discord.StringChoice) {
+	switch o := o.(type) {
+	case *discord.StringOption:
+		choices := make([]*discord.StringChoice, 0, len(o.Choices))
+		for i := range o.Choices {
+			choices = append(choices, &o.Choices[i])
+		}
+		return &o.DescriptionLocalizations, choices
+	case *discord.IntegerOption:
+		return &o.DescriptionLocalizations, nil
+	case *discord.NumberOption:
+		return &o.DescriptionLocalizations, nil
+	case *discord.BooleanOption:
+		return &o.DescriptionLocalizations, nil
+	case *discord.ChannelOption:
+		return &o.DescriptionLocalizations, nil
+	case *discord.RoleOption:
+		return &o.DescriptionLocalizations, nil
+	case *discord.UserOption:
+		return &o.DescriptionLocalizations, nil
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,40 @@
+package i18n
+
+import "errors"
+
+// Error is an error whose message is translated when it is shown to a user.
+// Its Error method returns the message in the default language.
+type Error struct {
+	err  error
+	key  string
+	args []any
+}
+
+// NewError returns an error with a translatable message.
+func NewError(key string, args ...any) error {
+	return &Error{key: key, args: args}
+}
+
+// WrapError returns an error with a translatable message that wraps err,
+// which keeps the error comparable with errors.Is.
+func WrapError(err error, key string, args ...any) error {
+	return &Error{err: err, key: key, args: args}
+}
+
+func (e *Error) Error() string {
+	return English.T(e.key, e.args...)
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Error returns the translated message of the first translatable error in the chain of err
+// or the untranslated message in case there is none.
+func (l Localizer) Error(err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return l.T(e.key, e.args...)
+	}
+	return err.Error()
+}
@@ -0,0 +1,168 @@
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// Default is the language of all texts that are part of the source code.
+// Every other language falls back to it in case a translation is missing.
+const Default = discord.EnglishUS
+
+//go:embed locales/*.json
+var localesFS embed.FS
+
+var (
+	catalogs = mustLoadCatalogs()
+	// English is the localizer of the default language
+	English = New(string(Default))
+)
+
+// Catalog contains all translations of one language.
+type Catalog struct {
+	Language discord.Language `json:"language"`
+	// native name of the language
+	Name     string                        `json:"name"`
+	Messages map[string]string             `json:"messages"`
+	Help     []string                      `json:"help"`
+	Commands map[string]CommandTranslation `json:"commands"`
+	// English name of an option choice -> translated name
+	Choices map[string]string `json:"choices"`
+}
+
+// CommandTranslation contains the translated description of a slash command and its options.
+type CommandTranslation struct {
+	Description string `json:"description"`
+	// option name -> option description
+	Options map[string]string `json:"options"`
+}
+
+func mustLoadCatalogs() map[discord.Language]*Catalog {
+	files, err := localesFS.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("failed to read embedded locales: %v", err))
+	}
+
+	result := make(map[discord.Language]*Catalog, len(files))
+	for _, f := range files {
+		data, err := localesFS.ReadFile(path.Join("locales", f.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("failed to read embedded locale %s: %v", f.Name(), err))
+		}
+		var c Catalog
+		err = json.Unmarshal(data, &c)
+		if err != nil {
+			panic(fmt.Sprintf("failed to parse embedded locale %s: %v", f.Name(), err))
+		}
+		result[c.Language] = &c
+	}
+
+	if _, ok := result[Default]; !ok {
+		panic(fmt.Sprintf("missing embedded locale of the default language %s", Default))
+	}
+	return result
+}
+
+// Catalogs returns the catalogs of all supported languages sorted by their language code.
+func Catalogs() []*Catalog {
+	result := make([]*Catalog, 0, len(catalogs))
+	for _, c := range catalogs {
+		result = append(result, c)
+	}
+	slices.SortFunc(result, func(a, b *Catalog) int {
+		return strings.Compare(string(a.Language), string(b.Language))
+	})
+	return result
+}
+
+// Supported returns the supported language that matches the given language code,
+// e.g. de-AT is matched by de and en-GB by en-US.
+func Supported(lang string) (discord.Language, bool) {
+	if _, ok := catalogs[discord.Language(lang)]; ok {
+		return discord.Language(lang), true
+	}
+
+	base, _, _ := strings.Cut(lang, "-")
+	for l := range catalogs {
+		b, _, _ := strings.Cut(string(l), "-")
+		if strings.EqualFold(base, b) {
+			return l, true
+		}
+	}
+	return "", false
+}
+
+// Localizer translates messages into one language.
+type Localizer struct {
+	c *Catalog
+}
+
+// New returns the localizer of the given language code or
+// the localizer of the default language in case the language is not supported.
+func New(lang string) Localizer {
+	l, ok := Supported(lang)
+	if !ok {
+		l = Default
+	}
+	return Localizer{c: catalogs[l]}
+}
+
+func (l Localizer) Language() discord.Language {
+	return l.c.Language
+}
+
+// T returns the translated message of the given key formatted with the given arguments.
+// Missing translations fall back to the default language and unknown keys are returned as is.
+func (l Localizer) T(key string, args ...any) string {
+	msg, ok := l.c.Messages[key]
+	if !ok {
+		msg, ok = catalogs[Default].Messages[key]
+		if !ok {
+			return key
+		}
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// Help returns the lines of the help message.
+func (l Localizer) Help() []string {
+	if len(l.c.Help) == 0 {
+		return catalogs[Default].Help
+	}
+	return l.c.Help
+}
+
+// Choice returns the translated name of an option choice, e.g. a weekday,
+// or the English name in case there is no translation.
+func (l Localizer) Choice(name string) string {
+	if c, ok := l.c.Choices[name]; ok {
+		return c
+	}
+	return name
+}
+
+type ctxKey struct{}
+
+// NewContext returns a context that carries the localizer.
+func NewContext(ctx context.Context, l Localizer) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// FromContext returns the localizer of the context or the localizer of the default language.
+func FromContext(ctx context.Context) Localizer {
+	l, ok := ctx.Value(ctxKey{}).(Localizer)
+	if !ok {
+		return English
+	}
+	return l
+}
@@ -0,0 +1,120 @@
+package i18n_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogs(t *testing.T) {
+	var english *i18n.Catalog
+	for _, c := range i18n.Catalogs() {
+		if c.Language == i18n.Default {
+			english = c
+		}
+	}
+	require.NotNil(t, english)
+
+	for _, c := range i18n.Catalogs() {
+		require.NotEmpty(t, c.Name, c.Language)
+		require.Len(t, c.Help, len(english.Help), "help of %s is out of sync", c.Language)
+
+		for key, msg := range c.Messages {
+			en, ok := english.Messages[key]
+			require.True(t, ok, "unknown message %s of %s", key, c.Language)
+			require.Equal(t, strings.Count(en, "%"), strings.Count(msg, "%"), "format verbs of message %s of %s", key, c.Language)
+		}
+
+		// discord limits
+		for name, cmd := range c.Commands {
+			require.LessOrEqual(t, utf8.RuneCountInString(cmd.Description), 100, "description of %s of %s", name, c.Language)
+			for option, description := range cmd.Options {
+				require.LessOrEqual(t, utf8.RuneCountInString(description), 100, "description of option %s of %s of %s", option, name, c.Language)
+			}
+		}
+		for _, choice := range c.Choices {
+			require.LessOrEqual(t, utf8.RuneCountInString(choice), 100, c.Language)
+		}
+	}
+}
+
+func TestLocalizer(t *testing.T) {
+	require.Equal(t, discord.German, i18n.New("de").Language())
+	require.Equal(t, discord.German, i18n.New("de-AT").Language())
+	require.Equal(t, discord.EnglishUS, i18n.New("en-GB").Language())
+	require.Equal(t, discord.EnglishUS, i18n.New("xx").Language())
+	require.Equal(t, discord.EnglishUS, i18n.New("").Language())
+
+	_, ok := i18n.Supported("xx")
+	require.False(t, ok)
+
+	de := i18n.New("de")
+	require.Equal(t, "... und 3 weitere", de.T("status.and_more", 3))
+	require.Equal(t, "... and 3 more", i18n.English.T("status.and_more", 3))
+	require.Equal(t, "unknown.key", de.T("unknown.key"))
+	require.NotEmpty(t, de.Help())
+	require.Equal(t, "Sonntag", de.Choice("Sunday"))
+	require.Equal(t, "unknown", de.Choice("unknown"))
+
+	require.Equal(t, i18n.English, i18n.FromContext(context.Background()))
+	require.Equal(t, de, i18n.FromContext(i18n.NewContext(context.Background(), de)))
+}
+
+func TestLocalizeCommands(t *testing.T) {
+	cmds := []api.CreateCommandData{
+		{
+			Name:        "start",
+			Description: "Start the bot for the given channel",
+			Options: []discord.CommandOption{
+				&discord.ChannelOption{
+					OptionName:  "channel",
+					Description: "The channel id of the channel you want to start the bot for.",
+				},
+			},
+		},
+		{
+			Name:        "stats",
+			Description: "Show a chart of the player count history of a tracked server",
+			Options: []discord.CommandOption{
+				&discord.StringOption{
+					OptionName:  "range",
+					Description: "The time range of the chart.",
+					Choices: []discord.StringChoice{
+						{Name: "24 hours", Value: "24h"},
+					},
+				},
+			},
+		},
+	}
+	i18n.LocalizeCommands(cmds)
+
+	require.Equal(t, "Startet den Bot für den angegebenen Kanal", cmds[0].DescriptionLocalizations[discord.German])
+	require.NotContains(t, cmds[0].DescriptionLocalizations, discord.EnglishUS)
+	channel := cmds[0].Options[0].(*discord.ChannelOption)
+	require.Equal(t, "Der Kanal, für den der Bot gestartet werden soll.", channel.DescriptionLocalizations[discord.German])
+
+	rng := cmds[1].Options[0].(*discord.StringOption)
+	require.Nil(t, rng.DescriptionLocalizations)
+	require.Equal(t, "24 Stunden", rng.Choices[0].NameLocalizations[discord.German])
+	require.Equal(t, "24 часа", rng.Choices[0].NameLocalizations[discord.Russian])
+}
+
+func TestError(t *testing.T) {
+	errNotFound := errors.New("not found")
+	err := fmt.Errorf("failed to get channel: %w", i18n.WrapError(errNotFound, "error.channel_not_found", "<#1>"))
+	require.ErrorIs(t, err, errNotFound)
+	require.Equal(t, "failed to get channel: channel <#1> was not found", err.Error())
+
+	de := i18n.New("de")
+	require.Equal(t, "der Kanal <#1> wurde nicht gefunden", de.Error(err))
+	require.Equal(t, "not found", de.Error(errNotFound))
+	require.Equal(t, "Zugriff verweigert", de.Error(i18n.NewError("access_forbidden")))
+}
@@ -71,6 +71,12 @@ func (c *rootContext) PreRunE(cmd *cobra.Command) func(cmd *cobra.Command, args
 		PostgresSSLMode:  db.SSLModeDisable,
 		PostgresDatabase: "twdb",
 		PollInterval:     16 * time.Second,
+
+		HistoryResolution:      time.Minute,
+		HistoryRetention:       7 * 24 * time.Hour,
+		HistoryHourlyRetention: 90 * 24 * time.Hour,
+		SessionRetention:       30 * 24 * time.Hour,
+		DiscoveryRetention:     30 * 24 * time.Hour,
 	}
 	runParser := config.RegisterFlags(c.Config, true, cmd)
 	return func(cmd *cobra.Command, args []string) error {
@@ -108,6 +114,14 @@ func (c *rootContext) RunE(cmd *cobra.Command, args []string) error {
 		c.Config.ChannelID,
 		c.Config.PollInterval,
 		c.Config.LegacyMessageFormat,
+		bot.HistoryConfig{
+			Resolution:         c.Config.HistoryResolution,
+			Retention:          c.Config.HistoryRetention,
+			HourlyRetention:    c.Config.HistoryHourlyRetention,
+			SessionRetention:   c.Config.SessionRetention,
+			DiscoveryRetention: c.Config.DiscoveryRetention,
+			ForecastHint:       c.Config.ForecastHint,
+		},
 	)
 	if err != nil {
 		return err
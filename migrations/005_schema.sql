@@ -0,0 +1,28 @@
+
+CREATE TABLE IF NOT EXISTS player_count_role_notifications (
+	guild_id BIGINT NOT NULL
+		REFERENCES guilds(guild_id)
+		ON DELETE CASCADE,
+	channel_id BIGINT NOT NULL
+		REFERENCES channels(channel_id)
+		ON DELETE CASCADE,
+	message_id BIGINT NOT NULL
+		REFERENCES tracking(message_id)
+		ON DELETE CASCADE
+		ON UPDATE CASCADE,
+	role_id BIGINT NOT NULL,
+	threshold SMALLINT NOT NULL
+		CHECK( threshold > 0),
+	cooldown INTEGER NOT NULL DEFAULT 3600 -- seconds
+		CHECK( cooldown >= 0),
+	last_notified timestamp WITH TIME ZONE,
+	-- role notifications are only sent when the player count crosses the threshold,
+	-- the flag is reset as soon as the player count drops below the threshold again
+	above_threshold BOOLEAN NOT NULL DEFAULT FALSE,
+	PRIMARY KEY (guild_id, channel_id, message_id, role_id)
+);
+
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS player_count_role_notifications;
@@ -0,0 +1,37 @@
+
+CREATE TABLE IF NOT EXISTS server_status_alerts (
+	message_id BIGINT PRIMARY KEY NOT NULL
+		REFERENCES tracking(message_id)
+		ON DELETE CASCADE
+		ON UPDATE CASCADE,
+	guild_id BIGINT NOT NULL
+		REFERENCES guilds(guild_id)
+		ON DELETE CASCADE,
+	channel_id BIGINT NOT NULL
+		REFERENCES channels(channel_id)
+		ON DELETE CASCADE,
+	alert_channel_id BIGINT, -- channel that receives the alerts
+	alert_user_id BIGINT, -- user that receives the alerts as direct message
+	debounce INTEGER NOT NULL DEFAULT 60 -- seconds
+		CHECK( debounce >= 0),
+	CHECK( alert_channel_id IS NOT NULL OR alert_user_id IS NOT NULL)
+);
+
+CREATE TABLE IF NOT EXISTS server_status_alert_states (
+	message_id BIGINT PRIMARY KEY NOT NULL
+		REFERENCES server_status_alerts(message_id)
+		ON DELETE CASCADE
+		ON UPDATE CASCADE,
+	name VARCHAR(128) NOT NULL,
+	map VARCHAR(128) NOT NULL,
+	num_players SMALLINT NOT NULL,
+	max_players SMALLINT NOT NULL,
+	offline_since timestamp WITH TIME ZONE NOT NULL,
+	alerted BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS server_status_alert_states;
+DROP TABLE IF EXISTS server_status_alerts;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS map_change_subscriptions (
+	guild_id BIGINT NOT NULL
+		REFERENCES guilds(guild_id)
+		ON DELETE CASCADE,
+	channel_id BIGINT NOT NULL
+		REFERENCES channels(channel_id)
+		ON DELETE CASCADE,
+	message_id BIGINT NOT NULL
+		REFERENCES tracking(message_id)
+		ON DELETE CASCADE
+		ON UPDATE CASCADE,
+	user_id BIGINT NOT NULL,
+	pattern VARCHAR(128) NOT NULL, -- map name or glob pattern, e.g. ctf*
+	dm BOOLEAN NOT NULL DEFAULT FALSE, -- direct message instead of channel mention
+	PRIMARY KEY (message_id, user_id, pattern)
+);
+
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS map_change_subscriptions;
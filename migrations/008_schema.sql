@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS user_notification_settings (
+	user_id BIGINT PRIMARY KEY NOT NULL,
+	timezone VARCHAR(64) NOT NULL DEFAULT 'UTC', -- IANA time zone name
+	quiet_start SMALLINT NOT NULL DEFAULT 0 -- minutes after midnight
+		CHECK( quiet_start >= 0 AND quiet_start < 1440),
+	quiet_end SMALLINT NOT NULL DEFAULT 0 -- minutes after midnight, disabled if equal to quiet_start
+		CHECK( quiet_end >= 0 AND quiet_end < 1440),
+	quiet_days SMALLINT NOT NULL DEFAULT 127 -- bitmask, bit 0 is sunday
+		CHECK( quiet_days >= 0 AND quiet_days <= 127)
+);
+
+-- player count notifications that were suppressed during quiet hours
+-- and need to be reevaluated once the quiet hours are over
+CREATE TABLE IF NOT EXISTS deferred_player_count_notifications (
+	user_id BIGINT NOT NULL,
+	message_id BIGINT NOT NULL
+		REFERENCES tracking(message_id)
+		ON DELETE CASCADE
+		ON UPDATE CASCADE,
+	PRIMARY KEY (user_id, message_id)
+);
+
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS deferred_player_count_notifications;
+DROP TABLE IF EXISTS user_notification_settings;
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS webhooks (
+	webhook_id BIGSERIAL PRIMARY KEY,
+	guild_id BIGINT NOT NULL
+		REFERENCES guilds(guild_id)
+		ON DELETE CASCADE,
+	url VARCHAR(2048) NOT NULL,
+	secret VARCHAR(256) NOT NULL, -- HMAC-SHA256 key
+	events TEXT[] NOT NULL DEFAULT '{}', -- empty for all events
+	UNIQUE (guild_id, url)
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+	delivery_id BIGSERIAL PRIMARY KEY,
+	webhook_id BIGINT NOT NULL
+		REFERENCES webhooks(webhook_id)
+		ON DELETE CASCADE,
+	event VARCHAR(64) NOT NULL,
+	status_code INTEGER NOT NULL, -- 0 if no response was received
+	attempts INTEGER NOT NULL,
+	error TEXT NOT NULL DEFAULT '',
+	delivered_at timestamp WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_webhook_id_idx ON webhook_deliveries (webhook_id, delivery_id);
+
+
+---- create above / drop below ----
+
+DROP INDEX IF EXISTS webhook_deliveries_webhook_id_idx;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
@@ -0,0 +1,33 @@
+-- player count samples of tracked servers
+CREATE TABLE IF NOT EXISTS server_history (
+	address VARCHAR(64) NOT NULL,
+	timestamp timestamp WITH TIME ZONE NOT NULL,
+	online BOOLEAN NOT NULL, -- listed on the master server
+	num_players SMALLINT NOT NULL,
+	num_spectators SMALLINT NOT NULL,
+	max_players SMALLINT NOT NULL,
+	map VARCHAR(128) NOT NULL,
+	PRIMARY KEY (address, timestamp)
+);
+
+CREATE INDEX IF NOT EXISTS server_history_timestamp_idx ON server_history (timestamp);
+
+-- aggregated samples, raw samples are pruned after their retention period
+CREATE TABLE IF NOT EXISTS server_history_rollups (
+	address VARCHAR(64) NOT NULL,
+	bucket_size INTEGER NOT NULL, -- seconds, 3600 for hourly and 86400 for daily buckets
+	bucket timestamp WITH TIME ZONE NOT NULL, -- start of the bucket
+	samples INTEGER NOT NULL,
+	online_samples INTEGER NOT NULL,
+	avg_players REAL NOT NULL,
+	peak_players SMALLINT NOT NULL,
+	avg_spectators REAL NOT NULL,
+	PRIMARY KEY (address, bucket_size, bucket)
+);
+
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS server_history_rollups;
+DROP INDEX IF EXISTS server_history_timestamp_idx;
+DROP TABLE IF EXISTS server_history;
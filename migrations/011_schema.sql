@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS guild_settings (
+	guild_id BIGINT PRIMARY KEY NOT NULL
+		REFERENCES guilds(guild_id)
+		ON DELETE CASCADE,
+	timezone VARCHAR(64) NOT NULL DEFAULT 'UTC' -- IANA time zone name
+);
+
+-- activity heatmaps that are posted once a week
+CREATE TABLE IF NOT EXISTS weekly_heatmaps (
+	guild_id BIGINT NOT NULL
+		REFERENCES guilds(guild_id)
+		ON DELETE CASCADE,
+	channel_id BIGINT NOT NULL -- channel that contains the tracked servers
+		REFERENCES channels(channel_id)
+		ON DELETE CASCADE,
+	address VARCHAR(64) NOT NULL, -- empty for all tracked servers of the channel
+	post_channel_id BIGINT NOT NULL,
+	last_posted_at timestamp WITH TIME ZONE NOT NULL DEFAULT NOW(),
+	-- failed posts are attempted again with an exponential backoff
+	last_attempt_at timestamp WITH TIME ZONE,
+	failed_attempts INTEGER NOT NULL DEFAULT 0,
+	PRIMARY KEY (channel_id, address)
+);
+
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS weekly_heatmaps;
+DROP TABLE IF EXISTS guild_settings;
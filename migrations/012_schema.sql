@@ -0,0 +1,24 @@
+-- time ranges in which a player was connected to a tracked server
+CREATE TABLE IF NOT EXISTS player_sessions (
+	id BIGSERIAL PRIMARY KEY NOT NULL,
+	address VARCHAR(64) NOT NULL,
+	name VARCHAR(32) NOT NULL,
+	clan VARCHAR(32) NOT NULL,
+	joined_at timestamp WITH TIME ZONE NOT NULL,
+	left_at timestamp WITH TIME ZONE -- NULL while the player is online
+);
+
+-- at most one open session per player and server
+CREATE UNIQUE INDEX IF NOT EXISTS player_sessions_open_idx ON player_sessions (address, name) WHERE left_at IS NULL;
+CREATE INDEX IF NOT EXISTS player_sessions_name_idx ON player_sessions (lower(name));
+CREATE INDEX IF NOT EXISTS player_sessions_address_idx ON player_sessions (address, joined_at);
+CREATE INDEX IF NOT EXISTS player_sessions_left_at_idx ON player_sessions (left_at);
+
+
+---- create above / drop below ----
+
+DROP INDEX IF EXISTS player_sessions_left_at_idx;
+DROP INDEX IF EXISTS player_sessions_address_idx;
+DROP INDEX IF EXISTS player_sessions_name_idx;
+DROP INDEX IF EXISTS player_sessions_open_idx;
+DROP TABLE IF EXISTS player_sessions;
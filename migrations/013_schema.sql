@@ -0,0 +1,14 @@
+-- accumulated playtime of players on tracked servers per day (UTC)
+CREATE TABLE IF NOT EXISTS player_playtime (
+	address VARCHAR(64) NOT NULL,
+	name VARCHAR(32) NOT NULL,
+	clan VARCHAR(32) NOT NULL,
+	day DATE NOT NULL,
+	seconds INTEGER NOT NULL,
+	PRIMARY KEY (address, day, name, clan)
+);
+
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS player_playtime;
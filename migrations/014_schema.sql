@@ -0,0 +1,22 @@
+-- weekly summaries of the tracked servers of a channel
+CREATE TABLE IF NOT EXISTS weekly_digests (
+	guild_id BIGINT NOT NULL
+		REFERENCES guilds(guild_id)
+		ON DELETE CASCADE,
+	channel_id BIGINT PRIMARY KEY NOT NULL -- channel that contains the tracked servers
+		REFERENCES channels(channel_id)
+		ON DELETE CASCADE,
+	post_channel_id BIGINT NOT NULL,
+	weekday SMALLINT NOT NULL, -- 0 = sunday
+	time_of_day SMALLINT NOT NULL, -- minutes after midnight
+	timezone VARCHAR(64) NOT NULL, -- IANA time zone name of the schedule
+	last_posted_at timestamp WITH TIME ZONE NOT NULL DEFAULT NOW(),
+	-- failed posts are attempted again with an exponential backoff
+	last_attempt_at timestamp WITH TIME ZONE,
+	failed_attempts INTEGER NOT NULL DEFAULT 0
+);
+
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS weekly_digests;
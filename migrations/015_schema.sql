@@ -0,0 +1,23 @@
+-- time ranges in which a map was played on a tracked server
+CREATE TABLE IF NOT EXISTS map_sessions (
+	id BIGSERIAL PRIMARY KEY NOT NULL,
+	address VARCHAR(64) NOT NULL,
+	map VARCHAR(128) NOT NULL,
+	started_at timestamp WITH TIME ZONE NOT NULL,
+	ended_at timestamp WITH TIME ZONE, -- NULL while the map is played
+	peak_players SMALLINT NOT NULL DEFAULT 0,
+	player_seconds BIGINT NOT NULL DEFAULT 0 -- accumulated playtime of all players
+);
+
+-- at most one open session per server
+CREATE UNIQUE INDEX IF NOT EXISTS map_sessions_open_idx ON map_sessions (address) WHERE ended_at IS NULL;
+CREATE INDEX IF NOT EXISTS map_sessions_address_idx ON map_sessions (address, started_at);
+CREATE INDEX IF NOT EXISTS map_sessions_ended_at_idx ON map_sessions (ended_at);
+
+
+---- create above / drop below ----
+
+DROP INDEX IF EXISTS map_sessions_ended_at_idx;
+DROP INDEX IF EXISTS map_sessions_address_idx;
+DROP INDEX IF EXISTS map_sessions_open_idx;
+DROP TABLE IF EXISTS map_sessions;
@@ -0,0 +1,10 @@
+-- trigram similarity for fuzzy player name searches
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS active_server_clients_name_trgm_idx ON active_server_clients USING GIN (name gin_trgm_ops);
+
+
+---- create above / drop below ----
+
+DROP INDEX IF EXISTS active_server_clients_name_trgm_idx;
+DROP EXTENSION IF EXISTS pg_trgm;
@@ -0,0 +1,7 @@
+-- region of a server like eu:de as reported by the master server
+ALTER TABLE active_servers ADD COLUMN IF NOT EXISTS location VARCHAR(16) NOT NULL DEFAULT '';
+
+
+---- create above / drop below ----
+
+ALTER TABLE active_servers DROP COLUMN IF EXISTS location;
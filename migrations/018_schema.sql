@@ -0,0 +1,19 @@
+-- live messages that list the most populated servers of the master server
+CREATE TABLE IF NOT EXISTS top_servers_tracking (
+	message_id BIGINT PRIMARY KEY NOT NULL,
+	guild_id BIGINT NOT NULL
+		REFERENCES guilds(guild_id)
+		ON DELETE CASCADE,
+	channel_id BIGINT NOT NULL
+		REFERENCES channels(channel_id)
+		ON DELETE CASCADE,
+	gametype VARCHAR(64) NOT NULL, -- empty for all gametypes
+	count SMALLINT NOT NULL,
+	ranking TEXT NOT NULL DEFAULT '', -- last published ranking
+	CONSTRAINT top_servers_tracking_unique_message_id UNIQUE (guild_id, channel_id, message_id)
+);
+
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS top_servers_tracking;
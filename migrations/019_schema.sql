@@ -0,0 +1,30 @@
+-- every server address that was listed on the master server,
+-- servers that are no longer listed are forgotten after a while
+CREATE TABLE IF NOT EXISTS known_servers (
+	address VARCHAR(64) PRIMARY KEY NOT NULL,
+	first_seen_at timestamp WITH TIME ZONE NOT NULL DEFAULT NOW(),
+	last_seen_at timestamp WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS known_servers_last_seen_at_idx ON known_servers (last_seen_at);
+
+-- alerts about servers that appear on the master server for the first time
+CREATE TABLE IF NOT EXISTS discovery_rules (
+	id BIGSERIAL PRIMARY KEY NOT NULL,
+	guild_id BIGINT NOT NULL
+		REFERENCES guilds(guild_id)
+		ON DELETE CASCADE,
+	alert_channel_id BIGINT NOT NULL
+		REFERENCES channels(channel_id)
+		ON DELETE CASCADE,
+	name VARCHAR(64) NOT NULL DEFAULT '', -- part of the server name, empty for any name
+	gametype VARCHAR(64) NOT NULL DEFAULT '', -- part of the gametype, empty for any gametype
+	CONSTRAINT discovery_rules_unique UNIQUE (guild_id, alert_channel_id, name, gametype)
+);
+
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS discovery_rules;
+DROP INDEX IF EXISTS known_servers_last_seen_at_idx;
+DROP TABLE IF EXISTS known_servers;
@@ -0,0 +1,7 @@
+-- members with this role may manage the bot without administrator permissions
+ALTER TABLE guild_settings ADD COLUMN IF NOT EXISTS manager_role_id BIGINT;
+
+
+---- create above / drop below ----
+
+ALTER TABLE guild_settings DROP COLUMN IF EXISTS manager_role_id;
@@ -0,0 +1,7 @@
+-- language of the bot, NULL for the preferred language of the guild
+ALTER TABLE guild_settings ADD COLUMN IF NOT EXISTS language VARCHAR(8);
+
+
+---- create above / drop below ----
+
+ALTER TABLE guild_settings DROP COLUMN IF EXISTS language;
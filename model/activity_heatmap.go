@@ -0,0 +1,121 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/jxsl13/twstatus-bot/chart"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+// HeatmapWeeks is the number of weeks that are taken into account for activity heatmaps.
+const HeatmapWeeks = 4
+
+var heatmapDays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
+
+// ActivityHeatmap contains the average player count per weekday and local hour of day.
+type ActivityHeatmap struct {
+	Location *time.Location
+	// indexed by weekday starting on monday and hour of day, NaN in case there is no data
+	Values [7][24]float64
+}
+
+// NewActivityHeatmap aggregates hourly buckets of one or multiple servers.
+// The player counts of multiple servers are summed up per bucket.
+func NewActivityHeatmap(hourly []sqlc.ServerHistoryRollup, loc *time.Location) ActivityHeatmap {
+	totals := make(map[int64]float64, len(hourly))
+	for _, r := range hourly {
+		totals[r.Bucket.Time.Unix()] += float64(r.AvgPlayers)
+	}
+
+	var (
+		sums   [7][24]float64
+		counts [7][24]int
+	)
+	for bucket, total := range totals {
+		local := time.Unix(bucket, 0).In(loc)
+		day := mondayFirst(local.Weekday())
+		sums[day][local.Hour()] += total
+		counts[day][local.Hour()]++
+	}
+
+	h := ActivityHeatmap{
+		Location: loc,
+	}
+	for day := range sums {
+		for hour := range sums[day] {
+			if counts[day][hour] == 0 {
+				h.Values[day][hour] = math.NaN()
+				continue
+			}
+			h.Values[day][hour] = sums[day][hour] / float64(counts[day][hour])
+		}
+	}
+	return h
+}
+
+// Peak returns the weekday and hour with the highest average player count.
+// ok is false in case there is no data.
+func (h *ActivityHeatmap) Peak() (day time.Weekday, hour int, players float64, ok bool) {
+	players = -1
+	for d := range h.Values {
+		for hr, v := range h.Values[d] {
+			if !math.IsNaN(v) && v > players {
+				day, hour, players, ok = time.Weekday((d+1)%7), hr, v, true
+			}
+		}
+	}
+	return day, hour, max(players, 0), ok
+}
+
+// Chart returns a heatmap with a row per weekday and a column per hour of day.
+// The chart font only contains ASCII glyphs, which is why all texts that are
+// drawn into the image are English.
+func (h *ActivityHeatmap) Chart(title string) chart.Heatmap {
+	rows := make([]string, 0, len(heatmapDays))
+	for _, day := range heatmapDays {
+		rows = append(rows, day.String()[:3])
+	}
+
+	columns := make([]string, 0, 24)
+	for hour := 0; hour < 24; hour++ {
+		columns = append(columns, fmt.Sprintf("%02d", hour))
+	}
+
+	values := make([][]float64, 0, len(h.Values))
+	for d := range h.Values {
+		values = append(values, h.Values[d][:])
+	}
+
+	return chart.Heatmap{
+		Title:        title,
+		RowLabels:    rows,
+		ColumnLabels: columns,
+		Values:       values,
+		Legend:       fmt.Sprintf("avg. players (%s)", h.Location),
+	}
+}
+
+func (h ActivityHeatmap) String() string {
+	return h.Format(i18n.English)
+}
+
+func (h ActivityHeatmap) Format(l i18n.Localizer) string {
+	day, hour, players, ok := h.Peak()
+	if !ok {
+		return l.T("heatmap.no_history")
+	}
+	return l.T("heatmap.busiest", l.Choice(day.String()), hour, (hour+1)%24, h.Location, players)
+}
+
+// shortWeekday returns the abbreviated name of the weekday, e.g. Mon.
+func shortWeekday(l i18n.Localizer, day time.Weekday) string {
+	return l.T("weekday.short." + strings.ToLower(day.String()))
+}
+
+func mondayFirst(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
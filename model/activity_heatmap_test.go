@@ -0,0 +1,120 @@
+package model_test
+
+import (
+	"bytes"
+	"image/png"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActivityHeatmap(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	bucket := func(s string) pgtype.Timestamptz {
+		ts, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+		return pgtype.Timestamptz{Time: ts, Valid: true}
+	}
+
+	rows := []sqlc.ServerHistoryRollup{
+		// monday 20:00 in Berlin
+		{Address: "a", BucketSize: 3600, Bucket: bucket("2024-03-04T19:00:00Z"), AvgPlayers: 4},
+		{Address: "b", BucketSize: 3600, Bucket: bucket("2024-03-04T19:00:00Z"), AvgPlayers: 2},
+		// next monday 20:00 in Berlin
+		{Address: "a", BucketSize: 3600, Bucket: bucket("2024-03-11T19:00:00Z"), AvgPlayers: 10},
+		// sunday 00:00 in Berlin
+		{Address: "a", BucketSize: 3600, Bucket: bucket("2024-03-09T23:00:00Z"), AvgPlayers: 1},
+	}
+
+	h := model.NewActivityHeatmap(rows, berlin)
+	require.Equal(t, 8.0, h.Values[0][20]) // (4+2+10)/2
+	require.Equal(t, 1.0, h.Values[6][0])
+	require.True(t, math.IsNaN(h.Values[0][19]))
+
+	day, hour, players, ok := h.Peak()
+	require.True(t, ok)
+	require.Equal(t, time.Monday, day)
+	require.Equal(t, 20, hour)
+	require.Equal(t, 8.0, players)
+	require.Equal(t, "Busiest time: Monday 20:00 - 21:00 (Europe/Berlin) with 8.0 players on average", h.String())
+	require.Equal(t, "Meiste Aktivität: Montag 20:00 - 21:00 (Europe/Berlin) mit durchschnittlich 8.0 Spielern", h.Format(i18n.New("de")))
+
+	empty := model.NewActivityHeatmap(nil, time.UTC)
+	_, _, _, ok = empty.Peak()
+	require.False(t, ok)
+	hm := empty.Chart("empty")
+	require.Len(t, hm.Values, 7)
+	require.Equal(t, []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}, hm.RowLabels)
+}
+
+func TestActivityHeatmapChartRussian(t *testing.T) {
+	rows := []sqlc.ServerHistoryRollup{
+		{Address: "a", BucketSize: 3600, Bucket: pgtype.Timestamptz{Time: time.Date(2024, 3, 4, 19, 0, 0, 0, time.UTC), Valid: true}, AvgPlayers: 4},
+	}
+	h := model.NewActivityHeatmap(rows, time.UTC)
+
+	// the localized text is sent as message content
+	require.Contains(t, h.Format(i18n.New("ru")), "Самое оживлённое время: Понедельник 19:00")
+
+	// the chart font only contains ASCII glyphs
+	hm := h.Chart("a - last 4 weeks")
+	requireDrawable(t, hm.Title)
+	requireDrawable(t, hm.Legend)
+	for _, label := range append(hm.RowLabels, hm.ColumnLabels...) {
+		requireDrawable(t, label)
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, hm.WritePNG(&buf, 1000, 340))
+	img, err := png.Decode(&buf)
+	require.NoError(t, err)
+	require.Equal(t, 1000, img.Bounds().Dx())
+}
+
+// requireDrawable checks that a text can be drawn with the ASCII chart font.
+func requireDrawable(t *testing.T, s string) {
+	t.Helper()
+	for _, r := range s {
+		require.Truef(t, r >= ' ' && r <= '~', "%q contains %q which is not part of the chart font", s, r)
+	}
+}
+
+func TestWeeklyHeatmapIsDue(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	local := func(s string) time.Time {
+		ts, err := time.ParseInLocation("2006-01-02 15:04", s, berlin)
+		require.NoError(t, err)
+		return ts
+	}
+
+	require.Equal(t, local("2024-03-04 00:00"), model.WeekStart(local("2024-03-10 23:59"), berlin))
+	require.Equal(t, local("2024-03-11 00:00"), model.WeekStart(local("2024-03-11 00:00"), berlin))
+
+	w := model.WeeklyHeatmap{
+		LastPostedAt: local("2024-03-04 00:10"),
+		Location:     berlin,
+	}
+	require.False(t, w.IsDue(local("2024-03-10 23:59")))
+	require.True(t, w.IsDue(local("2024-03-11 00:00")))
+
+	// failed posts back off
+	w.LastAttemptAt = local("2024-03-11 00:00")
+	w.FailedAttempts = 1
+	require.False(t, w.IsDue(local("2024-03-11 00:14")))
+	require.True(t, w.IsDue(local("2024-03-11 00:15")))
+	w.FailedAttempts = 3
+	require.False(t, w.IsDue(local("2024-03-11 00:59")))
+	require.True(t, w.IsDue(local("2024-03-11 01:00")))
+	w.FailedAttempts = 100
+	require.Equal(t, local("2024-03-11 12:00"), model.NextAttempt(w.LastAttemptAt, w.FailedAttempts))
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+const (
+	// MaxAutocompleteChoices is the maximum number of choices discord accepts.
+	MaxAutocompleteChoices = 25
+	// maximum length of the name and the value of a choice
+	maxAutocompleteChoiceLen = 100
+)
+
+// AutocompleteChoice is a suggested value of a command option.
+type AutocompleteChoice struct {
+	Name  string
+	Value string
+}
+
+func NewServerChoiceFromSQLC(row sqlc.AutocompleteServersRow) AutocompleteChoice {
+	return AutocompleteChoice{
+		Name:  truncateChoice(fmt.Sprintf("%s (%s)", row.Name, row.Address)),
+		Value: row.Address,
+	}
+}
+
+func NewFlagChoiceFromSQLC(row sqlc.Flag) AutocompleteChoice {
+	return AutocompleteChoice{
+		Name:  fmt.Sprintf("%s %s", row.Abbr, row.Emoji),
+		Value: row.Abbr,
+	}
+}
+
+// WithPrefix prepends the prefix to the value, which allows to complete an item of a list.
+// Choices that would exceed the length limit of values are not valid anymore.
+func (c AutocompleteChoice) WithPrefix(prefix string) (AutocompleteChoice, bool) {
+	c.Value = prefix + c.Value
+	return c, len(c.Value) <= maxAutocompleteChoiceLen
+}
+
+// truncateChoice keeps the name below the length limit of discord, which counts characters.
+func truncateChoice(s string) string {
+	runes := []rune(s)
+	if len(runes) <= maxAutocompleteChoiceLen {
+		return s
+	}
+	return string(runes[:maxAutocompleteChoiceLen-3]) + "..."
+}
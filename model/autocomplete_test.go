@@ -0,0 +1,30 @@
+package model_test
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutocompleteChoice(t *testing.T) {
+	c := model.NewServerChoiceFromSQLC(sqlc.AutocompleteServersRow{
+		Address: "127.0.0.1:8303",
+		Name:    strings.Repeat("ä", 120),
+	})
+	require.Equal(t, 100, utf8.RuneCountInString(c.Name))
+	require.True(t, strings.HasSuffix(c.Name, "..."))
+	require.Equal(t, "127.0.0.1:8303", c.Value)
+
+	c, ok := c.WithPrefix("127.0.0.1:8304,")
+	require.True(t, ok)
+	require.Equal(t, "127.0.0.1:8304,127.0.0.1:8303", c.Value)
+	_, ok = c.WithPrefix(strings.Repeat("127.0.0.1:8304,", 5))
+	require.False(t, ok)
+
+	f := model.NewFlagChoiceFromSQLC(sqlc.Flag{FlagID: 276, Abbr: "de", Emoji: ":flag_de:"})
+	require.Equal(t, model.AutocompleteChoice{Name: "de :flag_de:", Value: "de"}, f)
+}
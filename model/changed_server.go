@@ -1,7 +1,7 @@
 package model
 
 import (
-	"fmt"
+	"github.com/jxsl13/twstatus-bot/i18n"
 )
 
 type ChangedServerStatus struct {
@@ -12,9 +12,9 @@ type ChangedServerStatus struct {
 	Offline bool
 }
 
-func (c *ChangedServerStatus) Content() string {
+func (c *ChangedServerStatus) Content(l i18n.Localizer) string {
 	if c.Offline {
-		return fmt.Sprintf("%s [OFFLINE]", c.Prev.Name)
+		return l.T("status.offline", c.Prev.Name)
 	}
 
 	header := c.Curr.Header()
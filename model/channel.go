@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
 	"github.com/jxsl13/twstatus-bot/sqlc"
 )
 
@@ -33,10 +34,10 @@ func (c Channel) String() string {
 	return fmt.Sprintf("<#%d>", c.ID)
 }
 
-func (c Channel) StatusString() string {
-	active := "inactive"
+func (c Channel) StatusString(l i18n.Localizer) string {
+	active := l.T("channel.inactive")
 	if c.Running {
-		active = "active"
+		active = l.T("channel.active")
 	}
 	return fmt.Sprintf("%s (%s)", c.String(), active)
 }
@@ -55,13 +56,13 @@ func (c Channels) String() string {
 	return sb.String()
 }
 
-func (c Channels) StatusString() string {
+func (c Channels) StatusString(l i18n.Localizer) string {
 	if len(c) == 0 {
-		return "no channels"
+		return l.T("channels.none")
 	}
 	var sb strings.Builder
 	for _, channel := range c {
-		sb.WriteString(channel.StatusString())
+		sb.WriteString(channel.StatusString(l))
 		sb.WriteString("\n")
 	}
 	return sb.String()
@@ -0,0 +1,137 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/markdown"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+// DiscoveryRule alerts a channel when a server that matches the rule
+// appears on the master server for the first time.
+type DiscoveryRule struct {
+	ID             int64
+	GuildID        discord.GuildID
+	AlertChannelID discord.ChannelID
+	// part of the server name, empty for any name
+	Name string
+	// part of the gametype, empty for any gametype
+	Gametype string
+}
+
+func NewDiscoveryRuleFromSQLC(row sqlc.DiscoveryRule) DiscoveryRule {
+	return DiscoveryRule{
+		ID:             row.ID,
+		GuildID:        discord.GuildID(row.GuildID),
+		AlertChannelID: discord.ChannelID(row.AlertChannelID),
+		Name:           row.Name,
+		Gametype:       row.Gametype,
+	}
+}
+
+func (r *DiscoveryRule) ToAddSQLC() sqlc.AddDiscoveryRuleParams {
+	return sqlc.AddDiscoveryRuleParams{
+		GuildID:        int64(r.GuildID),
+		AlertChannelID: int64(r.AlertChannelID),
+		Name:           r.Name,
+		Gametype:       r.Gametype,
+	}
+}
+
+// Matches compares case insensitively like the server search.
+func (r *DiscoveryRule) Matches(s FoundServer) bool {
+	return strings.Contains(strings.ToLower(s.Name), strings.ToLower(r.Name)) &&
+		strings.Contains(strings.ToLower(s.Gametype), strings.ToLower(r.Gametype))
+}
+
+// Filter returns a human readable description of the servers that match the rule.
+func (r *DiscoveryRule) Filter(l i18n.Localizer) string {
+	filters := make([]string, 0, 2)
+	if r.Name != "" {
+		filters = append(filters, l.T("discovery_rule.name_contains", markdown.Escape(r.Name)))
+	}
+	if r.Gametype != "" {
+		filters = append(filters, l.T("discovery_rule.gametype_contains", markdown.Escape(r.Gametype)))
+	}
+	if len(filters) == 0 {
+		return l.T("discovery_rule.all_servers")
+	}
+	return strings.Join(filters, ", ")
+}
+
+func (r DiscoveryRule) String() string {
+	return r.Format(i18n.English)
+}
+
+func (r DiscoveryRule) Format(l i18n.Localizer) string {
+	return fmt.Sprintf("%d: %s -> %s", r.ID, r.Filter(l), r.AlertChannelID.Mention())
+}
+
+type DiscoveryRules []DiscoveryRule
+
+func (r DiscoveryRules) String() string {
+	return r.Format(i18n.English)
+}
+
+func (r DiscoveryRules) Format(l i18n.Localizer) string {
+	if len(r) == 0 {
+		return l.T("discovery_rules.none")
+	}
+	var sb strings.Builder
+	sb.Grow(len(r) * 64)
+	for _, rule := range r {
+		sb.WriteString(rule.Format(l))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// DiscoveryAlert is sent when a new server matches a discovery rule.
+type DiscoveryAlert struct {
+	DiscoveryRule
+	Server FoundServer
+}
+
+func (a *DiscoveryAlert) ToEmbed(l i18n.Localizer) discord.Embed {
+	title := a.Server.Name
+	if a.Server.Passworded {
+		title += " 🔒"
+	}
+	location := a.Server.Location
+	if location == "" {
+		location = l.T("discovery.unknown_region")
+	}
+
+	return discord.Embed{
+		Title:       markdown.Escape(title),
+		Description: l.T("discovery.new_server", a.Filter(l)),
+		URL:         ConnectURL(a.Server.Address),
+		Fields: []discord.EmbedField{
+			{Name: l.T("alert.address"), Value: a.Server.Address, Inline: true},
+			{Name: l.T("discovery.gametype"), Value: markdown.Escape(a.Server.Gametype), Inline: true},
+			{Name: l.T("discovery.map"), Value: markdown.Escape(a.Server.Map), Inline: true},
+			{Name: l.T("discovery.players"), Value: fmt.Sprintf("%d/%d", a.Server.NumPlayers, a.Server.MaxPlayers), Inline: true},
+			{Name: l.T("discovery.version"), Value: markdown.Escape(a.Server.Version), Inline: true},
+			{Name: l.T("discovery.region"), Value: location, Inline: true},
+		},
+	}
+}
+
+// NewDiscoveryAlerts matches the new servers against all discovery rules.
+func NewDiscoveryAlerts(rules DiscoveryRules, servers FoundServers) []DiscoveryAlert {
+	alerts := make([]DiscoveryAlert, 0)
+	for _, server := range servers {
+		for _, rule := range rules {
+			if rule.Matches(server) {
+				alerts = append(alerts, DiscoveryAlert{
+					DiscoveryRule: rule,
+					Server:        server,
+				})
+			}
+		}
+	}
+	return alerts
+}
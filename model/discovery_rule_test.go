@@ -0,0 +1,41 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscoveryRules(t *testing.T) {
+	rules := model.DiscoveryRules{
+		{ID: 1, AlertChannelID: 10, Name: "[abc]"},
+		{ID: 2, AlertChannelID: 20, Gametype: "ctf"},
+		{ID: 3, AlertChannelID: 30, Name: "abc", Gametype: "DDNet"},
+	}
+	require.Equal(t, "1: name contains \\[abc\\] -> <#10>\n2: gametype contains ctf -> <#20>\n3: name contains abc, gametype contains DDNet -> <#30>\n", rules.String())
+
+	servers := model.FoundServers{
+		{Address: "127.0.0.1:8303", Name: "[ABC] Fun CTF", Gametype: "CTF"},
+		{Address: "127.0.0.1:8304", Name: "ABC Novice", Gametype: "DDraceNetwork"},
+		{Address: "127.0.0.1:8305", Name: "[ABC] DDNet", Gametype: "DDNet"},
+		{Address: "127.0.0.1:8306", Name: "Other", Gametype: "dm"},
+	}
+
+	alerts := model.NewDiscoveryAlerts(rules, servers)
+	matches := make([]string, 0, len(alerts))
+	for _, a := range alerts {
+		matches = append(matches, a.Server.Address+" "+a.Filter(i18n.English))
+	}
+	require.Equal(t, []string{
+		"127.0.0.1:8303 name contains \\[abc\\]",
+		"127.0.0.1:8303 gametype contains ctf",
+		"127.0.0.1:8305 name contains \\[abc\\]",
+		"127.0.0.1:8305 name contains abc, gametype contains DDNet",
+	}, matches)
+
+	embed := alerts[0].ToEmbed(i18n.English)
+	require.Equal(t, "https://ddnet.org/connect-to/?addr=127.0.0.1%3A8303", embed.URL)
+	require.Equal(t, "unknown", embed.Fields[len(embed.Fields)-1].Value)
+}
@@ -0,0 +1,271 @@
+package model
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jxsl13/twstatus-bot/i18n"
+)
+
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatJSON = "json"
+
+	// attachment size limit of discord for servers without boosts
+	MaxAttachmentSize = 8 * 1024 * 1024
+	// maximum number of attachments per message
+	maxAttachments = 10
+)
+
+// ExportFormats are the file formats that can be selected for exports.
+var ExportFormats = []string{ExportFormatCSV, ExportFormatJSON}
+
+// ErrExportTooLarge is returned in case an export does not fit into a single message.
+var ErrExportTooLarge = i18n.NewError("error.export_too_large")
+
+// ExportFile is an attachment of an export.
+type ExportFile struct {
+	Name string
+	Data []byte
+}
+
+// Export contains the recorded history of a server between From and To.
+type Export struct {
+	Address     string
+	From        time.Time
+	To          time.Time
+	History     ServerHistory
+	Sessions    PlayerSessions
+	MapSessions MapSessions
+}
+
+// exportTable is a list of records with the same columns.
+type exportTable struct {
+	name    string
+	columns []string
+	rows    [][]any
+}
+
+func (e *Export) tables() []exportTable {
+	history := exportTable{
+		name:    "history",
+		columns: []string{"timestamp", "duration_seconds", "online_ratio", "players", "spectators", "map"},
+		rows:    make([][]any, 0, len(e.History.Samples)),
+	}
+	for _, s := range e.History.Samples {
+		if s.Timestamp.Before(e.From) || s.Timestamp.After(e.To) {
+			continue
+		}
+		history.rows = append(history.rows, []any{
+			s.Timestamp,
+			int64(s.Duration / time.Second),
+			s.OnlineRatio,
+			s.NumPlayers,
+			s.NumSpectators,
+			s.Map,
+		})
+	}
+
+	sessions := exportTable{
+		name:    "sessions",
+		columns: []string{"name", "clan", "joined_at", "left_at", "duration_seconds"},
+		rows:    make([][]any, 0, len(e.Sessions)),
+	}
+	for _, s := range e.Sessions {
+		sessions.rows = append(sessions.rows, []any{
+			s.Name,
+			s.Clan,
+			s.JoinedAt,
+			exportEnd(s.LeftAt),
+			int64(s.Duration(e.To) / time.Second),
+		})
+	}
+
+	maps := exportTable{
+		name:    "maps",
+		columns: []string{"map", "started_at", "ended_at", "peak_players", "player_seconds"},
+		rows:    make([][]any, 0, len(e.MapSessions)),
+	}
+	for _, s := range e.MapSessions {
+		maps.rows = append(maps.rows, []any{
+			s.Map,
+			s.StartedAt,
+			exportEnd(s.EndedAt),
+			s.PeakPlayers,
+			int64(s.PlayerTime / time.Second),
+		})
+	}
+
+	return []exportTable{history, sessions, maps}
+}
+
+// exportEnd returns nil for sessions that did not end yet.
+func exportEnd(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// Files encodes the export in the given format. Files that exceed the limit are compressed
+// and split into multiple parts in case the compressed file still exceeds the limit.
+func (e *Export) Files(format string, limit int) ([]ExportFile, error) {
+	var encode func(exportTable) ([]byte, error)
+	switch format {
+	case ExportFormatCSV:
+		encode = encodeCSV
+	case ExportFormatJSON:
+		encode = encodeJSON
+	default:
+		return nil, i18n.NewError("error.invalid_export_format", format, strings.Join(ExportFormats, ", "))
+	}
+
+	prefix := strings.NewReplacer(":", "_", "[", "", "]", "").Replace(e.Address)
+	files := make([]ExportFile, 0, 3)
+	for _, table := range e.tables() {
+		table.name = fmt.Sprintf("%s_%s", prefix, table.name)
+		parts, err := splitExportTable(table, format, encode, limit)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, parts...)
+	}
+
+	if len(files) > maxAttachments {
+		return nil, ErrExportTooLarge
+	}
+	return files, nil
+}
+
+// splitExportTable encodes the table as a single file if possible.
+// Otherwise the table is compressed and, if required, split in halves until every part fits into the limit.
+func splitExportTable(table exportTable, ext string, encode func(exportTable) ([]byte, error), limit int) ([]ExportFile, error) {
+	data, err := encode(table)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) <= limit {
+		return []ExportFile{{Name: fmt.Sprintf("%s.%s", table.name, ext), Data: data}}, nil
+	}
+
+	compressed, err := compress(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(compressed) <= limit {
+		return []ExportFile{{Name: fmt.Sprintf("%s.%s.gz", table.name, ext), Data: compressed}}, nil
+	}
+	if len(table.rows) <= 1 {
+		return nil, ErrExportTooLarge
+	}
+
+	half := len(table.rows) / 2
+	files := make([]ExportFile, 0, 2)
+	for i, rows := range [][][]any{table.rows[:half], table.rows[half:]} {
+		part := exportTable{
+			name:    fmt.Sprintf("%s_%d", table.name, i+1),
+			columns: table.columns,
+			rows:    rows,
+		}
+		parts, err := splitExportTable(part, ext, encode, limit)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, parts...)
+		if len(files) > maxAttachments {
+			return nil, ErrExportTooLarge
+		}
+	}
+	return files, nil
+}
+
+func compress(data []byte) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w := gzip.NewWriter(buf)
+	_, err := w.Write(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compress export: %w", err)
+	}
+	err = w.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compress export: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func encodeCSV(table exportTable) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+	err := w.Write(table.columns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", table.name, err)
+	}
+
+	record := make([]string, len(table.columns))
+	for _, row := range table.rows {
+		for i, v := range row {
+			record[i] = formatCSVValue(v)
+		}
+		err = w.Write(record)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", table.name, err)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", table.name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+func formatCSVValue(v any) string {
+	switch v := v.(type) {
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.UTC().Format(time.RFC3339)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// encodeJSON encodes the table as array of objects with one object per line.
+func encodeJSON(table exportTable) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteString("[")
+	object := make(map[string]any, len(table.columns))
+	for i, row := range table.rows {
+		for j, v := range row {
+			if t, ok := v.(time.Time); ok {
+				v = t.UTC()
+			} else if t, ok := v.(*time.Time); ok && t != nil {
+				v = t.UTC()
+			}
+			object[table.columns[j]] = v
+		}
+
+		data, err := json.Marshal(object)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", table.name, err)
+		}
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n")
+		buf.Write(data)
+	}
+	buf.WriteString("\n]\n")
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,101 @@
+package model_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportFiles(t *testing.T) {
+	to := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	from := to.Add(-time.Hour)
+
+	export := model.Export{
+		Address: "127.0.0.1:8303",
+		From:    from,
+		To:      to,
+		Sessions: model.PlayerSessions{
+			{Name: "alice", Clan: "a,b", JoinedAt: from, LeftAt: from.Add(time.Minute)},
+			{Name: "bob", JoinedAt: from.Add(time.Minute)},
+		},
+		MapSessions: model.MapSessions{
+			{Map: "ctf5", StartedAt: from, PeakPlayers: 2, PlayerTime: time.Minute},
+		},
+	}
+	for i := 0; i < 60; i++ {
+		export.History.Samples = append(export.History.Samples, model.ServerHistorySample{
+			Timestamp:   from.Add(time.Duration(i) * time.Minute),
+			Online:      true,
+			OnlineRatio: 1,
+			NumPlayers:  float64(i % 4),
+			Map:         "ctf5",
+		})
+	}
+
+	files, err := export.Files(model.ExportFormatCSV, model.MaxAttachmentSize)
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+	require.Equal(t, "127.0.0.1_8303_history.csv", files[0].Name)
+
+	records, err := csv.NewReader(bytes.NewReader(files[1].Data)).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		{"name", "clan", "joined_at", "left_at", "duration_seconds"},
+		{"alice", "a,b", "2024-03-01T11:00:00Z", "2024-03-01T11:01:00Z", "60"},
+		{"bob", "", "2024-03-01T11:01:00Z", "", "3540"},
+	}, records)
+
+	files, err = export.Files(model.ExportFormatJSON, model.MaxAttachmentSize)
+	require.NoError(t, err)
+	var maps []map[string]any
+	require.NoError(t, json.Unmarshal(files[2].Data, &maps))
+	require.Len(t, maps, 1)
+	require.Equal(t, "ctf5", maps[0]["map"])
+	require.Nil(t, maps[0]["ended_at"])
+
+	var history []map[string]any
+	require.NoError(t, json.Unmarshal(files[0].Data, &history))
+	require.Len(t, history, 60)
+
+	// the history does not fit into a single uncompressed file
+	files, err = export.Files(model.ExportFormatCSV, 1024)
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1_8303_history.csv.gz", files[0].Name)
+	require.Equal(t, 60+1, len(readLines(t, files[0].Data)))
+
+	// the compressed history does not fit into a single file either
+	files, err = export.Files(model.ExportFormatCSV, 300)
+	require.NoError(t, err)
+	rows := 0
+	for _, f := range files {
+		require.LessOrEqual(t, len(f.Data), 300)
+		if strings.Contains(f.Name, "history") {
+			require.True(t, strings.HasSuffix(f.Name, ".csv.gz"), f.Name)
+			// every part contains the header
+			rows += len(readLines(t, f.Data)) - 1
+		}
+	}
+	require.Equal(t, 60, rows)
+
+	_, err = export.Files(model.ExportFormatCSV, 10)
+	require.ErrorIs(t, err, model.ErrExportTooLarge)
+
+	_, err = export.Files("xml", model.MaxAttachmentSize)
+	require.Error(t, err)
+}
+
+func readLines(t *testing.T, data []byte) []string {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	raw, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return strings.Split(strings.TrimSuffix(string(raw), "\n"), "\n")
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
 	"github.com/jxsl13/twstatus-bot/sqlc"
 )
 
@@ -32,8 +33,12 @@ func (f *FlagMapping) String() string {
 type FlagMappings []FlagMapping
 
 func (f FlagMappings) String() string {
+	return f.Format(i18n.English)
+}
+
+func (f FlagMappings) Format(l i18n.Localizer) string {
 	if len(f) == 0 {
-		return "no flag mappings"
+		return l.T("flag_mappings.none")
 	}
 	var sb strings.Builder
 	sb.Grow(len(f) * 16)
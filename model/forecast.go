@@ -0,0 +1,218 @@
+package model
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+const (
+	// ForecastWeeks is the number of past weeks the forecast is based on.
+	ForecastWeeks = 4
+	// MaxForecastHours is the maximum number of hours that can be predicted.
+	MaxForecastHours = 12
+
+	// weight of a week compared to the week after it
+	forecastWeekDecay = 0.7
+	// the difference between the current and the usual player count fades out by this factor per hour
+	forecastLevelDecay = 0.6
+	// a server is considered busy at this fraction of its highest usual player count
+	forecastBusyRatio = 0.5
+
+	hoursPerWeek = 7 * 24
+)
+
+// ForecastModel is a seasonal average of the player count per weekday and hour (UTC).
+// Recent weeks weigh more than older weeks and the averages are smoothed with their neighboring hours.
+type ForecastModel struct {
+	// indexed by the hour of the week, NaN in case there is no data
+	values [hoursPerWeek]float64
+	// highest usual player count
+	Peak float64
+}
+
+// hourOfWeek returns the index of the hour of the week of t in UTC, starting on sunday.
+func hourOfWeek(t time.Time) int {
+	t = t.UTC()
+	return int(t.Weekday())*24 + t.Hour()
+}
+
+// NewForecastModel trains a forecast model with the hourly aggregates of a single server before now.
+func NewForecastModel(hourly []sqlc.ServerHistoryRollup, now time.Time) ForecastModel {
+	var (
+		week    = 7 * 24 * time.Hour
+		since   = now.Add(-ForecastWeeks * week)
+		sums    [hoursPerWeek]float64
+		weights [hoursPerWeek]float64
+	)
+	for _, r := range hourly {
+		bucket := r.Bucket.Time
+		if bucket.Before(since) || !bucket.Before(now) {
+			continue
+		}
+		w := math.Pow(forecastWeekDecay, float64(now.Sub(bucket)/week))
+		i := hourOfWeek(bucket)
+		sums[i] += w * float64(r.AvgPlayers)
+		weights[i] += w
+	}
+
+	var m ForecastModel
+	for i := range m.values {
+		// neighboring hours smooth out single outliers
+		var sum, weight float64
+		for _, n := range []struct {
+			offset int
+			weight float64
+		}{{-1, 0.25}, {0, 0.5}, {1, 0.25}} {
+			j := (i + n.offset + hoursPerWeek) % hoursPerWeek
+			if weights[j] == 0 {
+				continue
+			}
+			sum += n.weight * sums[j] / weights[j]
+			weight += n.weight
+		}
+		if weight == 0 || weights[i] == 0 {
+			m.values[i] = math.NaN()
+			continue
+		}
+		m.values[i] = sum / weight
+		m.Peak = math.Max(m.Peak, m.values[i])
+	}
+	return m
+}
+
+// NewForecastModels trains a forecast model per server.
+func NewForecastModels(hourly []sqlc.ServerHistoryRollup, now time.Time) map[string]ForecastModel {
+	byAddress := make(map[string][]sqlc.ServerHistoryRollup)
+	for _, r := range hourly {
+		byAddress[r.Address] = append(byAddress[r.Address], r)
+	}
+	models := make(map[string]ForecastModel, len(byAddress))
+	for address, rollups := range byAddress {
+		models[address] = NewForecastModel(rollups, now)
+	}
+	return models
+}
+
+// Usual returns the usual player count at t, false in case there is no data.
+func (m *ForecastModel) Usual(t time.Time) (float64, bool) {
+	v := m.values[hourOfWeek(t)]
+	return v, !math.IsNaN(v)
+}
+
+// ForecastPoint is the predicted player count at a point in time.
+type ForecastPoint struct {
+	Time    time.Time
+	Players float64
+}
+
+// Forecast predicts the player count of the next full hours after now.
+// The difference between the current and the usual player count fades out over time.
+func (m *ForecastModel) Forecast(now time.Time, current float64, hours int) []ForecastPoint {
+	offset := 0.0
+	if usual, ok := m.Usual(now); ok {
+		offset = current - usual
+	}
+
+	points := make([]ForecastPoint, 0, hours)
+	start := now.Truncate(time.Hour)
+	for i := 1; i <= hours; i++ {
+		t := start.Add(time.Duration(i) * time.Hour)
+		usual, ok := m.Usual(t)
+		if !ok {
+			continue
+		}
+		points = append(points, ForecastPoint{
+			Time:    t,
+			Players: math.Max(0, usual+offset*math.Pow(forecastLevelDecay, float64(i))),
+		})
+	}
+	return points
+}
+
+// BusyThreshold returns the player count at which the server is considered busy.
+func (m *ForecastModel) BusyThreshold() float64 {
+	return math.Max(1, m.Peak*forecastBusyRatio)
+}
+
+// BusySoon returns the first full hour within the given number of hours at which the server
+// is usually busy. Servers that are busy already are not reported.
+func (m *ForecastModel) BusySoon(now time.Time, current float64, hours int) (time.Time, bool) {
+	threshold := m.BusyThreshold()
+	if current >= threshold {
+		return time.Time{}, false
+	}
+	for _, p := range m.Forecast(now, current, hours) {
+		if p.Players >= threshold {
+			return p.Time, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// BusyHint returns a short hint for status messages in case the server is usually busy soon.
+func (m *ForecastModel) BusyHint(l i18n.Localizer, now time.Time, current float64) string {
+	t, ok := m.BusySoon(now, current, 3)
+	if !ok {
+		return ""
+	}
+	return l.T("forecast.busy_hint", t.Unix())
+}
+
+// MeanAbsoluteError returns the mean absolute difference between the usual and the actual
+// player counts of the given hourly aggregates. Hours without a prediction are ignored.
+func (m *ForecastModel) MeanAbsoluteError(actual []sqlc.ServerHistoryRollup) float64 {
+	var (
+		sum float64
+		n   int
+	)
+	for _, r := range actual {
+		usual, ok := m.Usual(r.Bucket.Time)
+		if !ok {
+			continue
+		}
+		sum += math.Abs(usual - float64(r.AvgPlayers))
+		n++
+	}
+	if n == 0 {
+		return math.NaN()
+	}
+	return sum / float64(n)
+}
+
+// Forecast is the predicted player count of a server.
+type Forecast struct {
+	Address string
+	Current float64
+	Points  []ForecastPoint
+	// usual player count at which the server is considered busy
+	BusyThreshold float64
+}
+
+func (f Forecast) String() string {
+	return f.Format(i18n.English)
+}
+
+func (f Forecast) Format(l i18n.Localizer) string {
+	var sb strings.Builder
+	sb.Grow(128 + len(f.Points)*48)
+	sb.WriteString(l.T("forecast.title", f.Address, f.Current))
+	sb.WriteString("\n")
+	if len(f.Points) == 0 {
+		sb.WriteString(l.T("forecast.no_history", ForecastWeeks))
+		sb.WriteString("\n")
+		return sb.String()
+	}
+	for _, p := range f.Points {
+		line := l.T("forecast.point", p.Time.Unix(), p.Players)
+		if p.Players >= f.BusyThreshold {
+			line += " " + l.T("forecast.busy")
+		}
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
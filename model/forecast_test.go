@@ -0,0 +1,104 @@
+package model_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+// syntheticHistory returns hourly aggregates of a server that is busy in the evening
+// and more busy on weekends with some random noise.
+func syntheticHistory(address string, from time.Time, weeks int) []sqlc.ServerHistoryRollup {
+	r := rand.New(rand.NewSource(42))
+	hourly := make([]sqlc.ServerHistoryRollup, 0, weeks*7*24)
+	for i := 0; i < weeks*7*24; i++ {
+		t := from.Add(time.Duration(i) * time.Hour)
+		players := 2 + 10*math.Max(0, math.Sin(float64(t.Hour()-12)/12*math.Pi))
+		if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+			players *= 1.5
+		}
+		players = math.Max(0, players+r.NormFloat64()*1.5)
+		hourly = append(hourly, sqlc.ServerHistoryRollup{
+			Address:    address,
+			BucketSize: 3600,
+			Bucket:     pgtype.Timestamptz{Time: t, Valid: true},
+			Samples:    60,
+			AvgPlayers: float32(players),
+		})
+	}
+	return hourly
+}
+
+func TestForecastAccuracy(t *testing.T) {
+	from := time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)
+	now := from.Add(model.ForecastWeeks * 7 * 24 * time.Hour)
+	history := syntheticHistory("a", from, model.ForecastWeeks+1)
+	split := model.ForecastWeeks * 7 * 24
+	train, actual := history[:split], history[split:]
+
+	m := model.NewForecastModel(history, now)
+	// data after now must not be used for training
+	require.Equal(t, model.NewForecastModel(train, now), m)
+
+	// baseline that always predicts the average player count
+	mean := 0.0
+	for _, r := range train {
+		mean += float64(r.AvgPlayers)
+	}
+	mean /= float64(len(train))
+	baseline := 0.0
+	for _, r := range actual {
+		baseline += math.Abs(mean - float64(r.AvgPlayers))
+	}
+	baseline /= float64(len(actual))
+
+	mae := m.MeanAbsoluteError(actual)
+	require.Less(t, mae, 2.0)
+	require.Less(t, mae, baseline/2)
+
+	models := model.NewForecastModels(history, now)
+	require.Len(t, models, 1)
+	require.Equal(t, m, models["a"])
+}
+
+func TestForecast(t *testing.T) {
+	from := time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)
+	// monday 10:30, the server is usually busy in the evening
+	now := from.Add(model.ForecastWeeks*7*24*time.Hour + 34*time.Hour + 30*time.Minute)
+	m := model.NewForecastModel(syntheticHistory("a", from, model.ForecastWeeks+1), now)
+
+	points := m.Forecast(now, 20, model.MaxForecastHours)
+	require.Len(t, points, model.MaxForecastHours)
+	require.Equal(t, now.Truncate(time.Hour).Add(time.Hour), points[0].Time)
+
+	// the current player count only affects the near future
+	usual, ok := m.Usual(points[0].Time)
+	require.True(t, ok)
+	require.Greater(t, points[0].Players, usual+5)
+	usual, ok = m.Usual(points[len(points)-1].Time)
+	require.True(t, ok)
+	require.InDelta(t, usual, points[len(points)-1].Players, 0.1)
+
+	busy, ok := m.BusySoon(now, 2, model.MaxForecastHours)
+	require.True(t, ok)
+	require.Equal(t, 15, busy.Hour())
+	_, ok = m.BusySoon(now, 2, 1)
+	require.False(t, ok)
+	_, ok = m.BusySoon(now, 20, model.MaxForecastHours)
+	require.False(t, ok)
+
+	// no history
+	empty := model.NewForecastModel(nil, now)
+	require.Empty(t, empty.Forecast(now, 3, model.MaxForecastHours))
+	_, ok = empty.BusySoon(now, 0, model.MaxForecastHours)
+	require.False(t, ok)
+	require.Contains(t, model.Forecast{Address: "a"}.String(), "not enough history")
+	require.Contains(t, model.Forecast{Address: "a"}.Format(i18n.New("de")), "nicht genug Verlauf")
+}
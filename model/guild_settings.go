@@ -0,0 +1,87 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+// GuildSettings are the preferences of a guild that apply to all of its channels.
+type GuildSettings struct {
+	GuildID  discord.GuildID
+	Location *time.Location
+	// members with this role may manage the bot like administrators, 0 if only administrators may manage it
+	ManagerRoleID discord.RoleID
+	// language of the bot, empty for the preferred language of the guild
+	Language discord.Language
+}
+
+func DefaultGuildSettings(guildID discord.GuildID) GuildSettings {
+	return GuildSettings{
+		GuildID:  guildID,
+		Location: time.UTC,
+	}
+}
+
+func NewGuildSettingsFromSQLC(row sqlc.GuildSetting) (GuildSettings, error) {
+	loc, err := time.LoadLocation(row.Timezone)
+	if err != nil {
+		return GuildSettings{}, fmt.Errorf("invalid time zone of guild %d: %w", row.GuildID, err)
+	}
+	settings := GuildSettings{
+		GuildID:  discord.GuildID(row.GuildID),
+		Location: loc,
+	}
+	if row.ManagerRoleID != nil {
+		settings.ManagerRoleID = discord.RoleID(*row.ManagerRoleID)
+	}
+	if row.Language != nil {
+		settings.Language = discord.Language(*row.Language)
+	}
+	return settings, nil
+}
+
+func (s *GuildSettings) ToSetSQLC() sqlc.SetGuildSettingsParams {
+	return sqlc.SetGuildSettingsParams{
+		GuildID:       int64(s.GuildID),
+		Timezone:      s.Location.String(),
+		ManagerRoleID: snowflakePtr(int64(s.ManagerRoleID)),
+		Language:      languagePtr(s.Language),
+	}
+}
+
+// IsManager returns true in case the member has the bot manager role of the guild.
+func (s *GuildSettings) IsManager(member *discord.Member) bool {
+	if member == nil || !s.ManagerRoleID.IsValid() {
+		return false
+	}
+	return slices.Contains(member.RoleIDs, s.ManagerRoleID)
+}
+
+func (s GuildSettings) String() string {
+	return s.Format(i18n.English)
+}
+
+func (s GuildSettings) Format(l i18n.Localizer) string {
+	managers := l.T("guild_settings.admins_only")
+	if s.ManagerRoleID.IsValid() {
+		managers = l.T("guild_settings.admins_and", s.ManagerRoleID.Mention())
+	}
+	language := l.T("guild_settings.guild_language")
+	if s.Language != "" {
+		language = fmt.Sprintf("`%s`", s.Language)
+	}
+	return l.T("guild_settings.format", s.Location, managers, language) + "\n"
+}
+
+func languagePtr(l discord.Language) *string {
+	if l == "" {
+		return nil
+	}
+	s := string(l)
+	return &s
+}
@@ -0,0 +1,50 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuildSettingsIsManager(t *testing.T) {
+	member := &discord.Member{RoleIDs: []discord.RoleID{1, 2}}
+
+	settings := model.DefaultGuildSettings(42)
+	require.False(t, settings.IsManager(member), "no manager role configured")
+	require.Contains(t, settings.String(), "administrators only")
+
+	settings.ManagerRoleID = 2
+	require.True(t, settings.IsManager(member))
+	require.False(t, settings.IsManager(&discord.Member{RoleIDs: []discord.RoleID{3}}))
+	require.False(t, settings.IsManager(nil))
+	require.Contains(t, settings.String(), "<@&2>")
+}
+
+func TestGuildSettingsSQLC(t *testing.T) {
+	settings, err := model.NewGuildSettingsFromSQLC(sqlc.GuildSetting{GuildID: 42, Timezone: "Europe/Berlin"})
+	require.NoError(t, err)
+	require.False(t, settings.ManagerRoleID.IsValid())
+	require.Nil(t, settings.ToSetSQLC().ManagerRoleID)
+
+	settings.ManagerRoleID = 7
+	params := settings.ToSetSQLC()
+	require.NotNil(t, params.ManagerRoleID)
+	require.Equal(t, int64(7), *params.ManagerRoleID)
+}
+
+func TestGuildSettingsLanguage(t *testing.T) {
+	settings, err := model.NewGuildSettingsFromSQLC(sqlc.GuildSetting{GuildID: 42, Timezone: "UTC"})
+	require.NoError(t, err)
+	require.Empty(t, settings.Language)
+	require.Nil(t, settings.ToSetSQLC().Language)
+	require.Contains(t, settings.String(), "language of this Discord server")
+
+	settings.Language = discord.German
+	params := settings.ToSetSQLC()
+	require.NotNil(t, params.Language)
+	require.Equal(t, "de", *params.Language)
+	require.Contains(t, settings.String(), "`de`")
+}
@@ -0,0 +1,183 @@
+package model
+
+import (
+	"path"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+	"github.com/jxsl13/twstatus-bot/utils"
+)
+
+// MapChangeSubscription notifies a user once a tracked server changes
+// to a map that matches the pattern.
+// The pattern is either a map name or a glob pattern like ctf*, see path.Match.
+type MapChangeSubscription struct {
+	MessageUserTarget
+	Address string
+	Pattern string
+	DM      bool // send a direct message instead of mentioning the user in the channel
+}
+
+func (m *MapChangeSubscription) ToAddSQLC() sqlc.AddMapChangeSubscriptionParams {
+	return sqlc.AddMapChangeSubscriptionParams{
+		GuildID:   int64(m.GuildID),
+		ChannelID: int64(m.ChannelID),
+		MessageID: int64(m.MessageID),
+		UserID:    int64(m.UserID),
+		Pattern:   m.Pattern,
+		Dm:        m.DM,
+	}
+}
+
+func (m *MapChangeSubscription) ToRemoveSQLC() sqlc.RemoveMapChangeSubscriptionParams {
+	return sqlc.RemoveMapChangeSubscriptionParams{
+		MessageID: int64(m.MessageID),
+		UserID:    int64(m.UserID),
+		Pattern:   m.Pattern,
+	}
+}
+
+func (m MapChangeSubscription) String() string {
+	return m.Format(i18n.English)
+}
+
+func (m MapChangeSubscription) Format(l i18n.Localizer) string {
+	via := l.T("map_subscription.mention")
+	if m.DM {
+		via = l.T("map_subscription.dm")
+	}
+	return l.T("map_subscription.format", m.Pattern, m.Address, via)
+}
+
+type MapChangeSubscriptions []MapChangeSubscription
+
+func (m MapChangeSubscriptions) String() string {
+	return m.Format(i18n.English)
+}
+
+func (m MapChangeSubscriptions) Format(l i18n.Localizer) string {
+	if len(m) == 0 {
+		return l.T("map_subscriptions.none")
+	}
+	var sb strings.Builder
+	sb.Grow(len(m) * 64)
+	for _, s := range m {
+		sb.WriteString(s.Format(l))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// ValidateMapPattern returns an error in case the pattern is malformed.
+func ValidateMapPattern(pattern string) error {
+	if pattern == "" {
+		return i18n.NewError("error.empty_map_pattern")
+	}
+	_, err := path.Match(strings.ToLower(pattern), "")
+	if err != nil {
+		return i18n.WrapError(err, "error.invalid_map_pattern", pattern, err)
+	}
+	return nil
+}
+
+// MatchMapPattern matches the map name case insensitively against the pattern.
+// Malformed patterns never match.
+func MatchMapPattern(pattern, mapName string) bool {
+	ok, err := path.Match(strings.ToLower(pattern), strings.ToLower(mapName))
+	return err == nil && ok
+}
+
+// MapChange is a tracked server that changed its map.
+type MapChange struct {
+	Address string
+	Name    string
+	Map     string
+}
+
+func (m MapChange) String() string {
+	return m.Format(i18n.English)
+}
+
+func (m MapChange) Format(l i18n.Localizer) string {
+	return l.T("map_change.played", m.Map, m.Name, m.Address)
+}
+
+// MapChangeNotification is a single matching subscription for a map change.
+type MapChangeNotification struct {
+	MapChange
+	MessageUserTarget
+	// notification message of the channel that needs to be replaced
+	PrevMessageID discord.MessageID
+	DM            bool
+}
+
+// DirectMessage is a private message that is sent to a single user.
+type DirectMessage struct {
+	UserID  discord.UserID
+	Content string
+}
+
+// MergeMapChangeNotifications adds the map changes of the notifications to the
+// channel notification messages which mention the subscribed users.
+// Subscriptions that prefer a direct message are returned as one direct message per user
+// in the language of the guild of each subscription.
+func MergeMapChangeNotifications(
+	messages []PlayerCountNotificationMessage,
+	notifications []MapChangeNotification,
+	localizer func(discord.GuildID) i18n.Localizer,
+) (
+	[]PlayerCountNotificationMessage,
+	[]DirectMessage,
+) {
+	if len(notifications) == 0 {
+		return messages, nil
+	}
+
+	idx := make(map[ChannelTarget]int, len(messages))
+	for i, m := range messages {
+		idx[m.ChannelTarget] = i
+	}
+
+	var (
+		dmOrder []discord.UserID
+		dms     = make(map[discord.UserID][]string)
+	)
+	for _, n := range notifications {
+		if n.DM {
+			if _, ok := dms[n.UserID]; !ok {
+				dmOrder = append(dmOrder, n.UserID)
+			}
+			dms[n.UserID] = append(dms[n.UserID], n.MapChange.Format(localizer(n.GuildID)))
+			continue
+		}
+
+		i, ok := idx[n.ChannelTarget]
+		if !ok {
+			i = len(messages)
+			idx[n.ChannelTarget] = i
+			messages = append(messages, PlayerCountNotificationMessage{
+				ChannelTarget: n.ChannelTarget,
+				PrevMessageID: n.PrevMessageID,
+			})
+		}
+		m := &messages[i]
+		m.UserIDs = append(m.UserIDs, n.UserID)
+		m.MapChanges = append(m.MapChanges, n.MapChange)
+	}
+
+	for i := range messages {
+		messages[i].UserIDs = utils.Unique(messages[i].UserIDs)
+		messages[i].MapChanges = utils.Unique(messages[i].MapChanges)
+	}
+
+	result := make([]DirectMessage, 0, len(dms))
+	for _, userID := range dmOrder {
+		result = append(result, DirectMessage{
+			UserID:  userID,
+			Content: strings.Join(utils.Unique(dms[userID]), "\n"),
+		})
+	}
+	return messages, result
+}
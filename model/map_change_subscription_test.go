@@ -0,0 +1,71 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchMapPattern(t *testing.T) {
+	table := []struct {
+		pattern string
+		mapName string
+		match   bool
+	}{
+		{"ctf5", "ctf5", true},
+		{"CTF5", "ctf5", true},
+		{"ctf*", "ctf_duel", true},
+		{"ctf?", "ctf5", true},
+		{"ctf?", "ctf10", false},
+		{"dm*", "ctf5", false},
+		{"[", "[", false}, // malformed
+	}
+
+	for _, tc := range table {
+		require.Equalf(t, tc.match, model.MatchMapPattern(tc.pattern, tc.mapName), "%s -> %s", tc.pattern, tc.mapName)
+	}
+
+	require.NoError(t, model.ValidateMapPattern("ctf*"))
+	require.Error(t, model.ValidateMapPattern("["))
+	require.Error(t, model.ValidateMapPattern(""))
+}
+
+func TestMergeMapChangeNotifications(t *testing.T) {
+	channel := model.ChannelTarget{GuildID: 1, ChannelID: 2}
+	existing := []model.PlayerCountNotificationMessage{
+		{ChannelTarget: channel, PrevMessageID: 4, UserIDs: []discord.UserID{5}},
+	}
+
+	ctf5 := model.MapChange{Address: "127.0.0.1:8303", Name: "srv", Map: "ctf5"}
+	notifications := []model.MapChangeNotification{
+		{
+			MapChange:         ctf5,
+			MessageUserTarget: model.MessageUserTarget{MessageTarget: model.MessageTarget{ChannelTarget: channel, MessageID: 3}, UserID: 5},
+			PrevMessageID:     4,
+		},
+		{
+			MapChange:         ctf5,
+			MessageUserTarget: model.MessageUserTarget{MessageTarget: model.MessageTarget{ChannelTarget: channel, MessageID: 3}, UserID: 6},
+			PrevMessageID:     4,
+		},
+		{
+			MapChange:         ctf5,
+			MessageUserTarget: model.MessageUserTarget{MessageTarget: model.MessageTarget{ChannelTarget: channel, MessageID: 3}, UserID: 7},
+			DM:                true,
+		},
+	}
+
+	german := func(discord.GuildID) i18n.Localizer { return i18n.New("de") }
+	msgs, dms := model.MergeMapChangeNotifications(existing, notifications, german)
+	require.Len(t, msgs, 1)
+	require.Equal(t, []discord.UserID{5, 6}, msgs[0].UserIDs)
+	require.Equal(t, []model.MapChange{ctf5}, msgs[0].MapChanges)
+	require.Equal(t, ctf5.String()+"\n<@5> <@6> ", msgs[0].Format(i18n.English))
+
+	require.Len(t, dms, 1)
+	require.Equal(t, discord.UserID(7), dms[0].UserID)
+	require.Equal(t, "🗺️ `ctf5` wird jetzt auf **srv** (`127.0.0.1:8303`) gespielt", dms[0].Content)
+}
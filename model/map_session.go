@@ -0,0 +1,201 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/markdown"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+// MapSession is a time range in which a map was played on a tracked server.
+type MapSession struct {
+	Address   string
+	Map       string
+	StartedAt time.Time
+	// zero while the map is played
+	EndedAt     time.Time
+	PeakPlayers int
+	// accumulated playtime of all players
+	PlayerTime time.Duration
+}
+
+func NewMapSessionFromSQLC(row sqlc.ListMapSessionsRow) MapSession {
+	return MapSession{
+		Address:     row.Address,
+		Map:         row.Map,
+		StartedAt:   row.StartedAt.Time,
+		EndedAt:     row.EndedAt.Time,
+		PeakPlayers: int(row.PeakPlayers),
+		PlayerTime:  time.Duration(row.PlayerSeconds) * time.Second,
+	}
+}
+
+func (s *MapSession) Active() bool {
+	return s.EndedAt.IsZero()
+}
+
+func (s MapSession) String() string {
+	return s.Format(i18n.English)
+}
+
+func (s MapSession) Format(l i18n.Localizer) string {
+	if s.Active() {
+		return l.T("maps.active_session",
+			markdown.Escape(s.Map),
+			s.StartedAt.Unix(),
+			s.PeakPlayers,
+		)
+	}
+	return l.T("maps.session",
+		markdown.Escape(s.Map),
+		s.StartedAt.Unix(),
+		s.EndedAt.Unix(),
+		formatDuration(s.EndedAt.Sub(s.StartedAt)),
+		s.PeakPlayers,
+	)
+}
+
+type MapSessions []MapSession
+
+func (s MapSessions) String() string {
+	return s.Format(i18n.English)
+}
+
+func (s MapSessions) Format(l i18n.Localizer) string {
+	if len(s) == 0 {
+		return l.T("maps.none")
+	}
+	var sb strings.Builder
+	sb.Grow(len(s) * 96)
+	for _, session := range s {
+		sb.WriteString(session.Format(l))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// NewMapSessionChanges returns the current map of every server whose map changed or that went
+// online or offline. The map of offline servers is empty.
+// Servers that are tracked in multiple channels are only taken into account once.
+func NewMapSessionChanges(changes map[MessageTarget]ChangedServerStatus) []MapSession {
+	var (
+		result = make([]MapSession, 0, len(changes))
+		seen   = make(map[string]bool, len(changes))
+	)
+	for _, c := range changes {
+		address, m := c.Curr.Address, c.Curr.Map
+		if c.Offline || address == "" {
+			address, m = c.Prev.Address, ""
+		}
+		if seen[address] {
+			continue
+		}
+		seen[address] = true
+
+		if !c.Offline && c.Prev.Address != "" && c.Prev.Map == c.Curr.Map {
+			continue
+		}
+		result = append(result, MapSession{
+			Address: address,
+			Map:     m,
+		})
+	}
+
+	sortMapSessions(result)
+	return result
+}
+
+// NewMapSessionPlayers returns the current map and player count of all tracked servers that have players.
+// Spectators and bots are not counted.
+func NewMapSessionPlayers(servers map[MessageTarget]ServerStatus) []MapSession {
+	var (
+		result = make([]MapSession, 0, len(servers))
+		seen   = make(map[string]bool, len(servers))
+	)
+	for _, server := range servers {
+		if seen[server.Address] {
+			continue
+		}
+		seen[server.Address] = true
+
+		players := 0
+		for _, c := range server.Clients {
+			if !c.IsSpectator() && !c.IsBot() {
+				players++
+			}
+		}
+		if players == 0 {
+			continue
+		}
+		result = append(result, MapSession{
+			Address:     server.Address,
+			Map:         server.Map,
+			PeakPlayers: players,
+		})
+	}
+
+	sortMapSessions(result)
+	return result
+}
+
+func sortMapSessions(sessions []MapSession) {
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].Address < sessions[j].Address
+	})
+}
+
+// MapPopularity is the accumulated playtime of a map on a server.
+type MapPopularity struct {
+	Map         string
+	Sessions    int
+	PeakPlayers int
+	Duration    time.Duration
+	PlayerTime  time.Duration
+}
+
+func NewMapPopularityFromSQLC(row sqlc.GetPopularMapsRow) MapPopularity {
+	return MapPopularity{
+		Map:         row.Map,
+		Sessions:    int(row.Sessions),
+		PeakPlayers: int(row.PeakPlayers),
+		Duration:    time.Duration(row.Seconds) * time.Second,
+		PlayerTime:  time.Duration(row.PlayerSeconds) * time.Second,
+	}
+}
+
+func (p MapPopularity) String() string {
+	return p.Format(i18n.English)
+}
+
+func (p MapPopularity) Format(l i18n.Localizer) string {
+	return l.T("maps.popularity",
+		markdown.Escape(p.Map),
+		int(p.PlayerTime/time.Minute),
+		formatDuration(p.Duration),
+		p.Sessions,
+		p.PeakPlayers,
+	)
+}
+
+// MapPopularities is sorted by player time in descending order.
+type MapPopularities []MapPopularity
+
+func (p MapPopularities) String() string {
+	return p.Format(i18n.English)
+}
+
+func (p MapPopularities) Format(l i18n.Localizer) string {
+	if len(p) == 0 {
+		return l.T("maps.none")
+	}
+	var sb strings.Builder
+	sb.Grow(len(p) * 96)
+	for i, m := range p {
+		sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, m.Format(l)))
+	}
+	return sb.String()
+}
@@ -0,0 +1,69 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapSessions(t *testing.T) {
+	spec := int16(-1)
+	server := func(address, m string, clients ...model.ClientStatus) model.ServerStatus {
+		s := model.ServerStatus{Address: address, Map: m}
+		for _, c := range clients {
+			s.AddClientStatus(c)
+		}
+		return s
+	}
+	var (
+		alice = model.ClientStatus{Name: "alice", IsPlayer: true}
+		bob   = model.ClientStatus{Name: "bob", IsPlayer: true}
+		carol = model.ClientStatus{Name: "carol", Team: &spec}
+		bot   = model.ClientStatus{Name: "bot", IsPlayer: false, Score: 0}
+	)
+
+	target := func(messageID int) model.MessageTarget {
+		return model.MessageTarget{MessageID: discord.MessageID(1000 + messageID)}
+	}
+
+	changes := map[model.MessageTarget]model.ChangedServerStatus{
+		// tracked in two channels
+		target(1): {
+			Prev: server("1.1.1.1:8303", "dm1", alice),
+			Curr: server("1.1.1.1:8303", "ctf5", alice),
+		},
+		target(2): {
+			Prev: server("1.1.1.1:8303", "dm1", alice),
+			Curr: server("1.1.1.1:8303", "ctf5", alice),
+		},
+		target(3): {
+			Prev:    server("2.2.2.2:8303", "dm2"),
+			Offline: true,
+		},
+		target(4): {
+			Curr: server("3.3.3.3:8303", "dm6"),
+		},
+		// only the players changed
+		target(5): {
+			Prev: server("4.4.4.4:8303", "dm7"),
+			Curr: server("4.4.4.4:8303", "dm7", bob),
+		},
+	}
+
+	require.Equal(t, []model.MapSession{
+		{Address: "1.1.1.1:8303", Map: "ctf5"},
+		{Address: "2.2.2.2:8303", Map: ""},
+		{Address: "3.3.3.3:8303", Map: "dm6"},
+	}, model.NewMapSessionChanges(changes))
+
+	servers := map[model.MessageTarget]model.ServerStatus{
+		target(1): server("1.1.1.1:8303", "ctf5", alice, bob, carol, bot),
+		target(2): server("1.1.1.1:8303", "ctf5", alice, bob, carol, bot),
+		target(3): server("3.3.3.3:8303", "dm6", carol),
+	}
+	require.Equal(t, []model.MapSession{
+		{Address: "1.1.1.1:8303", Map: "ctf5", PeakPlayers: 2},
+	}, model.NewMapSessionPlayers(servers))
+}
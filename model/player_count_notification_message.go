@@ -4,11 +4,15 @@ import (
 	"strings"
 
 	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
 	"github.com/jxsl13/twstatus-bot/sqlc"
 	"github.com/jxsl13/twstatus-bot/utils"
 )
 
-func NewPlayerCountNotificationMessages(rows []sqlc.GetPlayerCountNotificationMessagesRow) []PlayerCountNotificationMessage {
+func NewPlayerCountNotificationMessages(
+	rows []sqlc.GetPlayerCountNotificationMessagesRow,
+	roleRows []sqlc.GetPlayerCountRoleNotificationMessagesRow,
+) []PlayerCountNotificationMessage {
 
 	resultMap := make(map[ChannelTarget]PlayerCountNotificationMessage, len(rows)/10)
 	for _, row := range rows {
@@ -25,31 +29,46 @@ func NewPlayerCountNotificationMessages(rows []sqlc.GetPlayerCountNotificationMe
 			UserID: discord.UserID(row.UserID),
 		}
 
-		mt := MessageThreshold{
-			MessageID: discord.MessageID(row.ReqMessageID),
-			Threshold: int(row.Threshold),
-		}
-
 		if n, ok := resultMap[target]; ok {
 			n.UserIDs = append(n.UserIDs, discord.UserID(row.UserID))
-			n.RemoveMessageReactions = append(n.RemoveMessageReactions, mt)
-			n.RemoveUserMessageReactions = append(n.RemoveUserMessageReactions, usm)
+			n.RemoveRequests = append(n.RemoveRequests, usm)
 			resultMap[target] = n
 		} else {
 			resultMap[target] = PlayerCountNotificationMessage{
-				ChannelTarget:              target,
-				PrevMessageID:              discord.MessageID(row.PrevMessageID),
-				UserIDs:                    []discord.UserID{discord.UserID(row.UserID)},
-				RemoveMessageReactions:     []MessageThreshold{mt},
-				RemoveUserMessageReactions: []UserMessageThreshold{usm},
+				ChannelTarget:  target,
+				PrevMessageID:  discord.MessageID(row.PrevMessageID),
+				UserIDs:        []discord.UserID{discord.UserID(row.UserID)},
+				RemoveRequests: []UserMessageThreshold{usm},
+			}
+		}
+	}
+
+	// role notifications share the same notification message per channel
+	for _, row := range roleRows {
+		target := ChannelTarget{
+			GuildID:   discord.GuildID(row.GuildID),
+			ChannelID: discord.ChannelID(row.ChannelID),
+		}
+
+		n, ok := resultMap[target]
+		if !ok {
+			n = PlayerCountNotificationMessage{
+				ChannelTarget: target,
+				PrevMessageID: discord.MessageID(row.PrevMessageID),
 			}
 		}
+		n.RoleIDs = append(n.RoleIDs, discord.RoleID(row.RoleID))
+		n.NotifiedRoles = append(n.NotifiedRoles, MessageRoleID{
+			MessageID: discord.MessageID(row.ReqMessageID),
+			RoleID:    discord.RoleID(row.RoleID),
+		})
+		resultMap[target] = n
 	}
 
 	result := make([]PlayerCountNotificationMessage, 0, len(resultMap))
 	for _, v := range resultMap {
 		v.UserIDs = utils.Unique(v.UserIDs)
-		v.RemoveMessageReactions = utils.Unique(v.RemoveMessageReactions)
+		v.RoleIDs = utils.Unique(v.RoleIDs)
 		result = append(result, v)
 	}
 
@@ -62,15 +81,14 @@ type MessageUserID struct {
 	UserID    discord.UserID
 }
 
-type MessageThreshold struct {
+type MessageRoleID struct {
 	MessageID discord.MessageID
-	// corresponding emoji must be removed
-	// because we do not want to spam the channel
-	Threshold int
+	RoleID    discord.RoleID
 }
 
-func (m MessageThreshold) Reaction() discord.APIEmoji {
-	return ReactionPlayerCountNotificationReverseMap[m.Threshold]
+type MessageThreshold struct {
+	MessageID discord.MessageID
+	Threshold int
 }
 
 type UserMessageThreshold struct {
@@ -89,10 +107,17 @@ type PlayerCountNotificationMessage struct {
 	// mention these users for the current channel
 	UserIDs []discord.UserID
 
-	// for removing reactions from messages
-	RemoveMessageReactions []MessageThreshold
-	// for removing from database
-	RemoveUserMessageReactions []UserMessageThreshold
+	// mention these roles for the current channel
+	RoleIDs []discord.RoleID
+
+	// map changes that the mentioned users subscribed to
+	MapChanges []MapChange
+
+	// notification requests are only sent once and must be removed from the database
+	RemoveRequests []UserMessageThreshold
+
+	// role notifications must be marked as notified after the message was sent
+	NotifiedRoles []MessageRoleID
 }
 
 func (p *PlayerCountNotificationMessage) MessageTarget(messageID discord.MessageID) MessageTarget {
@@ -103,12 +128,30 @@ func (p *PlayerCountNotificationMessage) MessageTarget(messageID discord.Message
 }
 
 // format header
-func (p *PlayerCountNotificationMessage) Format() string {
+func (p *PlayerCountNotificationMessage) Format(l i18n.Localizer) string {
 
 	const limit = 2000
 	sb := strings.Builder{}
 	sb.Grow(limit)
 
+	for _, mc := range p.MapChanges {
+		line := mc.Format(l)
+		if sb.Len()+len(line)+1 > limit {
+			break
+		}
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+
+	for _, role := range p.RoleIDs {
+		mention := role.Mention()
+		if sb.Len()+len(mention) > limit {
+			return sb.String()
+		}
+		sb.WriteString(mention)
+		sb.WriteString(" ")
+	}
+
 	for _, user := range p.UserIDs {
 		mention := user.Mention()
 		if sb.Len()+len(mention) > limit {
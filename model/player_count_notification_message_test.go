@@ -0,0 +1,42 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayerCountNotificationMessagesWithRoles(t *testing.T) {
+	rows := []sqlc.GetPlayerCountNotificationMessagesRow{
+		{GuildID: 1, ChannelID: 2, ReqMessageID: 3, PrevMessageID: 4, UserID: 5, Threshold: 2, NumPlayers: 3},
+	}
+	roleRows := []sqlc.GetPlayerCountRoleNotificationMessagesRow{
+		{GuildID: 1, ChannelID: 2, ReqMessageID: 3, PrevMessageID: 4, RoleID: 6, Threshold: 3, NumPlayers: 3},
+		{GuildID: 1, ChannelID: 7, ReqMessageID: 8, PrevMessageID: 0, RoleID: 6, Threshold: 1, NumPlayers: 3},
+	}
+
+	msgs := model.NewPlayerCountNotificationMessages(rows, roleRows)
+	require.Len(t, msgs, 2)
+
+	byChannel := make(map[discord.ChannelID]model.PlayerCountNotificationMessage, len(msgs))
+	for _, m := range msgs {
+		byChannel[m.ChannelID] = m
+	}
+
+	shared := byChannel[2]
+	require.Equal(t, discord.MessageID(4), shared.PrevMessageID)
+	require.Equal(t, []discord.UserID{5}, shared.UserIDs)
+	require.Equal(t, []discord.RoleID{6}, shared.RoleIDs)
+	require.Equal(t, []model.MessageRoleID{{MessageID: 3, RoleID: 6}}, shared.NotifiedRoles)
+	require.Equal(t, "<@&6> <@5> ", shared.Format(i18n.English))
+
+	rolesOnly := byChannel[7]
+	require.Empty(t, rolesOnly.UserIDs)
+	require.Empty(t, rolesOnly.RemoveRequests)
+	require.Equal(t, []discord.RoleID{6}, rolesOnly.RoleIDs)
+	require.Equal(t, []model.MessageRoleID{{MessageID: 8, RoleID: 6}}, rolesOnly.NotifiedRoles)
+}
@@ -5,6 +5,9 @@ import (
 	"github.com/jxsl13/twstatus-bot/sqlc"
 )
 
+// PlayerCountNotificationThresholds are the thresholds that can be selected for player count notifications
+var PlayerCountNotificationThresholds = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
 type MessageUserTarget struct {
 	MessageTarget
 	UserID discord.UserID
@@ -36,11 +39,3 @@ func (p *PlayerCountNotificationRequest) ToRemoveSQLC() sqlc.RemovePlayerCountNo
 }
 
 type PlayerCountNotificationRequests []PlayerCountNotificationRequest
-
-type ByPlayerCountNotificationRequestIDs []PlayerCountNotificationRequest
-
-func (a ByPlayerCountNotificationRequestIDs) Len() int      { return len(a) }
-func (a ByPlayerCountNotificationRequestIDs) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
-func (a ByPlayerCountNotificationRequestIDs) Less(i, j int) bool {
-	return a[i].MessageTarget.Less(a[j].MessageTarget)
-}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+// PlayerCountRoleNotification is a guild configured notification that
+// mentions a role once the tracked server reaches the given threshold.
+// In contrast to user requests, role notifications are not removed after
+// being triggered, but are muted for the duration of the cooldown.
+type PlayerCountRoleNotification struct {
+	MessageTarget
+	Address      string
+	RoleID       discord.RoleID
+	Threshold    int
+	Cooldown     time.Duration
+	LastNotified time.Time // zero if never notified
+}
+
+func (p *PlayerCountRoleNotification) ToSetSQLC() sqlc.SetPlayerCountRoleNotificationParams {
+	return sqlc.SetPlayerCountRoleNotificationParams{
+		GuildID:   int64(p.GuildID),
+		ChannelID: int64(p.ChannelID),
+		MessageID: int64(p.MessageID),
+		RoleID:    int64(p.RoleID),
+		Threshold: int16(p.Threshold),
+		Cooldown:  int32(p.Cooldown / time.Second),
+	}
+}
+
+func (p *PlayerCountRoleNotification) ToRemoveSQLC() sqlc.RemovePlayerCountRoleNotificationParams {
+	return sqlc.RemovePlayerCountRoleNotificationParams{
+		GuildID:   int64(p.GuildID),
+		ChannelID: int64(p.ChannelID),
+		MessageID: int64(p.MessageID),
+		RoleID:    int64(p.RoleID),
+	}
+}
+
+func (p PlayerCountRoleNotification) String() string {
+	return p.Format(i18n.English)
+}
+
+func (p PlayerCountRoleNotification) Format(l i18n.Localizer) string {
+	return l.T("role_notification.format",
+		p.RoleID.Mention(),
+		p.Threshold,
+		p.Address,
+		p.Cooldown,
+	)
+}
+
+type PlayerCountRoleNotifications []PlayerCountRoleNotification
+
+func (p PlayerCountRoleNotifications) String() string {
+	return p.Format(i18n.English)
+}
+
+func (p PlayerCountRoleNotifications) Format(l i18n.Localizer) string {
+	if len(p) == 0 {
+		return l.T("role_notifications.none")
+	}
+	var sb strings.Builder
+	sb.Grow(len(p) * 64)
+	for _, n := range p {
+		sb.WriteString(n.Format(l))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,106 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/markdown"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+// PlayerMatch defines how closely a player name has to match the searched name.
+// Every match includes all closer matches.
+type PlayerMatch int16
+
+const (
+	PlayerMatchExact PlayerMatch = iota
+	PlayerMatchPrefix
+	PlayerMatchFuzzy
+)
+
+// PlayerMatches are the names of the matches that can be selected for player searches.
+var PlayerMatches = []string{"exact", "prefix", "fuzzy"}
+
+func ParsePlayerMatch(s string) (PlayerMatch, error) {
+	for i, name := range PlayerMatches {
+		if strings.EqualFold(s, name) {
+			return PlayerMatch(i), nil
+		}
+	}
+	return 0, i18n.NewError("error.invalid_match", s, strings.Join(PlayerMatches, ", "))
+}
+
+func (m PlayerMatch) String() string {
+	if m < 0 || int(m) >= len(PlayerMatches) {
+		return fmt.Sprintf("PlayerMatch(%d)", m)
+	}
+	return PlayerMatches[m]
+}
+
+// FoundPlayer is a client on any server of the master server that matches a searched name.
+type FoundPlayer struct {
+	Server ServerStatus
+	Client ClientStatus
+	Match  PlayerMatch
+}
+
+func NewFoundPlayerFromSQLC(row sqlc.FindPlayersRow) FoundPlayer {
+	return FoundPlayer{
+		Server: ServerStatus{
+			Address:   row.Address,
+			Name:      row.ServerName,
+			Map:       row.Map,
+			ScoreKind: row.ScoreKind,
+		},
+		Client: ClientStatus{
+			Name:     row.Name,
+			Clan:     row.Clan,
+			Score:    row.Score,
+			IsPlayer: row.IsPlayer,
+			Team:     row.Team,
+		},
+		Match: PlayerMatch(row.Match),
+	}
+}
+
+func (p FoundPlayer) String() string {
+	return p.Format(i18n.English)
+}
+
+func (p FoundPlayer) Format(l i18n.Localizer) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("**%s**", markdown.Escape(p.Client.Name)))
+	if p.Client.Clan != "" {
+		sb.WriteString(fmt.Sprintf(" (%s)", markdown.Escape(p.Client.Clan)))
+	}
+	sb.WriteString(" ")
+	sb.WriteString(l.T("find_player.server", p.Server.NameToQuickJoinUrl(), markdown.Escape(p.Server.Map)))
+
+	switch {
+	case p.Client.IsSpectator():
+		sb.WriteString(", " + l.T("find_player.spectating"))
+	case p.Client.IsBot():
+		sb.WriteString(", " + l.T("find_player.bot"))
+	default:
+		if score := p.Client.FormatScore(p.Server.ScoreKind); score != "" {
+			sb.WriteString(", " + l.T("find_player.score", score))
+		}
+		if p.Client.Team != nil {
+			sb.WriteString(", " + l.T("find_player.team", *p.Client.Team))
+		}
+	}
+	return sb.String()
+}
+
+// FoundPlayers is sorted by the closeness of the match.
+type FoundPlayers []FoundPlayer
+
+// Lines returns one line per found player.
+func (p FoundPlayers) Lines(l i18n.Localizer) []string {
+	lines := make([]string, 0, len(p))
+	for _, player := range p {
+		lines = append(lines, player.Format(l))
+	}
+	return lines
+}
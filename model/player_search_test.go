@@ -0,0 +1,48 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFoundPlayer(t *testing.T) {
+	match, err := model.ParsePlayerMatch("Prefix")
+	require.NoError(t, err)
+	require.Equal(t, model.PlayerMatchPrefix, match)
+	_, err = model.ParsePlayerMatch("similar")
+	require.Error(t, err)
+
+	team := int16(1)
+	players := model.FoundPlayers{
+		model.NewFoundPlayerFromSQLC(sqlc.FindPlayersRow{
+			Address:    "127.0.0.1:8303",
+			ServerName: "DDNet GER",
+			Map:        "Multeasymap",
+			ScoreKind:  "points",
+			Name:       "nameless_tee",
+			Clan:       "clan",
+			Score:      42,
+			IsPlayer:   true,
+			Team:       &team,
+			Match:      int16(model.PlayerMatchExact),
+		}),
+		model.NewFoundPlayerFromSQLC(sqlc.FindPlayersRow{
+			Address:    "127.0.0.1:8304",
+			ServerName: "DDNet GER 2",
+			Map:        "Multeasymap",
+			ScoreKind:  "time",
+			Name:       "nameless",
+			Score:      -1,
+			Match:      int16(model.PlayerMatchFuzzy),
+		}),
+	}
+
+	lines := players.Lines(i18n.English)
+	require.Len(t, lines, 2)
+	require.Equal(t, `**nameless\_tee** (clan) on [DDNet GER](https://ddnet.org/connect-to/?addr=127.0.0.1%3A8303), map Multeasymap, score 42, team 1`, lines[0])
+	require.Contains(t, lines[1], "spectating")
+}
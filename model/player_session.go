@@ -0,0 +1,172 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/markdown"
+)
+
+// PlayerSession is a time range in which a player was connected to a tracked server.
+type PlayerSession struct {
+	Address  string
+	Name     string
+	Clan     string
+	JoinedAt time.Time
+	// zero while the player is online
+	LeftAt time.Time
+}
+
+func (s *PlayerSession) Online() bool {
+	return s.LeftAt.IsZero()
+}
+
+// Duration returns the duration of the session, sessions of online players end now.
+func (s *PlayerSession) Duration(now time.Time) time.Duration {
+	end := s.LeftAt
+	if s.Online() {
+		end = now
+	}
+	return end.Sub(s.JoinedAt)
+}
+
+func (s PlayerSession) String() string {
+	return s.Format(i18n.English)
+}
+
+func (s PlayerSession) Format(l i18n.Localizer) string {
+	player := markdown.Escape(s.Name)
+	if s.Clan != "" {
+		player = fmt.Sprintf("%s [%s]", player, markdown.Escape(s.Clan))
+	}
+
+	if s.Online() {
+		return l.T("session.online", player, s.Address, s.JoinedAt.Unix())
+	}
+	return l.T("session.offline",
+		player,
+		s.Address,
+		s.JoinedAt.Unix(),
+		s.LeftAt.Unix(),
+		formatDuration(s.LeftAt.Sub(s.JoinedAt)),
+	)
+}
+
+type PlayerSessions []PlayerSession
+
+func (s PlayerSessions) String() string {
+	return s.Format(i18n.English)
+}
+
+func (s PlayerSessions) Format(l i18n.Localizer) string {
+	if len(s) == 0 {
+		return l.T("session.none")
+	}
+	var sb strings.Builder
+	sb.Grow(len(s) * 96)
+	for _, session := range s {
+		sb.WriteString(session.Format(l))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// PlayerSessionChanges are the players that joined or left tracked servers.
+type PlayerSessionChanges struct {
+	Joined []PlayerSession
+	Left   []PlayerSession
+}
+
+// NewPlayerSessionChanges derives joined and left players from the client lists of changed servers.
+// Servers that are tracked in multiple channels are only taken into account once.
+// Bots are ignored.
+func NewPlayerSessionChanges(changes map[MessageTarget]ChangedServerStatus) PlayerSessionChanges {
+	var (
+		result = PlayerSessionChanges{
+			Joined: make([]PlayerSession, 0, len(changes)),
+			Left:   make([]PlayerSession, 0, len(changes)),
+		}
+		seen = make(map[string]bool, len(changes))
+	)
+
+	for _, c := range changes {
+		address := c.Curr.Address
+		if c.Offline || address == "" {
+			address = c.Prev.Address
+		}
+		if seen[address] {
+			continue
+		}
+		seen[address] = true
+
+		prev := sessionClients(c.Prev.Clients)
+		curr := map[string]ClientStatus{}
+		if !c.Offline {
+			curr = sessionClients(c.Curr.Clients)
+		}
+
+		for name, client := range curr {
+			if _, ok := prev[name]; !ok {
+				result.Joined = append(result.Joined, PlayerSession{
+					Address: address,
+					Name:    name,
+					Clan:    client.Clan,
+				})
+			}
+		}
+		for name, client := range prev {
+			if _, ok := curr[name]; !ok {
+				result.Left = append(result.Left, PlayerSession{
+					Address: address,
+					Name:    name,
+					Clan:    client.Clan,
+				})
+			}
+		}
+	}
+
+	sortSessions(result.Joined)
+	sortSessions(result.Left)
+	return result
+}
+
+// sessionClients returns all non-bot clients by name.
+func sessionClients(clients ClientStatusList) map[string]ClientStatus {
+	result := make(map[string]ClientStatus, len(clients))
+	for _, c := range clients {
+		if c.IsBot() {
+			continue
+		}
+		result[c.Name] = c
+	}
+	return result
+}
+
+func sortSessions(sessions []PlayerSession) {
+	sort.Slice(sessions, func(i, j int) bool {
+		if sessions[i].Address != sessions[j].Address {
+			return sessions[i].Address < sessions[j].Address
+		}
+		return sessions[i].Name < sessions[j].Name
+	})
+}
+
+// formatDuration formats a duration with a precision of minutes.
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d < time.Minute {
+		return "<1m"
+	}
+	h, m := int(d.Hours()), int(d.Minutes())%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dh%dm", h, m)
+	}
+}
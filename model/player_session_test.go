@@ -0,0 +1,72 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPlayerSessionChanges(t *testing.T) {
+	spec := int16(-1)
+	server := func(address string, clients ...model.ClientStatus) model.ServerStatus {
+		s := model.ServerStatus{Address: address}
+		for _, c := range clients {
+			s.AddClientStatus(c)
+		}
+		return s
+	}
+	var (
+		alice = model.ClientStatus{Name: "alice", Clan: "a", IsPlayer: true}
+		bob   = model.ClientStatus{Name: "bob", IsPlayer: true}
+		carol = model.ClientStatus{Name: "carol", Team: &spec}
+		bot   = model.ClientStatus{Name: "bot", IsPlayer: false, Score: 0}
+	)
+
+	target := func(messageID int) model.MessageTarget {
+		return model.MessageTarget{MessageID: discord.MessageID(1000 + messageID)}
+	}
+
+	changes := map[model.MessageTarget]model.ChangedServerStatus{
+		// tracked in two channels
+		target(1): {
+			Prev: server("1.1.1.1:8303", alice, bot),
+			Curr: server("1.1.1.1:8303", bob, carol, bot),
+		},
+		target(2): {
+			Prev: server("1.1.1.1:8303", alice, bot),
+			Curr: server("1.1.1.1:8303", bob, carol, bot),
+		},
+		target(3): {
+			Prev:    server("2.2.2.2:8303", alice),
+			Offline: true,
+		},
+		target(4): {
+			Curr: server("3.3.3.3:8303", alice),
+		},
+	}
+
+	sc := model.NewPlayerSessionChanges(changes)
+	require.Equal(t, []model.PlayerSession{
+		{Address: "1.1.1.1:8303", Name: "bob"},
+		{Address: "1.1.1.1:8303", Name: "carol"},
+		{Address: "3.3.3.3:8303", Name: "alice", Clan: "a"},
+	}, sc.Joined)
+	require.Equal(t, []model.PlayerSession{
+		{Address: "1.1.1.1:8303", Name: "alice", Clan: "a"},
+		{Address: "2.2.2.2:8303", Name: "alice", Clan: "a"},
+	}, sc.Left)
+
+	now := time.Now()
+	online := model.PlayerSession{JoinedAt: now.Add(-time.Hour)}
+	require.True(t, online.Online())
+	require.Equal(t, time.Hour, online.Duration(now))
+	require.Contains(t, online.String(), "online since")
+	require.Contains(t, online.Format(i18n.New("pt-BR")), "online desde")
+
+	left := model.PlayerSession{Name: "alice", JoinedAt: now.Add(-90 * time.Minute), LeftAt: now}
+	require.Contains(t, left.String(), "(1h30m)")
+}
@@ -0,0 +1,164 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/markdown"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+// Playtime is the time a player spent on a server.
+type Playtime struct {
+	Address  string
+	Name     string
+	Clan     string
+	Duration time.Duration
+}
+
+// NewPlaytimes credits the given duration to every player of the tracked servers.
+// Spectators and bots are excluded and servers that are tracked in multiple channels
+// are only taken into account once.
+func NewPlaytimes(servers map[MessageTarget]ServerStatus, d time.Duration) []Playtime {
+	type key struct {
+		address, name, clan string
+	}
+	var (
+		seen   = make(map[key]bool, len(servers)*8)
+		result = make([]Playtime, 0, len(servers)*8)
+	)
+	for _, server := range servers {
+		for _, c := range server.Clients {
+			if c.IsSpectator() || c.IsBot() {
+				continue
+			}
+			k := key{server.Address, c.Name, c.Clan}
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+			result = append(result, Playtime{
+				Address:  server.Address,
+				Name:     c.Name,
+				Clan:     c.Clan,
+				Duration: d,
+			})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Address != b.Address {
+			return a.Address < b.Address
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Clan < b.Clan
+	})
+	return result
+}
+
+// PlaytimeInterval returns the duration that is credited to all players of a poll.
+// The duration since the last poll is capped in order not to credit downtimes of the bot.
+func PlaytimeInterval(last, now time.Time, pollInterval time.Duration) time.Duration {
+	elapsed := now.Sub(last)
+	if last.IsZero() || elapsed <= 0 || elapsed > 2*pollInterval {
+		return pollInterval
+	}
+	return elapsed
+}
+
+// PlaytimePeriods are the periods that can be selected for leaderboards.
+var PlaytimePeriods = []string{"week", "last-week", "month", "last-month", "all-time"}
+
+// ParsePlaytimePeriod returns the time range [since, until) of a period.
+// Weeks start on monday and periods are aligned to UTC days.
+func ParsePlaytimePeriod(period string, now time.Time) (since, until time.Time, err error) {
+	now = now.UTC()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	week := WeekStart(now, time.UTC)
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	switch period {
+	case "week":
+		return week, tomorrow, nil
+	case "last-week":
+		return week.AddDate(0, 0, -7), week, nil
+	case "month":
+		return month, tomorrow, nil
+	case "last-month":
+		return month.AddDate(0, -1, 0), month, nil
+	case "all-time":
+		return time.Time{}, tomorrow, nil
+	default:
+		return time.Time{}, time.Time{}, i18n.NewError("error.invalid_period", period, strings.Join(PlaytimePeriods, ", "))
+	}
+}
+
+// PlaytimeEntry is a single line of a leaderboard. Name is empty for clan leaderboards.
+type PlaytimeEntry struct {
+	Name     string
+	Clan     string
+	Players  int
+	Duration time.Duration
+}
+
+func NewPlayerPlaytimeEntryFromSQLC(row sqlc.GetPlayerPlaytimeLeaderboardRow) PlaytimeEntry {
+	return PlaytimeEntry{
+		Name:     row.Name,
+		Clan:     row.Clan,
+		Players:  1,
+		Duration: time.Duration(row.Seconds) * time.Second,
+	}
+}
+
+func NewClanPlaytimeEntryFromSQLC(row sqlc.GetClanPlaytimeLeaderboardRow) PlaytimeEntry {
+	return PlaytimeEntry{
+		Clan:     row.Clan,
+		Players:  int(row.Players),
+		Duration: time.Duration(row.Seconds) * time.Second,
+	}
+}
+
+func (e PlaytimeEntry) String() string {
+	return e.Format(i18n.English)
+}
+
+func (e PlaytimeEntry) Format(l i18n.Localizer) string {
+	if e.Name == "" {
+		return l.T("leaderboard.clan_entry", markdown.Escape(e.Clan), e.Players, formatDuration(e.Duration))
+	}
+	if e.Clan == "" {
+		return fmt.Sprintf("**%s**: %s", markdown.Escape(e.Name), formatDuration(e.Duration))
+	}
+	return fmt.Sprintf("**%s** [%s]: %s", markdown.Escape(e.Name), markdown.Escape(e.Clan), formatDuration(e.Duration))
+}
+
+// PlaytimeLeaderboard is sorted by playtime in descending order.
+type PlaytimeLeaderboard struct {
+	Title   string
+	Entries []PlaytimeEntry
+}
+
+func (lb PlaytimeLeaderboard) String() string {
+	return lb.Format(i18n.English)
+}
+
+func (lb PlaytimeLeaderboard) Format(l i18n.Localizer) string {
+	var sb strings.Builder
+	sb.Grow(len(lb.Entries)*64 + len(lb.Title) + 8)
+	sb.WriteString(fmt.Sprintf("**%s**\n", lb.Title))
+	if len(lb.Entries) == 0 {
+		sb.WriteString(l.T("leaderboard.no_playtime"))
+		sb.WriteString("\n")
+		return sb.String()
+	}
+	for i, e := range lb.Entries {
+		sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, e.Format(l)))
+	}
+	return sb.String()
+}
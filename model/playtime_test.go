@@ -0,0 +1,61 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPlaytimes(t *testing.T) {
+	spec := int16(-1)
+	server := model.ServerStatus{Address: "1.1.1.1:8303"}
+	for _, c := range []model.ClientStatus{
+		{Name: "alice", Clan: "a", IsPlayer: true},
+		{Name: "bob", IsPlayer: true, Score: -1},
+		{Name: "carol", IsPlayer: true, Team: &spec}, // spectator
+		{Name: "dave", IsPlayer: false, Score: -1},   // spectator
+		{Name: "bot", IsPlayer: false, Score: 10},
+	} {
+		server.AddClientStatus(c)
+	}
+
+	servers := map[model.MessageTarget]model.ServerStatus{
+		{MessageID: discord.MessageID(1)}: server,
+		// same server tracked in another channel
+		{MessageID: discord.MessageID(2)}: server,
+	}
+
+	playtimes := model.NewPlaytimes(servers, 16*time.Second)
+	require.Equal(t, []model.Playtime{
+		{Address: "1.1.1.1:8303", Name: "alice", Clan: "a", Duration: 16 * time.Second},
+		{Address: "1.1.1.1:8303", Name: "bob", Duration: 16 * time.Second},
+	}, playtimes)
+}
+
+func TestPlaytimeInterval(t *testing.T) {
+	now := time.Now()
+	poll := 16 * time.Second
+	require.Equal(t, poll, model.PlaytimeInterval(time.Time{}, now, poll))
+	require.Equal(t, 20*time.Second, model.PlaytimeInterval(now.Add(-20*time.Second), now, poll))
+	require.Equal(t, poll, model.PlaytimeInterval(now.Add(-time.Hour), now, poll))
+}
+
+func TestParsePlaytimePeriod(t *testing.T) {
+	now := time.Date(2024, 3, 13, 15, 0, 0, 0, time.UTC) // wednesday
+
+	since, until, err := model.ParsePlaytimePeriod("week", now)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC), since)
+	require.Equal(t, time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC), until)
+
+	since, until, err = model.ParsePlaytimePeriod("last-month", now)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), since)
+	require.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), until)
+
+	_, _, err = model.ParsePlaytimePeriod("year", now)
+	require.Error(t, err)
+}
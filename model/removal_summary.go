@@ -0,0 +1,97 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+// RemovalSummary counts everything that is deleted together with a channel or a guild.
+type RemovalSummary struct {
+	Channels             int
+	Trackings            int
+	NotificationRequests int
+	RoleNotifications    int
+	StatusAlerts         int
+	MapSubscriptions     int
+	FlagMappings         int
+	WeeklyHeatmaps       int
+	WeeklyDigests        int
+	TopServersMessages   int
+	Webhooks             int
+	DiscoveryRules       int
+}
+
+func NewChannelRemovalSummaryFromSQLC(row sqlc.GetChannelRemovalSummaryRow) RemovalSummary {
+	return RemovalSummary{
+		Trackings:            int(row.Trackings),
+		NotificationRequests: int(row.NotificationRequests),
+		RoleNotifications:    int(row.RoleNotifications),
+		StatusAlerts:         int(row.StatusAlerts),
+		MapSubscriptions:     int(row.MapSubscriptions),
+		FlagMappings:         int(row.FlagMappings),
+		WeeklyHeatmaps:       int(row.WeeklyHeatmaps),
+		WeeklyDigests:        int(row.WeeklyDigests),
+		TopServersMessages:   int(row.TopServersMessages),
+	}
+}
+
+func NewGuildRemovalSummaryFromSQLC(row sqlc.GetGuildRemovalSummaryRow) RemovalSummary {
+	return RemovalSummary{
+		Channels:             int(row.Channels),
+		Trackings:            int(row.Trackings),
+		NotificationRequests: int(row.NotificationRequests),
+		RoleNotifications:    int(row.RoleNotifications),
+		StatusAlerts:         int(row.StatusAlerts),
+		MapSubscriptions:     int(row.MapSubscriptions),
+		FlagMappings:         int(row.FlagMappings),
+		WeeklyHeatmaps:       int(row.WeeklyHeatmaps),
+		WeeklyDigests:        int(row.WeeklyDigests),
+		TopServersMessages:   int(row.TopServersMessages),
+		Webhooks:             int(row.Webhooks),
+		DiscoveryRules:       int(row.DiscoveryRules),
+	}
+}
+
+func (r RemovalSummary) String() string {
+	return r.Format(i18n.English)
+}
+
+// Format lists everything that is deleted, one line per kind, kinds without entries are omitted.
+func (r RemovalSummary) Format(l i18n.Localizer) string {
+	counts := []struct {
+		n   int
+		key string
+	}{
+		{r.Channels, "channels"},
+		{r.Trackings, "trackings"},
+		{r.NotificationRequests, "notification_requests"},
+		{r.RoleNotifications, "role_notifications"},
+		{r.StatusAlerts, "status_alerts"},
+		{r.MapSubscriptions, "map_subscriptions"},
+		{r.FlagMappings, "flag_mappings"},
+		{r.WeeklyHeatmaps, "weekly_heatmaps"},
+		{r.WeeklyDigests, "weekly_digests"},
+		{r.TopServersMessages, "top_servers_messages"},
+		{r.Webhooks, "webhooks"},
+		{r.DiscoveryRules, "discovery_rules"},
+	}
+
+	var sb strings.Builder
+	for _, c := range counts {
+		switch c.n {
+		case 0:
+			continue
+		case 1:
+			sb.WriteString(fmt.Sprintf("- %s\n", l.T("summary."+c.key+".one")))
+		default:
+			sb.WriteString(fmt.Sprintf("- %s\n", l.T("summary."+c.key+".other", c.n)))
+		}
+	}
+	if sb.Len() == 0 {
+		return fmt.Sprintf("- %s\n", l.T("summary.nothing"))
+	}
+	return sb.String()
+}
@@ -0,0 +1,31 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemovalSummary(t *testing.T) {
+	require.Equal(t, "- nothing else\n", model.RemovalSummary{}.String())
+
+	channel := model.NewChannelRemovalSummaryFromSQLC(sqlc.GetChannelRemovalSummaryRow{
+		Trackings:            3,
+		NotificationRequests: 1,
+		FlagMappings:         2,
+	})
+	require.Equal(t, "- 3 tracked servers\n- 1 player count notification request\n- 2 flag mappings\n", channel.String())
+
+	guild := model.NewGuildRemovalSummaryFromSQLC(sqlc.GetGuildRemovalSummaryRow{
+		Channels:       1,
+		Trackings:      1,
+		Webhooks:       2,
+		DiscoveryRules: 1,
+	})
+	require.Equal(t, "- 1 channel\n- 1 tracked server\n- 2 webhooks\n- 1 discovery rule\n", guild.String())
+	require.Equal(t, "- 1 Kanal\n- 1 verfolgter Server\n- 2 Webhooks\n- 1 Entdeckungsregel\n", guild.Format(i18n.New("de")))
+	require.Equal(t, "- больше ничего\n", model.RemovalSummary{}.Format(i18n.New("ru")))
+}
@@ -44,7 +44,9 @@ type Server struct {
 	MaxClients   int16
 	MaxPlayers   int16
 	ScoreKind    string
-	Clients      ClientList // serialized as json into database
+	// region like eu:de, empty if unknown
+	Location string
+	Clients  ClientList // serialized as json into database
 }
 
 func (s *Server) Merge(s2 Server) {
@@ -77,6 +79,7 @@ func (s *Server) Merge(s2 Server) {
 	s.MaxClients = utils.MergeValue(s.MaxClients, s2.MaxClients)
 	s.MaxPlayers = utils.MergeValue(s.MaxPlayers, s2.MaxPlayers)
 	s.ScoreKind = utils.MergeValue(s.ScoreKind, s2.ScoreKind)
+	s.Location = utils.MergeValue(s.Location, s2.Location)
 
 	if len(s.Clients) < len(s2.Clients) {
 		// merging is only necessary when a player connects to the server
@@ -112,6 +115,7 @@ func (s *Server) ToSQLC(knownFlags map[int16]bool) (sqlc.InsertActiveServersPara
 		MaxClients:   s.MaxClients,
 		MaxPlayers:   s.MaxPlayers,
 		ScoreKind:    s.ScoreKind,
+		Location:     s.Location,
 	}
 
 	clients := s.Clients.ToSQLC(srv.Address, knownFlags)
@@ -297,6 +301,10 @@ func NewServersFromDTO(servers []servers.Server) ([]Server, error) {
 		info := server.Info
 
 		scoreKind := ScoreKindFromDTO((*string)(info.ClientScoreKind), info.GameType)
+		location := ""
+		if server.Location != nil {
+			location = string(*server.Location)
+		}
 		clients := make([]Client, 0, len(info.Clients))
 		for _, client := range info.Clients {
 			clients = append(clients, ClientFromDTO(client))
@@ -335,6 +343,7 @@ func NewServersFromDTO(servers []servers.Server) ([]Server, error) {
 				MaxClients:   info.MaxClients,
 				MaxPlayers:   info.MaxPlayers,
 				ScoreKind:    scoreKind,
+				Location:     location,
 				Clients:      clients,
 			}
 			duplicates[addr] = append(duplicates[addr], server)
@@ -0,0 +1,137 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
+)
+
+type ServerEventType string
+
+const (
+	ServerEventOnline             ServerEventType = "server.online"
+	ServerEventOffline            ServerEventType = "server.offline"
+	ServerEventMapChanged         ServerEventType = "server.map_changed"
+	ServerEventPlayerCountChanged ServerEventType = "server.player_count_changed"
+	ServerEventThresholdReached   ServerEventType = "server.threshold_reached"
+)
+
+var ServerEventTypes = []ServerEventType{
+	ServerEventOnline,
+	ServerEventOffline,
+	ServerEventMapChanged,
+	ServerEventPlayerCountChanged,
+	ServerEventThresholdReached,
+}
+
+// ParseServerEventTypes parses a comma separated list of event types.
+// An empty string is parsed as an empty list which matches all events.
+func ParseServerEventTypes(s string) ([]ServerEventType, error) {
+	result := make([]ServerEventType, 0, len(ServerEventTypes))
+	if strings.TrimSpace(s) == "" {
+		return result, nil
+	}
+
+	for _, part := range strings.Split(s, ",") {
+		et := ServerEventType(strings.ToLower(strings.TrimSpace(part)))
+		found := false
+		for _, known := range ServerEventTypes {
+			if et == known {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, i18n.NewError("error.unknown_event", part, ServerEventTypes)
+		}
+		result = append(result, et)
+	}
+	return result, nil
+}
+
+// ServerEvent is the payload that is sent to outbound webhooks.
+type ServerEvent struct {
+	Type      ServerEventType   `json:"type"`
+	Timestamp time.Time         `json:"timestamp"`
+	GuildID   discord.GuildID   `json:"guild_id"`
+	ChannelID discord.ChannelID `json:"channel_id"`
+	MessageID discord.MessageID `json:"message_id"`
+
+	Address    string `json:"address"`
+	Name       string `json:"name"`
+	Gametype   string `json:"gametype"`
+	Map        string `json:"map"`
+	NumPlayers int    `json:"num_players"`
+	MaxPlayers int    `json:"max_players"`
+
+	PrevMap        string `json:"prev_map,omitempty"`
+	PrevNumPlayers int    `json:"prev_num_players"`
+	// highest threshold that was reached, see ServerEventThresholdReached
+	Threshold int `json:"threshold,omitempty"`
+}
+
+// NewServerEvents derives all events of a changed server status.
+func NewServerEvents(c ChangedServerStatus, now time.Time) []ServerEvent {
+	base := ServerEvent{
+		Timestamp: now,
+		GuildID:   c.Target.GuildID,
+		ChannelID: c.Target.ChannelID,
+		MessageID: c.Target.MessageID,
+	}
+
+	if c.Offline {
+		e := base
+		e.Type = ServerEventOffline
+		e.Address = c.Prev.Address
+		e.Name = c.Prev.Name
+		e.Gametype = c.Prev.Gametype
+		e.Map = c.Prev.Map
+		e.MaxPlayers = int(c.Prev.MaxPlayers)
+		e.PrevMap = c.Prev.Map
+		e.PrevNumPlayers = c.Prev.NumPlayers
+		return []ServerEvent{e}
+	}
+
+	base.Address = c.Curr.Address
+	base.Name = c.Curr.Name
+	base.Gametype = c.Curr.Gametype
+	base.Map = c.Curr.Map
+	base.NumPlayers = c.Curr.NumPlayers
+	base.MaxPlayers = int(c.Curr.MaxPlayers)
+	base.PrevMap = c.Prev.Map
+	base.PrevNumPlayers = c.Prev.NumPlayers
+
+	events := make([]ServerEvent, 0, 3)
+	if c.Prev.Address == "" {
+		e := base
+		e.Type = ServerEventOnline
+		events = append(events, e)
+	} else if c.Prev.Map != c.Curr.Map {
+		e := base
+		e.Type = ServerEventMapChanged
+		events = append(events, e)
+	}
+
+	if c.Prev.NumPlayers != c.Curr.NumPlayers {
+		e := base
+		e.Type = ServerEventPlayerCountChanged
+		events = append(events, e)
+	}
+
+	threshold := 0
+	for _, t := range PlayerCountNotificationThresholds {
+		if c.Prev.NumPlayers < t && t <= c.Curr.NumPlayers {
+			threshold = t
+		}
+	}
+	if threshold > 0 {
+		e := base
+		e.Type = ServerEventThresholdReached
+		e.Threshold = threshold
+		events = append(events, e)
+	}
+
+	return events
+}
@@ -0,0 +1,50 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/stretchr/testify/require"
+)
+
+func eventTypes(events []model.ServerEvent) []model.ServerEventType {
+	result := make([]model.ServerEventType, 0, len(events))
+	for _, e := range events {
+		result = append(result, e.Type)
+	}
+	return result
+}
+
+func TestNewServerEvents(t *testing.T) {
+	now := time.Now()
+	online := model.ServerStatus{Address: "127.0.0.1:8303", Name: "srv", Map: "ctf5", NumPlayers: 2, MaxPlayers: 16}
+
+	events := model.NewServerEvents(model.ChangedServerStatus{Prev: online, Offline: true}, now)
+	require.Equal(t, []model.ServerEventType{model.ServerEventOffline}, eventTypes(events))
+	require.Equal(t, "ctf5", events[0].Map)
+
+	events = model.NewServerEvents(model.ChangedServerStatus{Curr: online}, now)
+	require.Equal(t, []model.ServerEventType{
+		model.ServerEventOnline,
+		model.ServerEventPlayerCountChanged,
+		model.ServerEventThresholdReached,
+	}, eventTypes(events))
+	require.Equal(t, 2, events[2].Threshold)
+
+	curr := online
+	curr.Map = "ctf2"
+	curr.NumPlayers = 1
+	events = model.NewServerEvents(model.ChangedServerStatus{Prev: online, Curr: curr}, now)
+	require.Equal(t, []model.ServerEventType{
+		model.ServerEventMapChanged,
+		model.ServerEventPlayerCountChanged,
+	}, eventTypes(events))
+	require.Equal(t, "ctf5", events[0].PrevMap)
+
+	types, err := model.ParseServerEventTypes("server.online, server.offline")
+	require.NoError(t, err)
+	require.Equal(t, []model.ServerEventType{model.ServerEventOnline, model.ServerEventOffline}, types)
+	_, err = model.ParseServerEventTypes("server.exploded")
+	require.Error(t, err)
+}
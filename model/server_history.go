@@ -0,0 +1,277 @@
+package model
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jxsl13/twstatus-bot/chart"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+// HistoryRanges are the time ranges that can be selected for server statistics.
+var HistoryRanges = []string{"24h", "7d", "30d"}
+
+// ParseHistoryRange parses one of the HistoryRanges.
+func ParseHistoryRange(s string) (time.Duration, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "24h":
+		return 24 * time.Hour, nil
+	case "7d":
+		return 7 * 24 * time.Hour, nil
+	case "30d":
+		return 30 * 24 * time.Hour, nil
+	default:
+		return 0, i18n.NewError("error.invalid_range", s, strings.Join(HistoryRanges, ", "))
+	}
+}
+
+// minHistoryGap is the minimum duration between two samples that is considered a gap in the data.
+const minHistoryGap = 5 * time.Minute
+
+// ServerHistorySample is either a raw sample or an aggregated bucket of samples.
+type ServerHistorySample struct {
+	Timestamp time.Time
+	// zero for raw samples, bucket size for aggregated samples
+	Duration time.Duration
+	Online   bool
+	// fraction of the time in which the server was listed on the master server
+	OnlineRatio   float64
+	NumPlayers    float64
+	NumSpectators float64
+	// empty for aggregated samples
+	Map string
+}
+
+// ServerHistory is the chronologically sorted player count history of a server.
+type ServerHistory struct {
+	Address string
+	Samples []ServerHistorySample
+}
+
+// NewServerHistoryFromSQLC merges raw samples with hourly and daily aggregates.
+// Aggregates are only used for the time before the first more detailed sample.
+func NewServerHistoryFromSQLC(
+	address string,
+	daily []sqlc.ServerHistoryRollup,
+	hourly []sqlc.ServerHistoryRollup,
+	raw []sqlc.ServerHistory,
+) ServerHistory {
+	samples := make([]ServerHistorySample, 0, len(daily)+len(hourly)+len(raw))
+
+	var rawStart time.Time
+	if len(raw) > 0 {
+		rawStart = raw[0].Timestamp.Time
+	}
+	var hourlyStart time.Time
+	if len(hourly) > 0 {
+		hourlyStart = hourly[0].Bucket.Time
+	} else {
+		hourlyStart = rawStart
+	}
+
+	appendRollups := func(rollups []sqlc.ServerHistoryRollup, until time.Time) {
+		for _, r := range rollups {
+			size := time.Duration(r.BucketSize) * time.Second
+			if !until.IsZero() && r.Bucket.Time.Add(size).After(until) {
+				break
+			}
+			samples = append(samples, ServerHistorySample{
+				Timestamp:     r.Bucket.Time,
+				Duration:      size,
+				Online:        r.OnlineSamples > 0,
+				OnlineRatio:   float64(r.OnlineSamples) / float64(max(r.Samples, 1)),
+				NumPlayers:    float64(r.AvgPlayers),
+				NumSpectators: float64(r.AvgSpectators),
+			})
+		}
+	}
+	appendRollups(daily, hourlyStart)
+	appendRollups(hourly, rawStart)
+
+	for _, r := range raw {
+		ratio := 0.0
+		if r.Online {
+			ratio = 1
+		}
+		samples = append(samples, ServerHistorySample{
+			Timestamp:     r.Timestamp.Time,
+			Online:        r.Online,
+			OnlineRatio:   ratio,
+			NumPlayers:    float64(r.NumPlayers),
+			NumSpectators: float64(r.NumSpectators),
+			Map:           r.Map,
+		})
+	}
+
+	return ServerHistory{
+		Address: address,
+		Samples: samples,
+	}
+}
+
+// gap returns the duration between two samples which is considered a gap in the data.
+// The resolution of raw samples is not known, which is why it is approximated by the
+// median distance between raw samples.
+func (h *ServerHistory) gap() time.Duration {
+	steps := make([]time.Duration, 0, len(h.Samples))
+	for i := 1; i < len(h.Samples); i++ {
+		if h.Samples[i].Duration == 0 && h.Samples[i-1].Duration == 0 {
+			steps = append(steps, h.Samples[i].Timestamp.Sub(h.Samples[i-1].Timestamp))
+		}
+	}
+	if len(steps) == 0 {
+		return minHistoryGap
+	}
+	sort.Slice(steps, func(i, j int) bool {
+		return steps[i] < steps[j]
+	})
+	return max(minHistoryGap, 3*steps[len(steps)/2])
+}
+
+// end returns the point in time at which the sample is no longer valid.
+func (s *ServerHistorySample) end(gap time.Duration) time.Time {
+	if s.Duration > 0 {
+		return s.Timestamp.Add(s.Duration)
+	}
+	return s.Timestamp.Add(gap)
+}
+
+// OfflinePeriods returns all periods in which the server was not listed on the master server.
+func (h *ServerHistory) OfflinePeriods() []chart.Span {
+	var (
+		gap     = h.gap()
+		periods = make([]chart.Span, 0, 4)
+		current *chart.Span
+	)
+	for i, s := range h.Samples {
+		if current != nil {
+			prevEnd := h.Samples[i-1].end(gap)
+			if s.Online || s.Timestamp.After(prevEnd) {
+				// back online or no data
+				current.To = minTime(s.Timestamp, prevEnd)
+				periods = append(periods, *current)
+				current = nil
+			}
+		}
+		if !s.Online && current == nil {
+			current = &chart.Span{From: s.Timestamp}
+		}
+	}
+	if current != nil {
+		current.To = h.Samples[len(h.Samples)-1].end(gap)
+		periods = append(periods, *current)
+	}
+	return periods
+}
+
+// Availability returns the fraction of the observed time between from and to in which the server was
+// listed on the master server. Gaps in the data are not part of the observed time.
+func (h *ServerHistory) Availability(from, to time.Time) (availability float64, observed time.Duration) {
+	var (
+		gap    = h.gap()
+		online float64
+	)
+	for i, s := range h.Samples {
+		start, end := s.Timestamp, s.end(gap)
+		if i+1 < len(h.Samples) {
+			end = minTime(end, h.Samples[i+1].Timestamp)
+		}
+		start, end = maxTime(start, from), minTime(end, to)
+		if !end.After(start) {
+			continue
+		}
+
+		d := end.Sub(start)
+		observed += d
+		online += float64(d) * s.OnlineRatio
+	}
+
+	if observed == 0 {
+		return 0, 0
+	}
+	return online / float64(observed), observed
+}
+
+// MapChanges returns all map changes of the server.
+// Map changes are only known for raw samples.
+func (h *ServerHistory) MapChanges() []chart.Marker {
+	var (
+		markers = make([]chart.Marker, 0, 4)
+		prevMap string
+	)
+	for _, s := range h.Samples {
+		if !s.Online || s.Map == "" {
+			continue
+		}
+		if prevMap != "" && s.Map != prevMap {
+			markers = append(markers, chart.Marker{
+				Time:  s.Timestamp,
+				Label: s.Map,
+			})
+		}
+		prevMap = s.Map
+	}
+	return markers
+}
+
+// PlayerSegments returns the player counts of the online periods of the server.
+func (h *ServerHistory) PlayerSegments() [][]chart.Point {
+	var (
+		gap      = h.gap()
+		segments = make([][]chart.Point, 0, 4)
+		current  []chart.Point
+	)
+	for i, s := range h.Samples {
+		if current != nil && (!s.Online || s.Timestamp.After(h.Samples[i-1].end(gap))) {
+			segments = append(segments, current)
+			current = nil
+		}
+		if !s.Online {
+			continue
+		}
+
+		t := s.Timestamp
+		if s.Duration > 0 {
+			// aggregated values are drawn at the center of their bucket
+			t = t.Add(s.Duration / 2)
+		}
+		current = append(current, chart.Point{Time: t, Value: s.NumPlayers})
+	}
+	if current != nil {
+		segments = append(segments, current)
+	}
+	return segments
+}
+
+// LineChart returns a chart of the player count history between from and to.
+// The legends are English, the chart font only contains ASCII glyphs.
+func (h *ServerHistory) LineChart(title string, from, to time.Time, loc *time.Location) chart.LineChart {
+	return chart.LineChart{
+		Title:        title,
+		From:         from,
+		To:           to,
+		Location:     loc,
+		Segments:     h.PlayerSegments(),
+		Markers:      h.MapChanges(),
+		Spans:        h.OfflinePeriods(),
+		MinMax:       1,
+		MarkerLegend: "map change",
+		SpanLegend:   "offline",
+	}
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
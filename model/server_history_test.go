@@ -0,0 +1,66 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerHistory(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	ts := func(d time.Duration) pgtype.Timestamptz {
+		return pgtype.Timestamptz{Time: start.Add(d), Valid: true}
+	}
+
+	hourly := []sqlc.ServerHistoryRollup{
+		{BucketSize: 3600, Bucket: ts(-2 * time.Hour), Samples: 60, OnlineSamples: 60, AvgPlayers: 4.5},
+		{BucketSize: 3600, Bucket: ts(-time.Hour), Samples: 60, OnlineSamples: 60, AvgPlayers: 6},
+		// overlaps with raw samples
+		{BucketSize: 3600, Bucket: ts(0), Samples: 10, OnlineSamples: 10, AvgPlayers: 1},
+	}
+	raw := []sqlc.ServerHistory{
+		{Timestamp: ts(0), Online: true, NumPlayers: 3, Map: "dm1"},
+		{Timestamp: ts(time.Minute), Online: true, NumPlayers: 4, Map: "dm1"},
+		{Timestamp: ts(2 * time.Minute), Online: false},
+		{Timestamp: ts(3 * time.Minute), Online: false},
+		{Timestamp: ts(4 * time.Minute), Online: true, NumPlayers: 2, Map: "dm2"},
+		{Timestamp: ts(5 * time.Minute), Online: true, NumPlayers: 2, Map: "dm2"},
+		// bot was not running
+		{Timestamp: ts(time.Hour), Online: true, NumPlayers: 8, Map: "ctf5"},
+	}
+
+	h := model.NewServerHistoryFromSQLC("127.0.0.1:8303", nil, hourly, raw)
+	require.Len(t, h.Samples, 2+len(raw))
+
+	offline := h.OfflinePeriods()
+	require.Len(t, offline, 1)
+	require.Equal(t, start.Add(2*time.Minute), offline[0].From)
+	require.Equal(t, start.Add(4*time.Minute), offline[0].To)
+
+	changes := h.MapChanges()
+	require.Len(t, changes, 2)
+	require.Equal(t, "dm2", changes[0].Label)
+	require.Equal(t, start.Add(4*time.Minute), changes[0].Time)
+	require.Equal(t, "ctf5", changes[1].Label)
+
+	lc := h.LineChart("127.0.0.1:8303 - last 24h", start.Add(-24*time.Hour), start.Add(time.Hour), time.UTC)
+	requireDrawable(t, lc.MarkerLegend)
+	requireDrawable(t, lc.SpanLegend)
+
+	segments := h.PlayerSegments()
+	require.Len(t, segments, 3)
+	require.Len(t, segments[0], 4) // two hourly aggregates and two raw samples
+	require.Equal(t, start.Add(-90*time.Minute), segments[0][0].Time)
+	require.Equal(t, 4.5, segments[0][0].Value)
+	require.Len(t, segments[1], 2)
+	require.Len(t, segments[2], 1)
+
+	_, err := model.ParseHistoryRange("7d")
+	require.NoError(t, err)
+	_, err = model.ParseHistoryRange("1y")
+	require.Error(t, err)
+}
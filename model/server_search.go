@@ -0,0 +1,162 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/markdown"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+const (
+	// ServerSearchPageSize is the number of servers per page, which is limited by the number of buttons per row.
+	ServerSearchPageSize = 5
+	// MaxFoundServers is the maximum number of servers over all pages.
+	MaxFoundServers = 100
+)
+
+// ServerSearch contains the filters of a search on all servers of the master server.
+// Empty filters match every server.
+type ServerSearch struct {
+	Name     string
+	Gametype string
+	Map      string
+	// version prefix
+	Version string
+	// region prefix like eu or eu:de
+	Region     string
+	Passworded *bool
+	MinPlayers int
+}
+
+func (s *ServerSearch) ToSQLC() sqlc.SearchServersParams {
+	return sqlc.SearchServersParams{
+		Name:       s.Name,
+		Gametype:   s.Gametype,
+		Map:        s.Map,
+		Version:    s.Version,
+		Region:     s.Region,
+		Passworded: s.Passworded,
+		MinPlayers: int64(s.MinPlayers),
+		MaxEntries: MaxFoundServers,
+	}
+}
+
+func (s ServerSearch) String() string {
+	return s.Format(i18n.English)
+}
+
+func (s ServerSearch) Format(l i18n.Localizer) string {
+	filters := make([]string, 0, 7)
+	if s.Name != "" {
+		filters = append(filters, l.T("search.name_contains", markdown.Escape(s.Name)))
+	}
+	if s.Gametype != "" {
+		filters = append(filters, l.T("search.gametype_contains", markdown.Escape(s.Gametype)))
+	}
+	if s.Map != "" {
+		filters = append(filters, l.T("search.map_contains", markdown.Escape(s.Map)))
+	}
+	if s.Version != "" {
+		filters = append(filters, l.T("search.version", markdown.Escape(s.Version)))
+	}
+	if s.Region != "" {
+		filters = append(filters, l.T("search.region", markdown.Escape(s.Region)))
+	}
+	if s.Passworded != nil {
+		if *s.Passworded {
+			filters = append(filters, l.T("search.passworded"))
+		} else {
+			filters = append(filters, l.T("search.not_passworded"))
+		}
+	}
+	if s.MinPlayers > 0 {
+		filters = append(filters, l.T("search.min_players", s.MinPlayers))
+	}
+	if len(filters) == 0 {
+		return l.T("search.all_servers")
+	}
+	return strings.Join(filters, ", ")
+}
+
+// FoundServer is a server of the master server that matches a search.
+type FoundServer struct {
+	Address    string
+	Name       string
+	Gametype   string
+	Passworded bool
+	Map        string
+	Version    string
+	Location   string
+	MaxClients int
+	MaxPlayers int
+	// spectators are not counted
+	NumPlayers int
+	NumClients int
+}
+
+func NewFoundServerFromSQLC(row sqlc.SearchServersRow) FoundServer {
+	return FoundServer{
+		Address:    row.Address,
+		Name:       row.Name,
+		Gametype:   row.Gametype,
+		Passworded: row.Passworded,
+		Map:        row.Map,
+		Version:    row.Version,
+		Location:   row.Location,
+		MaxClients: int(row.MaxClients),
+		MaxPlayers: int(row.MaxPlayers),
+		NumPlayers: int(row.NumPlayers),
+		NumClients: int(row.NumClients),
+	}
+}
+
+// ToEmbedField returns the server as a field of a search result, idx is the number of the server on its page.
+func (s *FoundServer) ToEmbedField(l i18n.Localizer, idx int) discord.EmbedField {
+	name := fmt.Sprintf("%d. %s", idx, s.Name)
+	if s.Passworded {
+		name += " 🔒"
+	}
+
+	location := s.Location
+	if location == "" {
+		location = l.T("search.unknown_region")
+	}
+	spectators := s.NumClients - s.NumPlayers
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("[%s](%s)\n", s.Address, ConnectURL(s.Address)))
+	sb.WriteString(l.T("search.players", s.NumPlayers, s.MaxPlayers))
+	if spectators > 0 {
+		sb.WriteString(fmt.Sprintf(" (+%d)", spectators))
+	}
+	sb.WriteString(" " + l.T("search.on_map", markdown.Escape(s.Map)) + "\n")
+	sb.WriteString(l.T("search.details",
+		markdown.Escape(s.Gametype),
+		markdown.Escape(s.Version),
+		location,
+	))
+
+	return discord.EmbedField{
+		Name:  name,
+		Value: sb.String(),
+	}
+}
+
+// FoundServers is sorted by the number of players in descending order.
+type FoundServers []FoundServer
+
+// NumPages returns the number of pages of the search result.
+func (f FoundServers) NumPages() int {
+	return max(1, (len(f)+ServerSearchPageSize-1)/ServerSearchPageSize)
+}
+
+// Page returns the servers of the given page, the page is clamped to the available pages.
+func (f FoundServers) Page(page int) (int, FoundServers) {
+	page = max(0, min(page, f.NumPages()-1))
+	from := page * ServerSearchPageSize
+	to := min(from+ServerSearchPageSize, len(f))
+	return page, f[from:to]
+}
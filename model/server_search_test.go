@@ -0,0 +1,53 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerSearch(t *testing.T) {
+	require.Equal(t, "all servers", model.ServerSearch{}.String())
+
+	passworded := false
+	search := model.ServerSearch{
+		Gametype:   "DDNet",
+		Region:     "eu",
+		Passworded: &passworded,
+		MinPlayers: 3,
+	}
+	require.Equal(t, "gametype contains DDNet, region eu, not passworded, at least 3 players", search.String())
+	params := search.ToSQLC()
+	require.Equal(t, int64(3), params.MinPlayers)
+	require.Equal(t, int32(model.MaxFoundServers), params.MaxEntries)
+
+	servers := make(model.FoundServers, 2*model.ServerSearchPageSize+1)
+	require.Equal(t, 3, servers.NumPages())
+	page, found := servers.Page(1)
+	require.Equal(t, 1, page)
+	require.Len(t, found, model.ServerSearchPageSize)
+	page, found = servers.Page(5)
+	require.Equal(t, 2, page)
+	require.Len(t, found, 1)
+
+	page, found = model.FoundServers{}.Page(-1)
+	require.Equal(t, 0, page)
+	require.Empty(t, found)
+
+	field := (&model.FoundServer{
+		Address:    "127.0.0.1:8303",
+		Name:       "DDNet GER",
+		Gametype:   "DDraceNetwork",
+		Passworded: true,
+		Map:        "Multeasymap",
+		Version:    "0.6.4, 18.0",
+		MaxPlayers: 64,
+		NumPlayers: 3,
+		NumClients: 4,
+	}).ToEmbedField(i18n.English, 1)
+	require.Equal(t, "1. DDNet GER 🔒", field.Name)
+	require.Contains(t, field.Value, "3/64 players (+1) on Multeasymap")
+	require.Contains(t, field.Value, "region unknown")
+}
@@ -0,0 +1,121 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+// ServerStatusAlertSetting defines who is alerted when a tracked server
+// goes offline or comes back online.
+type ServerStatusAlertSetting struct {
+	Tracking
+	AlertChannelID discord.ChannelID // 0 if no channel is alerted
+	AlertUserID    discord.UserID    // 0 if no user is alerted via direct message
+	// the server must be offline for at least this duration before an alert is sent
+	Debounce time.Duration
+}
+
+func (s *ServerStatusAlertSetting) ToSetSQLC() sqlc.SetServerStatusAlertParams {
+	return sqlc.SetServerStatusAlertParams{
+		MessageID:      int64(s.MessageID),
+		GuildID:        int64(s.GuildID),
+		ChannelID:      int64(s.ChannelID),
+		AlertChannelID: snowflakePtr(int64(s.AlertChannelID)),
+		AlertUserID:    snowflakePtr(int64(s.AlertUserID)),
+		Debounce:       int32(s.Debounce / time.Second),
+	}
+}
+
+func (s ServerStatusAlertSetting) String() string {
+	return s.Format(i18n.English)
+}
+
+func (s ServerStatusAlertSetting) Format(l i18n.Localizer) string {
+	targets := make([]string, 0, 2)
+	if s.AlertChannelID != 0 {
+		targets = append(targets, s.AlertChannelID.Mention())
+	}
+	if s.AlertUserID != 0 {
+		targets = append(targets, l.T("status_alert.dm", s.AlertUserID.Mention()))
+	}
+	return l.T("status_alert.format", s.Address, strings.Join(targets, ", "), s.Debounce)
+}
+
+type ServerStatusAlertSettings []ServerStatusAlertSetting
+
+func (s ServerStatusAlertSettings) String() string {
+	return s.Format(i18n.English)
+}
+
+func (s ServerStatusAlertSettings) Format(l i18n.Localizer) string {
+	if len(s) == 0 {
+		return l.T("status_alerts.none")
+	}
+	var sb strings.Builder
+	sb.Grow(len(s) * 64)
+	for _, a := range s {
+		sb.WriteString(a.Format(l))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// ServerStatusAlert is sent when a server has been offline for longer than
+// the debounce duration or when a server recovers after such an alert.
+type ServerStatusAlert struct {
+	ServerStatusAlertSetting
+
+	// last known state before the server went offline
+	Name       string
+	Map        string
+	NumPlayers int
+	MaxPlayers int
+
+	OfflineSince time.Time
+	Recovered    bool
+	RecoveredAt  time.Time
+}
+
+func (a *ServerStatusAlert) Downtime() time.Duration {
+	if a.Recovered {
+		return a.RecoveredAt.Sub(a.OfflineSince)
+	}
+	return time.Since(a.OfflineSince)
+}
+
+func (a *ServerStatusAlert) ToEmbed(l i18n.Localizer) discord.Embed {
+	var (
+		title               = l.T("alert.offline", a.Name)
+		color discord.Color = 0xED4245 // red
+	)
+	if a.Recovered {
+		title = l.T("alert.recovered", a.Name)
+		color = 0x57F287 // green
+	}
+
+	return discord.Embed{
+		Title: title,
+		Type:  discord.NormalEmbed,
+		Color: color,
+		Fields: []discord.EmbedField{
+			{Name: l.T("alert.address"), Value: fmt.Sprintf("`%s`", a.Address), Inline: true},
+			{Name: l.T("alert.tracking"), Value: a.MessageTarget.String(), Inline: true},
+			{Name: l.T("alert.last_map"), Value: fmt.Sprintf("`%s`", a.Map), Inline: true},
+			{Name: l.T("alert.last_players"), Value: fmt.Sprintf("%d/%d", a.NumPlayers, a.MaxPlayers), Inline: true},
+			{Name: l.T("alert.offline_since"), Value: fmt.Sprintf("<t:%d:f>", a.OfflineSince.Unix()), Inline: true},
+			{Name: l.T("alert.downtime"), Value: a.Downtime().Round(time.Second).String(), Inline: true},
+		},
+	}
+}
+
+func snowflakePtr(id int64) *int64 {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
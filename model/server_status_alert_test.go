@@ -0,0 +1,30 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerStatusAlertToEmbed(t *testing.T) {
+	offlineSince := time.Unix(1700000000, 0)
+	alert := model.ServerStatusAlert{
+		Name:         "DDNet GER1",
+		OfflineSince: offlineSince,
+		Recovered:    true,
+		RecoveredAt:  offlineSince.Add(90 * time.Second),
+	}
+
+	embed := alert.ToEmbed(i18n.English)
+	require.Equal(t, "🟢 DDNet GER1 is back online", embed.Title)
+	require.Equal(t, "Downtime", embed.Fields[len(embed.Fields)-1].Name)
+	require.Equal(t, "1m30s", embed.Fields[len(embed.Fields)-1].Value)
+
+	alert.Recovered = false
+	embed = alert.ToEmbed(i18n.New("de"))
+	require.Equal(t, "🔴 DDNet GER1 ist offline", embed.Title)
+	require.Equal(t, "Ausfallzeit", embed.Fields[len(embed.Fields)-1].Name)
+}
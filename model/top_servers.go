@@ -0,0 +1,147 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/markdown"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+const (
+	DefaultTopServers = 10
+	MaxTopServers     = 25
+	// embed description limit
+	maxTopServersLength = 4096
+)
+
+// TopServersFilter selects the most populated servers of the master server.
+type TopServersFilter struct {
+	// empty for all gametypes
+	Gametype string
+	Count    int
+}
+
+// Search returns the search of all servers with at least one player that match the filter.
+func (f TopServersFilter) Search() ServerSearch {
+	return ServerSearch{
+		Gametype:   f.Gametype,
+		MinPlayers: 1,
+	}
+}
+
+func (f TopServersFilter) Title(l i18n.Localizer) string {
+	if f.Gametype == "" {
+		return l.T("top_servers.title", f.Count)
+	}
+	return l.T("top_servers.title_gametype", f.Count, markdown.Escape(f.Gametype))
+}
+
+// TopServersTracking is a message that is kept up to date with the most populated servers.
+type TopServersTracking struct {
+	MessageTarget
+	TopServersFilter
+	// last published ranking
+	Ranking string
+}
+
+func NewTopServersTrackingFromSQLC(row sqlc.TopServersTracking) TopServersTracking {
+	return TopServersTracking{
+		MessageTarget: MessageTarget{
+			ChannelTarget: ChannelTarget{
+				GuildID:   discord.GuildID(row.GuildID),
+				ChannelID: discord.ChannelID(row.ChannelID),
+			},
+			MessageID: discord.MessageID(row.MessageID),
+		},
+		TopServersFilter: TopServersFilter{
+			Gametype: row.Gametype,
+			Count:    int(row.Count),
+		},
+		Ranking: row.Ranking,
+	}
+}
+
+func (t TopServersTracking) ToAddSQLC() sqlc.AddTopServersTrackingParams {
+	return sqlc.AddTopServersTrackingParams{
+		GuildID:   int64(t.GuildID),
+		ChannelID: int64(t.ChannelID),
+		MessageID: int64(t.MessageID),
+		Gametype:  t.Gametype,
+		Count:     int16(t.Count),
+	}
+}
+
+// TopServers are the most populated servers that match a filter.
+type TopServers struct {
+	TopServersFilter
+	Servers FoundServers
+}
+
+// NewTopServers expects the servers to be sorted by the number of players in descending order.
+func NewTopServers(filter TopServersFilter, servers FoundServers) TopServers {
+	return TopServers{
+		TopServersFilter: filter,
+		Servers:          slices.Clone(servers[:min(filter.Count, len(servers))]),
+	}
+}
+
+// Ranking is the rendered list of servers, which is why a message only needs to be edited
+// in case the ranking changed.
+func (t TopServers) Ranking() string {
+	return t.String()
+}
+
+func (t TopServers) String() string {
+	return t.Format(i18n.English)
+}
+
+func (t TopServers) Format(l i18n.Localizer) string {
+	if len(t.Servers) == 0 {
+		return l.T("top_servers.none")
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(t.Servers) * 128)
+	for idx, s := range t.Servers {
+		line := fmt.Sprintf("%d. [%s](%s) %d/%d",
+			idx+1,
+			markdown.Escape(s.Name),
+			ConnectURL(s.Address),
+			s.NumPlayers,
+			s.MaxPlayers,
+		)
+		if spectators := s.NumClients - s.NumPlayers; spectators > 0 {
+			line += fmt.Sprintf(" (+%d)", spectators)
+		}
+		if s.Passworded {
+			line += " 🔒"
+		}
+		line += " " + l.T("top_servers.on_map", markdown.Escape(s.Map)) + "\n"
+
+		if sb.Len()+len(line) > maxTopServersLength {
+			break
+		}
+		sb.WriteString(line)
+	}
+	return sb.String()
+}
+
+func (t TopServers) ToEmbed(l i18n.Localizer) discord.Embed {
+	return discord.Embed{
+		Title:       t.Title(l),
+		Description: t.Format(l),
+		Footer: &discord.EmbedFooter{
+			Text: l.T("top_servers.footer"),
+		},
+	}
+}
+
+// TopServersMessage is a top servers message whose ranking changed.
+type TopServersMessage struct {
+	MessageTarget
+	TopServers
+}
@@ -0,0 +1,48 @@
+package model_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopServers(t *testing.T) {
+	filter := model.TopServersFilter{Gametype: "DDNet", Count: 2}
+	require.Equal(t, "Top 2 DDNet servers", filter.Title(i18n.English))
+	require.Equal(t, 1, filter.Search().MinPlayers)
+
+	servers := model.FoundServers{
+		{Address: "127.0.0.1:8303", Name: "A", Map: "Kobra", MaxPlayers: 64, NumPlayers: 10, NumClients: 12},
+		{Address: "127.0.0.1:8304", Name: "B", Map: "Multeasymap", MaxPlayers: 16, NumPlayers: 5, NumClients: 5, Passworded: true},
+		{Address: "127.0.0.1:8305", Name: "C", Map: "Tutorial", MaxPlayers: 16, NumPlayers: 1, NumClients: 1},
+	}
+	top := model.NewTopServers(filter, servers)
+	require.Len(t, top.Servers, 2)
+	require.Equal(t,
+		"1. [A](https://ddnet.org/connect-to/?addr=127.0.0.1%3A8303) 10/64 (+2) on Kobra\n"+
+			"2. [B](https://ddnet.org/connect-to/?addr=127.0.0.1%3A8304) 5/16 🔒 on Multeasymap\n",
+		top.String(),
+	)
+
+	// servers below the top do not change the ranking
+	servers[2].NumPlayers = 2
+	require.Equal(t, top.Ranking(), model.NewTopServers(filter, servers).Ranking())
+
+	servers[1].NumPlayers = 6
+	require.NotEqual(t, top.Ranking(), model.NewTopServers(filter, servers).Ranking())
+
+	require.Equal(t, "no servers with players found", model.NewTopServers(filter, nil).String())
+	require.Equal(t, "Top 2 DDNet-Server", top.ToEmbed(i18n.New("de")).Title)
+
+	// the description limit of embeds must not be exceeded
+	many := make(model.FoundServers, model.MaxTopServers)
+	for i := range many {
+		many[i] = model.FoundServer{Name: strings.Repeat("n", 64), Map: strings.Repeat("m", 128)}
+	}
+	long := model.NewTopServers(model.TopServersFilter{Count: model.MaxTopServers}, many)
+	require.LessOrEqual(t, len(long.String()), 4096)
+	require.Equal(t, "Top 25 servers", long.Title(i18n.English))
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"net/url"
 	"slices"
 	"sort"
 	"strconv"
@@ -11,6 +12,7 @@ import (
 	"time"
 
 	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
 	"github.com/jxsl13/twstatus-bot/markdown"
 	"github.com/jxsl13/twstatus-bot/sqlc"
 	"github.com/jxsl13/twstatus-bot/utils"
@@ -196,8 +198,13 @@ func (s *ServerStatus) ProtocolsFromJSON(data []byte) error {
 	return nil
 }
 
+// ConnectURL returns the url that allows to join the server with the given address via the DDNet client.
+func ConnectURL(address string) string {
+	return "https://ddnet.org/connect-to/?addr=" + url.QueryEscape(address)
+}
+
 func (ss ServerStatus) NameToQuickJoinUrl() string {
-	return fmt.Sprintf("[%s](https://ddnet.org/connect-to/?addr=%s)", ss.Name, ss.Address)
+	return fmt.Sprintf("[%s](%s)", ss.Name, ConnectURL(ss.Address))
 }
 
 func (s *ServerStatus) HasV6Protocol() bool {
@@ -234,7 +241,36 @@ func (ss ServerStatus) Header() string {
 	return markdown.WrapInFat(header)
 }
 
-func (ss ServerStatus) ToEmbeds() []discord.Embed {
+// Details returns the technical details of the server that are not part of the status message.
+func (ss ServerStatus) Details(l i18n.Localizer) string {
+	mapSize := l.T("details.unknown")
+	if ss.MapSize != nil {
+		mapSize = fmt.Sprintf("%.1f KiB", float64(*ss.MapSize)/1024)
+	}
+	mapHash := l.T("details.unknown")
+	if ss.MapSha256Sum != nil {
+		mapHash = markdown.WrapInInlineCodeBlock(*ss.MapSha256Sum)
+	}
+	passworded := l.T("details.no")
+	if ss.Passworded {
+		passworded = l.T("details.yes")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("**%s**: %s\n", l.T("details.address"), markdown.WrapInInlineCodeBlock(ss.Address)))
+	sb.WriteString(fmt.Sprintf("**%s**: %s\n", l.T("details.gametype"), markdown.Escape(ss.Gametype)))
+	sb.WriteString(fmt.Sprintf("**%s**: %s\n", l.T("details.protocols"), strings.Join(ss.Protocols, ", ")))
+	sb.WriteString(fmt.Sprintf("**%s**: %s\n", l.T("details.version"), markdown.Escape(ss.Version)))
+	sb.WriteString(fmt.Sprintf("**%s**: %s (%s)\n", l.T("details.map"), markdown.Escape(ss.Map), mapSize))
+	sb.WriteString(fmt.Sprintf("**%s**: %s\n", l.T("details.map_sha256"), mapHash))
+	sb.WriteString(fmt.Sprintf("**%s**: %s\n", l.T("details.score_kind"), ss.ScoreKind))
+	sb.WriteString(fmt.Sprintf("**%s**: %s\n", l.T("details.clients"), l.T("details.clients_value", len(ss.Clients), ss.MaxClients, ss.NumPlayers, ss.MaxPlayers)))
+	sb.WriteString(fmt.Sprintf("**%s**: %s\n", l.T("details.passworded"), passworded))
+	sb.WriteString(fmt.Sprintf("**%s**: <t:%d:R>", l.T("details.updated"), ss.Timestamp.Unix()))
+	return sb.String()
+}
+
+func (ss ServerStatus) ToEmbeds(l i18n.Localizer) []discord.Embed {
 	if len(ss.Clients) == 0 {
 		return []discord.Embed{}
 	}
@@ -242,7 +278,7 @@ func (ss ServerStatus) ToEmbeds() []discord.Embed {
 	const discordEmbedsLimit = 10
 	totalTeams := ss.TotalTeams()
 	if ss.ScoreKind == "time" || totalTeams > discordEmbedsLimit || (len(ss.Spectators) == 0 && len(ss.Teams) == 1) {
-		return ss.Clients.ToEmbedList(0, ss.LongestName, ss.LongestClan, ss.ScoreKind)
+		return ss.Clients.ToEmbedList(l, 0, ss.LongestName, ss.LongestClan, ss.ScoreKind)
 	}
 
 	// scoreKind == "points"
@@ -256,18 +292,33 @@ func (ss ServerStatus) ToEmbeds() []discord.Embed {
 		team = ss.Teams[teamID]
 		color = teamColors[int(teamID)%maxTeamColors]
 
-		embeds = append(embeds, team.ToEmbedList(color, ss.LongestName, ss.LongestClan, ss.ScoreKind)...)
+		embeds = append(embeds, team.ToEmbedList(l, color, ss.LongestName, ss.LongestClan, ss.ScoreKind)...)
 	}
 
-	embeds = append(embeds, ss.Spectators.ToEmbedList(0, ss.LongestName, ss.LongestClan, ss.ScoreKind)...)
+	embeds = append(embeds, ss.Spectators.ToEmbedList(l, 0, ss.LongestName, ss.LongestClan, ss.ScoreKind)...)
 	return embeds
 }
 
+// ClientLines returns all clients in the order of the status message without any limit.
+func (ss ServerStatus) ClientLines() []string {
+	lines := make([]string, 0, len(ss.Clients))
+	ss.Clients.Iterate(ss.ScoreKind, func(_ int, client ClientStatus) bool {
+		lines = append(lines, client.Format(ss.LongestName, ss.LongestClan, ss.ScoreKind))
+		return true
+	})
+	return lines
+}
+
 func (ss ServerStatus) String() string {
+	return ss.Message(i18n.English)
+}
+
+// Message returns the status message in the legacy message format without embeds.
+func (ss ServerStatus) Message(l i18n.Localizer) string {
 	var sb strings.Builder
 
 	header := ss.Header()
-	clients := ss.Clients.Format(ss.LongestName, ss.LongestClan, ss.ScoreKind)
+	clients := ss.Clients.Format(l, ss.LongestName, ss.LongestClan, ss.ScoreKind)
 	sb.WriteString(header)
 	sb.WriteString("\n")
 	sb.WriteString(clients)
@@ -355,7 +406,7 @@ var teamColors = []discord.Color{
 }
 var maxTeamColors = len(teamColors)
 
-func (clients ClientStatusList) ToEmbedList(color discord.Color, namePadding, clanPadding int, scoreKind string) []discord.Embed {
+func (clients ClientStatusList) ToEmbedList(l i18n.Localizer, color discord.Color, namePadding, clanPadding int, scoreKind string) []discord.Embed {
 	const (
 		maxCharacters     = 6000 - 128
 		maxFieldsPerEmbed = 25
@@ -387,7 +438,7 @@ func (clients ClientStatusList) ToEmbedList(color discord.Color, namePadding, cl
 		// discord character limit
 		if characterCnt+charLen > maxCharacters {
 			embed.Fields = append(embed.Fields, discord.EmbedField{
-				Value:  fmt.Sprintf("... and %d more", len(clients)-i),
+				Value:  l.T("status.and_more", len(clients)-i),
 				Inline: false,
 			})
 
@@ -439,7 +490,7 @@ func (clients ClientStatusList) Iterate(scoreKind string, f func(idx int, client
 	}
 }
 
-func (clients ClientStatusList) Format(namePadding, clanPadding int, scoreKind string) string {
+func (clients ClientStatusList) Format(l i18n.Localizer, namePadding, clanPadding int, scoreKind string) string {
 	const maxCharacters = 2000 - 128
 
 	if len(clients) == 0 {
@@ -456,7 +507,8 @@ func (clients ClientStatusList) Format(namePadding, clanPadding int, scoreKind s
 		if sb.Len()+len(line) > maxCharacters {
 			additional := len(clients) - i
 			if additional > 0 {
-				sb.WriteString(fmt.Sprintf("... and %d more\n", len(clients)-i))
+				sb.WriteString(l.T("status.and_more", len(clients)-i))
+				sb.WriteString("\n")
 			}
 			return false
 		} else {
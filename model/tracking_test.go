@@ -1,8 +1,11 @@
 package model_test
 
 import (
+	"fmt"
 	"testing"
 
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
 	"github.com/mattn/go-runewidth"
 	"github.com/stretchr/testify/require"
 )
@@ -22,3 +25,68 @@ func TestRuneWidth(t *testing.T) {
 
 	require.Equal(t, wl, nl)
 }
+
+func TestServerStatusDetails(t *testing.T) {
+	size := int32(2048)
+	hash := "f00"
+	ss := model.ServerStatus{
+		Address:      "127.0.0.1:8303",
+		Protocols:    []string{"tw-0.6+udp", "tw-0.7+udp"},
+		Gametype:     "DDraceNetwork",
+		Map:          "Multeasymap",
+		MapSize:      &size,
+		MapSha256Sum: &hash,
+		Version:      "0.6.4, 18.0",
+		MaxClients:   64,
+		MaxPlayers:   64,
+		ScoreKind:    "time",
+	}
+	ss.AddClientStatus(model.ClientStatus{Name: "nameless tee", IsPlayer: true})
+
+	details := ss.Details(i18n.English)
+	require.Contains(t, details, "**Protocols**: tw-0.6+udp, tw-0.7+udp\n")
+	require.Contains(t, details, "**Map**: Multeasymap (2.0 KiB)\n")
+	require.Contains(t, details, "**Map SHA256**: `f00`\n")
+	require.Contains(t, details, "**Score kind**: time\n")
+	require.Contains(t, details, "**Clients**: 1/64, players: 1/64\n")
+	require.Contains(t, ss.Details(i18n.New("de")), "**Passwortgeschützt**: nein\n")
+}
+
+func TestServerStatusClientLines(t *testing.T) {
+	ss := model.ServerStatus{ScoreKind: "points"}
+	for i := 0; i < 64; i++ {
+		client := model.ClientStatus{Name: fmt.Sprintf("player %d", i), Score: int32(i), IsPlayer: true}
+		if i%8 == 0 {
+			client = model.ClientStatus{Name: fmt.Sprintf("spectator %d", i), Score: -1}
+		}
+		ss.AddClientStatus(client)
+	}
+
+	// unlike the status message the list is never truncated
+	lines := ss.ClientLines()
+	require.Len(t, lines, 64)
+	require.Contains(t, lines[0], "player 63")
+	require.Contains(t, lines[len(lines)-1], "👁️")
+}
+
+func TestServerStatusLocalized(t *testing.T) {
+	ss := model.ServerStatus{Name: "server", ScoreKind: "points"}
+	for i := 0; i < 256; i++ {
+		ss.AddClientStatus(model.ClientStatus{Name: fmt.Sprintf("player %d", i), Score: int32(i), IsPlayer: true})
+	}
+
+	de := i18n.New("de")
+	require.Contains(t, ss.String(), "more\n")
+	require.Contains(t, ss.Message(de), "weitere\n")
+
+	changed := model.ChangedServerStatus{Prev: ss, Offline: true}
+	require.Equal(t, "server [OFFLINE]", changed.Content(i18n.English))
+	require.Equal(t, "server [НЕ В СЕТИ]", changed.Content(i18n.New("ru")))
+	require.Equal(t, "server [NICHT ERREICHBAR]", changed.Content(de))
+	require.Equal(t, "server [FORA DO AR]", changed.Content(i18n.New("pt-BR")))
+}
+
+func TestConnectURL(t *testing.T) {
+	require.Equal(t, "https://ddnet.org/connect-to/?addr=127.0.0.1%3A8303", model.ConnectURL("127.0.0.1:8303"))
+	require.Equal(t, "https://ddnet.org/connect-to/?addr=%5B2001%3Adb8%3A%3A1%5D%3A8303", model.ConnectURL("[2001:db8::1]:8303"))
+}
@@ -0,0 +1,153 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jxsl13/twstatus-bot/i18n"
+)
+
+// maxOutages is the maximum number of outages that are listed in an uptime report.
+const maxOutages = 10
+
+// UptimeRange is the availability of a server during a time range.
+type UptimeRange struct {
+	Name         string
+	Duration     time.Duration
+	Availability float64
+	// time in which data was recorded, might be shorter than the duration of the range
+	Observed time.Duration
+}
+
+func (r UptimeRange) String() string {
+	return r.Format(i18n.English)
+}
+
+func (r UptimeRange) Format(l i18n.Localizer) string {
+	if r.Observed == 0 {
+		return fmt.Sprintf("**%s**: %s", r.Name, l.T("uptime.no_data"))
+	}
+	s := fmt.Sprintf("**%s**: %.2f%%", r.Name, r.Availability*100)
+	if r.Observed < r.Duration-time.Minute {
+		s += " " + l.T("uptime.observed", formatDuration(r.Observed))
+	}
+	return s
+}
+
+// Outage is a period in which a server was not listed on the master server.
+type Outage struct {
+	From time.Time
+	To   time.Time
+	// the server is still offline
+	Ongoing bool
+}
+
+func (o Outage) Duration() time.Duration {
+	return o.To.Sub(o.From)
+}
+
+func (o Outage) String() string {
+	return o.Format(i18n.English)
+}
+
+func (o Outage) Format(l i18n.Localizer) string {
+	if o.Ongoing {
+		return l.T("uptime.ongoing_outage", o.From.Unix(), formatDuration(o.Duration()))
+	}
+	return fmt.Sprintf("<t:%d:f> - <t:%d:t> (%s)", o.From.Unix(), o.To.Unix(), formatDuration(o.Duration()))
+}
+
+// UptimeReport contains the availability of a server during all HistoryRanges
+// and its most recent outages.
+type UptimeReport struct {
+	Address string
+	Ranges  []UptimeRange
+	// most recent outages first
+	Outages []Outage
+	// number of outages, including the ones that are not listed
+	NumOutages int
+}
+
+// NewUptimeReport computes the availability of the server up until now.
+// The history is expected to cover the longest of the HistoryRanges.
+func NewUptimeReport(history ServerHistory, now time.Time) UptimeReport {
+	ranges := make([]UptimeRange, 0, len(HistoryRanges))
+	longest := time.Duration(0)
+	for _, name := range HistoryRanges {
+		d, _ := ParseHistoryRange(name)
+		longest = max(longest, d)
+		availability, observed := history.Availability(now.Add(-d), now)
+		ranges = append(ranges, UptimeRange{
+			Name:         name,
+			Duration:     d,
+			Availability: availability,
+			Observed:     observed,
+		})
+	}
+
+	var (
+		since   = now.Add(-longest)
+		periods = history.OfflinePeriods()
+		outages = make([]Outage, 0, min(len(periods), maxOutages))
+		total   = 0
+	)
+	for i := len(periods) - 1; i >= 0; i-- {
+		p := periods[i]
+		if !p.To.After(since) {
+			break
+		}
+		total++
+		if len(outages) >= maxOutages {
+			continue
+		}
+
+		o := Outage{
+			From: maxTime(p.From, since),
+			To:   minTime(p.To, now),
+		}
+		// the most recent sample is offline and still valid
+		o.Ongoing = i == len(periods)-1 && !p.To.Before(now)
+		outages = append(outages, o)
+	}
+
+	return UptimeReport{
+		Address:    history.Address,
+		Ranges:     ranges,
+		Outages:    outages,
+		NumOutages: total,
+	}
+}
+
+func (r UptimeReport) String() string {
+	return r.Format(i18n.English)
+}
+
+func (r UptimeReport) Format(l i18n.Localizer) string {
+	var sb strings.Builder
+	sb.Grow(256 + len(r.Outages)*64)
+	sb.WriteString(l.T("uptime.title", r.Address))
+	sb.WriteString("\n")
+	for _, ur := range r.Ranges {
+		sb.WriteString(ur.Format(l))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(l.T("uptime.outages"))
+	sb.WriteString("\n")
+	if len(r.Outages) == 0 {
+		sb.WriteString(l.T("uptime.no_outages"))
+		sb.WriteString("\n")
+		return sb.String()
+	}
+	for _, o := range r.Outages {
+		sb.WriteString(o.Format(l))
+		sb.WriteString("\n")
+	}
+	if r.NumOutages > len(r.Outages) {
+		sb.WriteString(l.T("status.and_more", r.NumOutages-len(r.Outages)))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
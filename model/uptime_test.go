@@ -0,0 +1,72 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUptimeReport(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	ts := func(d time.Duration) pgtype.Timestamptz {
+		return pgtype.Timestamptz{Time: now.Add(d), Valid: true}
+	}
+
+	// half of the day before the raw samples the server was online
+	hourly := make([]sqlc.ServerHistoryRollup, 0, 24)
+	for h := -24; h < 0; h++ {
+		online := int32(0)
+		if h%2 == 0 {
+			online = 60
+		}
+		hourly = append(hourly, sqlc.ServerHistoryRollup{
+			BucketSize:    3600,
+			Bucket:        ts(time.Duration(h-1) * time.Hour),
+			Samples:       60,
+			OnlineSamples: online,
+		})
+	}
+
+	// one minute resolution during the last hour, ten minutes offline
+	raw := make([]sqlc.ServerHistory, 0, 60)
+	for m := -60; m < 0; m++ {
+		raw = append(raw, sqlc.ServerHistory{
+			Timestamp: ts(time.Duration(m) * time.Minute),
+			Online:    m < -30 || m >= -20,
+		})
+	}
+
+	h := model.NewServerHistoryFromSQLC("127.0.0.1:8303", nil, hourly, raw)
+	availability, observed := h.Availability(now.Add(-time.Hour), now)
+	require.Equal(t, time.Hour, observed)
+	require.InDelta(t, 50.0/60.0, availability, 0.0001)
+
+	report := model.NewUptimeReport(h, now)
+	require.Len(t, report.Ranges, len(model.HistoryRanges))
+	day := report.Ranges[0]
+	require.Equal(t, 24*time.Hour, day.Observed)
+	require.InDelta(t, (11*60.0+50.0)/(24*60.0), day.Availability, 0.0001)
+
+	// there is no data older than 25 hours
+	month := report.Ranges[2]
+	require.Equal(t, 25*time.Hour, month.Observed)
+
+	// every other hour and once during the last hour
+	require.Equal(t, 13, report.NumOutages)
+	require.Len(t, report.Outages, 10)
+	latest := report.Outages[0]
+	require.False(t, latest.Ongoing)
+	require.Equal(t, now.Add(-30*time.Minute), latest.From)
+	require.Equal(t, 10*time.Minute, latest.Duration())
+
+	// server goes offline at the end
+	raw = append(raw, sqlc.ServerHistory{Timestamp: ts(0), Online: false})
+	h = model.NewServerHistoryFromSQLC("127.0.0.1:8303", nil, nil, raw)
+	report = model.NewUptimeReport(h, now)
+	require.True(t, report.Outages[0].Ongoing)
+	require.Equal(t, now, report.Outages[0].From)
+}
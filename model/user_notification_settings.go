@@ -0,0 +1,179 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata" // the minimal docker image does not contain any time zone data
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+// Weekdays is a bitmask of weekdays, bit 0 is sunday.
+type Weekdays uint8
+
+const AllWeekdays Weekdays = 1<<7 - 1
+
+var weekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// ParseWeekdays parses a comma separated list of weekdays like "mon,tue,fri".
+// "all", "weekdays" and "weekend" are supported as well.
+func ParseWeekdays(s string) (Weekdays, error) {
+	var result Weekdays
+	for _, part := range strings.Split(strings.ToLower(s), ",") {
+		part = strings.TrimSpace(part)
+		switch part {
+		case "all":
+			result |= AllWeekdays
+			continue
+		case "weekdays":
+			result |= 0b0111110
+			continue
+		case "weekend":
+			result |= 0b1000001
+			continue
+		}
+
+		found := false
+		for i, name := range weekdayNames {
+			if len(part) >= 3 && strings.HasPrefix(part, name) {
+				result |= 1 << i
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, i18n.NewError("error.invalid_weekday", part, strings.Join(weekdayNames, ", "))
+		}
+	}
+	return result, nil
+}
+
+func (w Weekdays) Contains(day time.Weekday) bool {
+	return w&(1<<day) != 0
+}
+
+func (w Weekdays) String() string {
+	if w&AllWeekdays == AllWeekdays {
+		return "all"
+	}
+	days := make([]string, 0, len(weekdayNames))
+	for i, name := range weekdayNames {
+		if w.Contains(time.Weekday(i)) {
+			days = append(days, name)
+		}
+	}
+	return strings.Join(days, ",")
+}
+
+// ParseClock parses a time of day in the format 15:04 and returns the minutes after midnight.
+func ParseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, i18n.NewError("error.invalid_time_of_day", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// UserNotificationSettings are the personal notification preferences of a user.
+type UserNotificationSettings struct {
+	UserID   discord.UserID
+	Location *time.Location
+
+	// quiet hours in minutes after midnight in the user's time zone.
+	// Quiet hours are disabled in case start and end are equal.
+	// The end may be smaller than the start for quiet hours that span midnight.
+	QuietStart int
+	QuietEnd   int
+	// days on which the quiet hours start
+	QuietDays Weekdays
+}
+
+func DefaultUserNotificationSettings(userID discord.UserID) UserNotificationSettings {
+	return UserNotificationSettings{
+		UserID:    userID,
+		Location:  time.UTC,
+		QuietDays: AllWeekdays,
+	}
+}
+
+func NewUserNotificationSettingsFromSQLC(row sqlc.UserNotificationSetting) (UserNotificationSettings, error) {
+	loc, err := time.LoadLocation(row.Timezone)
+	if err != nil {
+		return UserNotificationSettings{}, fmt.Errorf("invalid time zone of user %d: %w", row.UserID, err)
+	}
+	return UserNotificationSettings{
+		UserID:     discord.UserID(row.UserID),
+		Location:   loc,
+		QuietStart: int(row.QuietStart),
+		QuietEnd:   int(row.QuietEnd),
+		QuietDays:  Weekdays(row.QuietDays),
+	}, nil
+}
+
+func (s *UserNotificationSettings) ToSetSQLC() sqlc.SetUserNotificationSettingsParams {
+	return sqlc.SetUserNotificationSettingsParams{
+		UserID:     int64(s.UserID),
+		Timezone:   s.Location.String(),
+		QuietStart: int16(s.QuietStart),
+		QuietEnd:   int16(s.QuietEnd),
+		QuietDays:  int16(s.QuietDays),
+	}
+}
+
+func (s *UserNotificationSettings) HasQuietHours() bool {
+	return s.QuietStart != s.QuietEnd && s.QuietDays != 0
+}
+
+// IsQuiet returns true in case the user does not want to be notified at the given point in time.
+func (s *UserNotificationSettings) IsQuiet(t time.Time) bool {
+	if !s.HasQuietHours() {
+		return false
+	}
+
+	local := t.In(s.Location)
+	minute := local.Hour()*60 + local.Minute()
+
+	if s.QuietStart < s.QuietEnd {
+		return s.QuietDays.Contains(local.Weekday()) &&
+			s.QuietStart <= minute && minute < s.QuietEnd
+	}
+
+	// quiet hours span midnight
+	if minute >= s.QuietStart {
+		return s.QuietDays.Contains(local.Weekday())
+	}
+	if minute < s.QuietEnd {
+		// started on the previous day
+		return s.QuietDays.Contains((local.Weekday() + 6) % 7)
+	}
+	return false
+}
+
+func (s UserNotificationSettings) String() string {
+	return s.Format(i18n.English)
+}
+
+func (s UserNotificationSettings) Format(l i18n.Localizer) string {
+	var sb strings.Builder
+	sb.WriteString(l.T("notification_settings.time_zone", s.Location))
+	sb.WriteString("\n")
+	if !s.HasQuietHours() {
+		sb.WriteString(l.T("notification_settings.quiet_hours_disabled"))
+		sb.WriteString("\n")
+		return sb.String()
+	}
+	sb.WriteString(l.T("notification_settings.quiet_hours",
+		formatClock(s.QuietStart),
+		formatClock(s.QuietEnd),
+		s.QuietDays,
+	))
+	sb.WriteString("\n")
+	return sb.String()
+}
@@ -0,0 +1,57 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserNotificationSettingsIsQuiet(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	days, err := model.ParseWeekdays("fri,sat")
+	require.NoError(t, err)
+
+	start, err := model.ParseClock("22:00")
+	require.NoError(t, err)
+	end, err := model.ParseClock("07:30")
+	require.NoError(t, err)
+
+	s := model.UserNotificationSettings{
+		Location:   berlin,
+		QuietStart: start,
+		QuietEnd:   end,
+		QuietDays:  days,
+	}
+
+	table := []struct {
+		local string
+		quiet bool
+	}{
+		{"2024-03-01 21:59", false}, // friday
+		{"2024-03-01 22:00", true},
+		{"2024-03-02 03:00", true}, // started on friday
+		{"2024-03-02 07:30", false},
+		{"2024-03-02 23:00", true},  // saturday
+		{"2024-03-03 06:00", true},  // started on saturday
+		{"2024-03-03 23:00", false}, // sunday
+		{"2024-03-04 03:00", false}, // started on sunday
+	}
+
+	for _, tc := range table {
+		local, err := time.ParseInLocation("2006-01-02 15:04", tc.local, berlin)
+		require.NoError(t, err)
+		require.Equalf(t, tc.quiet, s.IsQuiet(local.UTC()), "%s", tc.local)
+	}
+
+	disabled := model.DefaultUserNotificationSettings(0)
+	require.False(t, disabled.IsQuiet(time.Now()))
+
+	_, err = model.ParseWeekdays("someday")
+	require.Error(t, err)
+	_, err = model.ParseClock("25:00")
+	require.Error(t, err)
+}
@@ -0,0 +1,141 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+// Webhook is an outbound http endpoint of a guild that receives signed server events.
+type Webhook struct {
+	ID      int64
+	GuildID discord.GuildID
+	URL     string
+	Secret  string
+	// empty for all events
+	Events []ServerEventType
+
+	// zero value if no event was delivered yet
+	LastDelivery WebhookDelivery
+}
+
+func NewWebhookFromSQLC(w sqlc.Webhook) Webhook {
+	return Webhook{
+		ID:      w.WebhookID,
+		GuildID: discord.GuildID(w.GuildID),
+		URL:     w.Url,
+		Secret:  w.Secret,
+		Events:  eventTypesFromStrings(w.Events),
+	}
+}
+
+func (w *Webhook) ToAddSQLC() sqlc.AddWebhookParams {
+	events := make([]string, 0, len(w.Events))
+	for _, e := range w.Events {
+		events = append(events, string(e))
+	}
+	return sqlc.AddWebhookParams{
+		GuildID: int64(w.GuildID),
+		Url:     w.URL,
+		Secret:  w.Secret,
+		Events:  events,
+	}
+}
+
+// Accepts returns true in case the webhook is subscribed to the given event type.
+func (w *Webhook) Accepts(t ServerEventType) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (w Webhook) String() string {
+	return w.Format(i18n.English)
+}
+
+func (w Webhook) Format(l i18n.Localizer) string {
+	events := l.T("webhook.all_events")
+	if len(w.Events) > 0 {
+		events = fmt.Sprint(w.Events)
+	}
+
+	last := l.T("webhook.never_delivered")
+	if !w.LastDelivery.DeliveredAt.IsZero() {
+		last = w.LastDelivery.Format(l)
+	}
+	return fmt.Sprintf("`%s` (%s): %s", w.URL, events, last)
+}
+
+type Webhooks []Webhook
+
+func (w Webhooks) String() string {
+	return w.Format(i18n.English)
+}
+
+func (w Webhooks) Format(l i18n.Localizer) string {
+	if len(w) == 0 {
+		return l.T("webhooks.none")
+	}
+	var sb strings.Builder
+	sb.Grow(len(w) * 128)
+	for _, wh := range w {
+		sb.WriteString(wh.Format(l))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// WebhookDelivery is the delivery log entry of a single event.
+type WebhookDelivery struct {
+	WebhookID   int64
+	Event       ServerEventType
+	StatusCode  int // 0 if no response was received
+	Attempts    int
+	Error       string
+	DeliveredAt time.Time
+}
+
+func (d *WebhookDelivery) ToAddSQLC() sqlc.AddWebhookDeliveryParams {
+	return sqlc.AddWebhookDeliveryParams{
+		WebhookID:  d.WebhookID,
+		Event:      string(d.Event),
+		StatusCode: int32(d.StatusCode),
+		Attempts:   int32(d.Attempts),
+		Error:      d.Error,
+	}
+}
+
+func (d WebhookDelivery) String() string {
+	return d.Format(i18n.English)
+}
+
+func (d WebhookDelivery) Format(l i18n.Localizer) string {
+	if d.Error != "" {
+		return l.T("webhook.delivery_failed", d.DeliveredAt.Unix(), d.StatusCode, d.Error)
+	}
+	return l.T("webhook.delivered", d.DeliveredAt.Unix(), d.StatusCode)
+}
+
+// WebhookEvent is a single event that must be delivered to a webhook.
+type WebhookEvent struct {
+	Webhook Webhook
+	Event   ServerEvent
+}
+
+func eventTypesFromStrings(ss []string) []ServerEventType {
+	result := make([]ServerEventType, 0, len(ss))
+	for _, s := range ss {
+		result = append(result, ServerEventType(s))
+	}
+	return result
+}
@@ -0,0 +1,293 @@
+package model
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/markdown"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+const (
+	// DigestPeriod is the time range that is summarized by a digest.
+	DigestPeriod = 7 * 24 * time.Hour
+
+	// keeps the digest below the message size limit of discord
+	maxDigestServers = 10
+	maxDigestMaps    = 5
+	MaxDigestPlayers = 5
+)
+
+// ParseWeekday parses a single weekday like "mon" or "monday".
+func ParseWeekday(s string) (time.Weekday, error) {
+	days, err := ParseWeekdays(s)
+	if err != nil {
+		return 0, err
+	}
+	if bits.OnesCount8(uint8(days)) != 1 {
+		return 0, i18n.NewError("error.invalid_single_weekday", s)
+	}
+	return time.Weekday(bits.TrailingZeros8(uint8(days))), nil
+}
+
+// WeeklyDigest is the schedule of a summary of the tracked servers of a channel.
+type WeeklyDigest struct {
+	// channel that contains the tracked servers
+	ChannelTarget
+	PostChannelID discord.ChannelID
+	Weekday       time.Weekday
+	// minutes after midnight
+	TimeOfDay int
+	// time zone of the schedule
+	Location     *time.Location
+	LastPostedAt time.Time
+	// failed posts are attempted again after a backoff
+	LastAttemptAt  time.Time
+	FailedAttempts int
+}
+
+func NewWeeklyDigestFromSQLC(row sqlc.WeeklyDigest) (WeeklyDigest, error) {
+	loc, err := time.LoadLocation(row.Timezone)
+	if err != nil {
+		return WeeklyDigest{}, fmt.Errorf("invalid time zone of the weekly digest of channel %d: %w", row.ChannelID, err)
+	}
+	return WeeklyDigest{
+		ChannelTarget: ChannelTarget{
+			GuildID:   discord.GuildID(row.GuildID),
+			ChannelID: discord.ChannelID(row.ChannelID),
+		},
+		PostChannelID:  discord.ChannelID(row.PostChannelID),
+		Weekday:        time.Weekday(row.Weekday),
+		TimeOfDay:      int(row.TimeOfDay),
+		Location:       loc,
+		LastPostedAt:   row.LastPostedAt.Time,
+		LastAttemptAt:  row.LastAttemptAt.Time,
+		FailedAttempts: int(row.FailedAttempts),
+	}, nil
+}
+
+func (d *WeeklyDigest) ToSetSQLC() sqlc.SetWeeklyDigestParams {
+	return sqlc.SetWeeklyDigestParams{
+		GuildID:       int64(d.GuildID),
+		ChannelID:     int64(d.ChannelID),
+		PostChannelID: int64(d.PostChannelID),
+		Weekday:       int16(d.Weekday),
+		TimeOfDay:     int16(d.TimeOfDay),
+		Timezone:      d.Location.String(),
+	}
+}
+
+func (d *WeeklyDigest) ToRemoveSQLC() sqlc.RemoveWeeklyDigestParams {
+	return sqlc.RemoveWeeklyDigestParams{
+		GuildID:   int64(d.GuildID),
+		ChannelID: int64(d.ChannelID),
+	}
+}
+
+// LastOccurrence returns the most recent scheduled point in time that is not after now.
+func (d *WeeklyDigest) LastOccurrence(now time.Time) time.Time {
+	local := now.In(d.Location)
+	days := (int(local.Weekday()) - int(d.Weekday) + 7) % 7
+	t := time.Date(local.Year(), local.Month(), local.Day()-days, 0, d.TimeOfDay, 0, 0, d.Location)
+	if t.After(now) {
+		t = time.Date(local.Year(), local.Month(), local.Day()-days-7, 0, d.TimeOfDay, 0, 0, d.Location)
+	}
+	return t
+}
+
+// IsDue returns true in case the digest was not posted since its last scheduled point in time
+// and the backoff after a failed attempt has passed.
+func (d *WeeklyDigest) IsDue(now time.Time) bool {
+	return d.LastPostedAt.Before(d.LastOccurrence(now)) &&
+		!now.Before(NextAttempt(d.LastAttemptAt, d.FailedAttempts))
+}
+
+// Schedule returns a human readable description of the schedule.
+func (d *WeeklyDigest) Schedule(l i18n.Localizer) string {
+	return l.T("weekly_digest.schedule", l.Choice(d.Weekday.String()), formatClock(d.TimeOfDay), d.Location)
+}
+
+func (d WeeklyDigest) String() string {
+	return d.Format(i18n.English)
+}
+
+func (d WeeklyDigest) Format(l i18n.Localizer) string {
+	return l.T("weekly_digest.format", d.ChannelID, d.Schedule(l), d.PostChannelID)
+}
+
+type WeeklyDigests []WeeklyDigest
+
+func (w WeeklyDigests) String() string {
+	return w.Format(i18n.English)
+}
+
+func (w WeeklyDigests) Format(l i18n.Localizer) string {
+	if len(w) == 0 {
+		return l.T("weekly_digests.none")
+	}
+	var sb strings.Builder
+	sb.Grow(len(w) * 96)
+	for _, d := range w {
+		sb.WriteString(d.Format(l))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// DigestServer contains the player statistics of a single server.
+type DigestServer struct {
+	Address     string
+	PeakPlayers int
+	AvgPlayers  float64
+	// start of the hour with the highest average player count, zero if there were no players
+	BusiestHour        time.Time
+	BusiestHourPlayers float64
+}
+
+func (s DigestServer) String() string {
+	return s.Format(i18n.English)
+}
+
+func (s DigestServer) Format(l i18n.Localizer) string {
+	line := l.T("digest.server", s.Address, s.PeakPlayers, s.AvgPlayers)
+	if !s.BusiestHour.IsZero() {
+		busiest := shortWeekday(l, s.BusiestHour.Weekday()) + " " + s.BusiestHour.Format("15:04")
+		line += ", " + l.T("digest.busiest_hour", busiest, s.BusiestHourPlayers)
+	}
+	return line
+}
+
+// NewDigestServers computes the statistics of every server from hourly aggregates in the range [from, to).
+// Servers without any aggregates are not part of the result.
+func NewDigestServers(hourly []sqlc.ServerHistoryRollup, from, to time.Time, loc *time.Location) []DigestServer {
+	type sums struct {
+		server  DigestServer
+		players float64
+		samples int
+	}
+	byAddress := make(map[string]*sums)
+	for _, r := range hourly {
+		if r.Bucket.Time.Before(from) || !r.Bucket.Time.Before(to) {
+			continue
+		}
+		s, ok := byAddress[r.Address]
+		if !ok {
+			s = &sums{server: DigestServer{Address: r.Address}}
+			byAddress[r.Address] = s
+		}
+		s.server.PeakPlayers = max(s.server.PeakPlayers, int(r.PeakPlayers))
+		s.players += float64(r.AvgPlayers) * float64(r.Samples)
+		s.samples += int(r.Samples)
+		if avg := float64(r.AvgPlayers); avg > s.server.BusiestHourPlayers {
+			s.server.BusiestHourPlayers = avg
+			s.server.BusiestHour = r.Bucket.Time.In(loc)
+		}
+	}
+
+	servers := make([]DigestServer, 0, len(byAddress))
+	for _, s := range byAddress {
+		if s.samples > 0 {
+			s.server.AvgPlayers = s.players / float64(s.samples)
+		}
+		servers = append(servers, s.server)
+	}
+	sort.Slice(servers, func(i, j int) bool {
+		if servers[i].AvgPlayers != servers[j].AvgPlayers {
+			return servers[i].AvgPlayers > servers[j].AvgPlayers
+		}
+		return servers[i].Address < servers[j].Address
+	})
+	return servers
+}
+
+// DigestMap is a map and its share of the time in which the servers were online.
+type DigestMap struct {
+	Name  string
+	Share float64
+}
+
+// NewDigestMaps returns the most played maps, rows are expected to be sorted by their number of samples.
+func NewDigestMaps(rows []sqlc.GetDigestMapsRow) []DigestMap {
+	total := 0
+	for _, r := range rows {
+		total += int(r.Samples)
+	}
+	maps := make([]DigestMap, 0, min(len(rows), maxDigestMaps))
+	for _, r := range rows {
+		if len(maps) == maxDigestMaps {
+			break
+		}
+		maps = append(maps, DigestMap{
+			Name:  r.Map,
+			Share: float64(r.Samples) / float64(total),
+		})
+	}
+	return maps
+}
+
+// Digest is the weekly summary of the tracked servers of a channel.
+type Digest struct {
+	ChannelID discord.ChannelID
+	From      time.Time
+	To        time.Time
+
+	Servers          []DigestServer
+	Maps             []DigestMap
+	TopPlayers       []PlaytimeEntry
+	NewPlayers       int
+	ReturningPlayers int
+}
+
+func (d Digest) String() string {
+	return d.Format(i18n.English)
+}
+
+func (d Digest) Format(l i18n.Localizer) string {
+	var sb strings.Builder
+	sb.Grow(512 + len(d.Servers)*128)
+	sb.WriteString(l.T("digest.title", d.ChannelID, d.From.Unix(), d.To.Unix()))
+	sb.WriteString("\n")
+
+	sb.WriteString(fmt.Sprintf("\n**%s**\n", l.T("digest.servers")))
+	if len(d.Servers) == 0 {
+		sb.WriteString(l.T("digest.no_servers"))
+		sb.WriteString("\n")
+	}
+	for i, s := range d.Servers {
+		if i == maxDigestServers {
+			sb.WriteString(l.T("status.and_more", len(d.Servers)-maxDigestServers))
+			sb.WriteString("\n")
+			break
+		}
+		sb.WriteString(s.Format(l))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(fmt.Sprintf("\n**%s**\n", l.T("digest.maps")))
+	if len(d.Maps) == 0 {
+		sb.WriteString(l.T("digest.no_maps"))
+		sb.WriteString("\n")
+	}
+	for i, m := range d.Maps {
+		sb.WriteString(fmt.Sprintf("%d. %s (%.0f%%)\n", i+1, markdown.Escape(m.Name), m.Share*100))
+	}
+
+	sb.WriteString(fmt.Sprintf("\n**%s**\n", l.T("digest.top_players")))
+	if len(d.TopPlayers) == 0 {
+		sb.WriteString(l.T("digest.no_playtime"))
+		sb.WriteString("\n")
+	}
+	for i, e := range d.TopPlayers {
+		sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, e.Format(l)))
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(l.T("digest.players", d.NewPlayers, d.ReturningPlayers))
+	sb.WriteString("\n")
+	return sb.String()
+}
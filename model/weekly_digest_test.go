@@ -0,0 +1,94 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/model"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeeklyDigestSchedule(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	d := model.WeeklyDigest{
+		Weekday:   time.Sunday,
+		TimeOfDay: 18 * 60,
+		Location:  loc,
+	}
+
+	// wednesday
+	now := time.Date(2024, 3, 6, 12, 0, 0, 0, loc)
+	require.Equal(t, time.Date(2024, 3, 3, 18, 0, 0, 0, loc), d.LastOccurrence(now))
+
+	// sunday before and after the scheduled time
+	now = time.Date(2024, 3, 10, 17, 59, 0, 0, loc)
+	require.Equal(t, time.Date(2024, 3, 3, 18, 0, 0, 0, loc), d.LastOccurrence(now))
+	now = time.Date(2024, 3, 10, 18, 0, 0, 0, loc)
+	require.Equal(t, now, d.LastOccurrence(now))
+
+	d.LastPostedAt = time.Date(2024, 3, 3, 18, 1, 0, 0, loc)
+	require.True(t, d.IsDue(now))
+	require.False(t, d.IsDue(now.Add(-time.Minute)))
+	d.LastAttemptAt = now
+	d.FailedAttempts = 2
+	require.False(t, d.IsDue(now.Add(29*time.Minute)))
+	require.True(t, d.IsDue(now.Add(30*time.Minute)))
+	d.FailedAttempts = 0
+	d.LastPostedAt = now
+	require.False(t, d.IsDue(now.Add(6*24*time.Hour)))
+
+	d.ChannelID = 1
+	d.PostChannelID = 2
+	require.Equal(t, "<#1>: posted every Sunday at 18:00 (Europe/Berlin) in <#2>", d.String())
+	require.Equal(t, "<#1>: wird jeden Sonntag um 18:00 (Europe/Berlin) in <#2> gepostet", d.Format(i18n.New("de")))
+
+	day, err := model.ParseWeekday("Friday")
+	require.NoError(t, err)
+	require.Equal(t, time.Friday, day)
+	_, err = model.ParseWeekday("weekend")
+	require.Error(t, err)
+}
+
+func TestDigestStatistics(t *testing.T) {
+	until := time.Date(2024, 3, 10, 18, 0, 0, 0, time.UTC)
+	from := until.Add(-model.DigestPeriod)
+	ts := func(d time.Duration) pgtype.Timestamptz {
+		return pgtype.Timestamptz{Time: from.Add(d), Valid: true}
+	}
+
+	hourly := []sqlc.ServerHistoryRollup{
+		// before the digest period
+		{Address: "a", Bucket: ts(-time.Hour), Samples: 60, AvgPlayers: 30, PeakPlayers: 32},
+		{Address: "a", Bucket: ts(0), Samples: 60, AvgPlayers: 2, PeakPlayers: 4},
+		{Address: "a", Bucket: ts(time.Hour), Samples: 60, AvgPlayers: 6, PeakPlayers: 8},
+		{Address: "b", Bucket: ts(2 * time.Hour), Samples: 30, AvgPlayers: 1, PeakPlayers: 1},
+		// after the digest period
+		{Address: "b", Bucket: ts(model.DigestPeriod), Samples: 60, AvgPlayers: 20, PeakPlayers: 20},
+	}
+
+	servers := model.NewDigestServers(hourly, from, until, time.UTC)
+	require.Len(t, servers, 2)
+	require.Equal(t, "a", servers[0].Address)
+	require.Equal(t, 8, servers[0].PeakPlayers)
+	require.InDelta(t, 4.0, servers[0].AvgPlayers, 0.0001)
+	require.Equal(t, from.Add(time.Hour), servers[0].BusiestHour)
+	require.Equal(t, "b", servers[1].Address)
+	require.InDelta(t, 1.0, servers[1].AvgPlayers, 0.0001)
+
+	maps := model.NewDigestMaps([]sqlc.GetDigestMapsRow{
+		{Map: "ctf5", Samples: 50},
+		{Map: "dm1", Samples: 25},
+		{Map: "dm2", Samples: 10},
+		{Map: "dm6", Samples: 5},
+		{Map: "ctf2", Samples: 5},
+		{Map: "dm7", Samples: 5},
+	})
+	require.Len(t, maps, 5)
+	require.Equal(t, "ctf5", maps[0].Name)
+	require.InDelta(t, 0.5, maps[0].Share, 0.0001)
+}
@@ -0,0 +1,107 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/jxsl13/twstatus-bot/i18n"
+	"github.com/jxsl13/twstatus-bot/sqlc"
+)
+
+const (
+	// initial and maximum delay before a failed scheduled post is attempted again
+	retryBackoff    = 15 * time.Minute
+	maxRetryBackoff = 12 * time.Hour
+)
+
+// WeeklyHeatmap is an activity heatmap that is posted automatically once a week.
+type WeeklyHeatmap struct {
+	// channel that contains the tracked servers
+	ChannelTarget
+	// empty for all tracked servers of the channel
+	Address       string
+	PostChannelID discord.ChannelID
+	LastPostedAt  time.Time
+	// failed posts are attempted again after a backoff
+	LastAttemptAt  time.Time
+	FailedAttempts int
+	// time zone of the guild
+	Location *time.Location
+}
+
+func (w *WeeklyHeatmap) ToAddSQLC() sqlc.AddWeeklyHeatmapParams {
+	return sqlc.AddWeeklyHeatmapParams{
+		GuildID:       int64(w.GuildID),
+		ChannelID:     int64(w.ChannelID),
+		Address:       w.Address,
+		PostChannelID: int64(w.PostChannelID),
+	}
+}
+
+func (w *WeeklyHeatmap) ToRemoveSQLC() sqlc.RemoveWeeklyHeatmapParams {
+	return sqlc.RemoveWeeklyHeatmapParams{
+		GuildID:   int64(w.GuildID),
+		ChannelID: int64(w.ChannelID),
+		Address:   w.Address,
+	}
+}
+
+// IsDue returns true in case the heatmap was not posted since the start of the current week
+// and the backoff after a failed attempt has passed.
+func (w *WeeklyHeatmap) IsDue(now time.Time) bool {
+	return w.LastPostedAt.Before(WeekStart(now, w.Location)) &&
+		!now.Before(NextAttempt(w.LastAttemptAt, w.FailedAttempts))
+}
+
+// Subject returns a human readable description of the servers that are part of the heatmap.
+func (w *WeeklyHeatmap) Subject(l i18n.Localizer) string {
+	if w.Address == "" {
+		return l.T("weekly_heatmap.all_servers", w.ChannelID)
+	}
+	return fmt.Sprintf("`%s`", w.Address)
+}
+
+func (w WeeklyHeatmap) String() string {
+	return w.Format(i18n.English)
+}
+
+func (w WeeklyHeatmap) Format(l i18n.Localizer) string {
+	return l.T("weekly_heatmap.format", w.Subject(l), w.PostChannelID)
+}
+
+type WeeklyHeatmaps []WeeklyHeatmap
+
+func (w WeeklyHeatmaps) String() string {
+	return w.Format(i18n.English)
+}
+
+func (w WeeklyHeatmaps) Format(l i18n.Localizer) string {
+	if len(w) == 0 {
+		return l.T("weekly_heatmaps.none")
+	}
+	var sb strings.Builder
+	sb.Grow(len(w) * 96)
+	for _, wh := range w {
+		sb.WriteString(wh.Format(l))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// NextAttempt returns the point in time at which a failed scheduled post is attempted again.
+// The backoff doubles with every failed attempt.
+func NextAttempt(lastAttemptAt time.Time, failedAttempts int) time.Time {
+	if failedAttempts <= 0 {
+		return time.Time{}
+	}
+	backoff := min(retryBackoff<<min(failedAttempts-1, 8), maxRetryBackoff)
+	return lastAttemptAt.Add(backoff)
+}
+
+// WeekStart returns monday 00:00 of the week that contains t in the given time zone.
+func WeekStart(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day()-mondayFirst(local.Weekday()), 0, 0, 0, 0, loc)
+}
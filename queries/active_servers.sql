@@ -40,8 +40,9 @@ INSERT INTO active_servers (
 	version,
 	max_clients,
 	max_players,
-	score_kind
-) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13);
+	score_kind,
+	location
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14);
 
 
 -- name: ListTrackedServerClients :many
@@ -99,3 +100,140 @@ WHERE address = $1
 LIMIT 1;
 
 
+
+
+-- name: FindPlayers :many
+SELECT
+	s.address,
+	s.name AS server_name,
+	s.map,
+	s.score_kind,
+	c.name,
+	c.clan,
+	(CASE WHEN c.score = -9999 THEN 2147483647 ELSE c.score END)::INTEGER as score,
+	c.is_player,
+	c.team,
+	m.match
+FROM (
+	SELECT
+		id,
+		(CASE
+			WHEN lower(name) = lower(sqlc.arg(name)) THEN 0
+			WHEN starts_with(lower(name), lower(sqlc.arg(name))) THEN 1
+			ELSE 2
+		END)::SMALLINT AS match,
+		similarity(name, sqlc.arg(name))::REAL AS similarity
+	FROM active_server_clients
+	WHERE
+		starts_with(lower(name), lower(sqlc.arg(name))) OR
+		strpos(lower(name), lower(sqlc.arg(name))) > 0 OR
+		name % sqlc.arg(name)
+) m
+JOIN active_server_clients c ON c.id = m.id
+JOIN active_servers s ON s.address = c.address
+WHERE m.match <= sqlc.arg(max_match)
+ORDER BY
+	m.match ASC,
+	m.similarity DESC,
+	c.name ASC,
+	s.name ASC
+LIMIT sqlc.arg(max_entries);
+
+
+-- name: SearchServers :many
+SELECT
+	s.address,
+	s.name,
+	s.gametype,
+	s.passworded,
+	s.map,
+	s.version,
+	s.location,
+	s.max_clients,
+	s.max_players,
+	(COUNT(c.id) FILTER (WHERE c.is_player))::SMALLINT AS num_players,
+	COUNT(c.id)::SMALLINT AS num_clients
+FROM active_servers s
+LEFT JOIN active_server_clients c ON c.address = s.address
+WHERE
+	strpos(lower(s.name), lower(sqlc.arg(name))) > 0 AND
+	strpos(lower(s.gametype), lower(sqlc.arg(gametype))) > 0 AND
+	strpos(lower(s.map), lower(sqlc.arg(map))) > 0 AND
+	starts_with(s.version, sqlc.arg(version)) AND
+	starts_with(s.location, sqlc.arg(region)) AND
+	(sqlc.narg(passworded)::BOOLEAN IS NULL OR s.passworded = sqlc.narg(passworded))
+GROUP BY s.address
+HAVING COUNT(c.id) FILTER (WHERE c.is_player) >= sqlc.arg(min_players)
+ORDER BY
+	num_players DESC,
+	num_clients DESC,
+	s.name ASC,
+	s.address ASC
+LIMIT sqlc.arg(max_entries);
+
+
+-- name: GetActiveServer :many
+SELECT
+	timestamp,
+	address,
+	protocols,
+	name,
+	gametype,
+	passworded,
+	map,
+	map_sha256sum,
+	map_size,
+	version,
+	max_clients,
+	max_players,
+	score_kind,
+	location
+FROM active_servers
+WHERE address = $1
+LIMIT 1;
+
+
+-- name: ListActiveServerClients :many
+SELECT
+	c.name,
+	c.clan,
+	c.country_id,
+	(CASE WHEN c.score = -9999 THEN 2147483647 ELSE c.score END)::INTEGER as score,
+	c.is_player,
+	c.team,
+	f.abbr,
+	COALESCE(fm.emoji, f.emoji)::VARCHAR(64) as flag_emoji
+FROM active_server_clients c
+JOIN flags f ON c.country_id = f.flag_id
+LEFT JOIN flag_mappings fm ON
+	(
+		fm.channel_id = $2 AND
+		c.country_id = fm.flag_id
+	)
+WHERE c.address = $1
+ORDER BY
+	score DESC,
+	c.name ASC;
+
+
+-- name: AutocompleteServers :many
+SELECT
+	s.address,
+	s.name
+FROM active_servers s
+LEFT JOIN (
+	SELECT
+		address,
+		COUNT(*) AS num_clients
+	FROM active_server_clients
+	GROUP BY address
+) c ON c.address = s.address
+WHERE
+	strpos(s.address, sqlc.arg(search)) > 0 OR
+	strpos(lower(s.name), lower(sqlc.arg(search))) > 0
+ORDER BY
+	starts_with(s.address, sqlc.arg(search)) DESC,
+	COALESCE(c.num_clients, 0) DESC,
+	s.name ASC,
+	s.address ASC
+LIMIT sqlc.arg(max_entries);
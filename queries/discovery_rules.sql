@@ -0,0 +1,80 @@
+-- name: ListDiscoveryRules :many
+SELECT
+	id,
+	guild_id,
+	alert_channel_id,
+	name,
+	gametype
+FROM discovery_rules
+WHERE guild_id = $1
+ORDER BY id ASC;
+
+
+-- name: ListAllDiscoveryRules :many
+SELECT
+	id,
+	guild_id,
+	alert_channel_id,
+	name,
+	gametype
+FROM discovery_rules
+ORDER BY guild_id ASC, id ASC;
+
+
+-- name: AddDiscoveryRule :exec
+INSERT INTO discovery_rules (
+	guild_id,
+	alert_channel_id,
+	name,
+	gametype
+) VALUES ($1, $2, $3, $4)
+ON CONFLICT (guild_id, alert_channel_id, name, gametype) DO NOTHING;
+
+
+-- name: RemoveDiscoveryRule :exec
+DELETE FROM discovery_rules
+WHERE guild_id = $1
+AND id = $2;
+
+
+-- name: HasKnownServers :many
+SELECT EXISTS(SELECT 1 FROM known_servers) AS found;
+
+
+-- name: TouchKnownServers :exec
+UPDATE known_servers ks
+SET last_seen_at = NOW()
+FROM active_servers s
+WHERE ks.address = s.address;
+
+
+-- name: PruneKnownServers :exec
+DELETE FROM known_servers
+WHERE last_seen_at < $1;
+
+
+-- name: AddKnownServers :many
+INSERT INTO known_servers (address)
+SELECT address FROM active_servers
+ON CONFLICT (address) DO NOTHING
+RETURNING address;
+
+
+-- name: ListDiscoveredServers :many
+SELECT
+	s.address,
+	s.name,
+	s.gametype,
+	s.passworded,
+	s.map,
+	s.version,
+	s.location,
+	s.max_clients,
+	s.max_players,
+	(COUNT(c.id) FILTER (WHERE c.is_player))::SMALLINT AS num_players,
+	COUNT(c.id)::SMALLINT AS num_clients
+FROM active_servers s
+LEFT JOIN active_server_clients c ON c.address = s.address
+WHERE s.address = ANY(sqlc.arg(addresses)::VARCHAR(64)[])
+GROUP BY s.address
+ORDER BY s.address ASC;
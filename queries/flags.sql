@@ -21,4 +21,13 @@ LIMIT 1;
 SELECT flag_id, abbr, emoji
 FROM flags
 WHERE abbr = $1
-LIMIT 1;
\ No newline at end of file
+LIMIT 1;
+
+-- name: AutocompleteFlags :many
+SELECT flag_id, abbr, emoji
+FROM flags
+WHERE strpos(abbr, lower(sqlc.arg(search))) > 0
+ORDER BY
+	starts_with(abbr, lower(sqlc.arg(search))) DESC,
+	abbr ASC
+LIMIT sqlc.arg(max_entries);
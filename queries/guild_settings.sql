@@ -0,0 +1,23 @@
+-- name: GetGuildSettings :many
+SELECT
+	guild_id,
+	timezone,
+	manager_role_id,
+	language
+FROM guild_settings
+WHERE guild_id = $1
+LIMIT 1;
+
+
+-- name: SetGuildSettings :exec
+INSERT INTO guild_settings (
+	guild_id,
+	timezone,
+	manager_role_id,
+	language
+) VALUES ($1, $2, $3, $4)
+ON CONFLICT (guild_id)
+DO UPDATE SET
+	timezone = EXCLUDED.timezone,
+	manager_role_id = EXCLUDED.manager_role_id,
+	language = EXCLUDED.language;
@@ -0,0 +1,51 @@
+-- name: ListMapChangeSubscriptions :many
+SELECT
+	m.message_id,
+	t.address,
+	m.pattern,
+	m.dm
+FROM map_change_subscriptions m
+JOIN tracking t ON m.message_id = t.message_id
+WHERE m.guild_id = $1
+AND m.channel_id = $2
+AND m.user_id = $3
+ORDER BY t.address ASC, m.pattern ASC;
+
+
+-- name: AddMapChangeSubscription :exec
+INSERT INTO map_change_subscriptions (
+	guild_id,
+	channel_id,
+	message_id,
+	user_id,
+	pattern,
+	dm
+) VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (message_id, user_id, pattern)
+DO UPDATE SET
+	dm = EXCLUDED.dm;
+
+
+-- name: RemoveMapChangeSubscription :exec
+DELETE FROM map_change_subscriptions
+WHERE message_id = $1
+AND user_id = $2
+AND pattern = $3;
+
+
+-- name: GetMapChangeSubscriptions :many
+SELECT
+	m.guild_id,
+	m.channel_id,
+	m.message_id,
+	COALESCE(pcm.message_id, 0)::bigint AS prev_message_id,
+	m.user_id,
+	m.pattern,
+	m.dm
+FROM map_change_subscriptions m
+JOIN channels c ON m.channel_id = c.channel_id
+LEFT JOIN player_count_notification_messages pcm
+ON (m.channel_id = pcm.channel_id)
+WHERE c.running = TRUE
+AND m.message_id = ANY(sqlc.arg(message_ids)::BIGINT[])
+ORDER BY m.guild_id, m.channel_id, m.message_id, m.user_id;
@@ -0,0 +1,104 @@
+-- name: EndMapSessions :exec
+UPDATE map_sessions m
+SET ended_at = NOW()
+FROM unnest(
+	sqlc.arg(addresses)::VARCHAR(64)[],
+	sqlc.arg(maps)::VARCHAR(128)[]
+) AS u(address, map)
+WHERE m.address = u.address
+AND m.map <> u.map
+AND m.ended_at IS NULL;
+
+
+-- name: StartMapSessions :exec
+INSERT INTO map_sessions (
+	address,
+	map,
+	started_at
+)
+SELECT
+	u.address,
+	u.map,
+	NOW()
+FROM unnest(
+	sqlc.arg(addresses)::VARCHAR(64)[],
+	sqlc.arg(maps)::VARCHAR(128)[]
+) AS u(address, map)
+WHERE u.map <> ''
+ON CONFLICT (address) WHERE ended_at IS NULL DO NOTHING;
+
+
+-- name: AddMapSessionPlayers :exec
+UPDATE map_sessions m
+SET
+	peak_players = GREATEST(m.peak_players, u.players),
+	player_seconds = m.player_seconds + u.players * sqlc.arg(seconds)::integer
+FROM unnest(
+	sqlc.arg(addresses)::VARCHAR(64)[],
+	sqlc.arg(maps)::VARCHAR(128)[],
+	sqlc.arg(players)::SMALLINT[]
+) AS u(address, map, players)
+WHERE m.address = u.address
+AND m.map = u.map
+AND m.ended_at IS NULL;
+
+
+-- name: CloseStaleMapSessions :exec
+UPDATE map_sessions
+SET ended_at = NOW()
+WHERE ended_at IS NULL
+AND address NOT IN (
+	SELECT t.address
+	FROM tracking t
+	JOIN channels c ON c.channel_id = t.channel_id
+	WHERE c.running = TRUE
+);
+
+
+-- name: PruneMapSessions :exec
+DELETE FROM map_sessions
+WHERE ended_at < $1;
+
+
+-- name: ListMapSessions :many
+SELECT
+	address,
+	map,
+	started_at,
+	ended_at,
+	peak_players,
+	player_seconds
+FROM map_sessions
+WHERE address = sqlc.arg(address)
+ORDER BY started_at DESC
+LIMIT sqlc.arg(max_sessions);
+
+
+-- name: GetPopularMaps :many
+SELECT
+	m.map,
+	count(*)::integer AS sessions,
+	max(m.peak_players)::smallint AS peak_players,
+	sum(m.player_seconds)::bigint AS player_seconds,
+	sum(EXTRACT(EPOCH FROM COALESCE(m.ended_at, NOW()) - m.started_at))::bigint AS seconds
+FROM map_sessions m
+WHERE m.address = sqlc.arg(address)
+AND COALESCE(m.ended_at, NOW()) >= sqlc.arg(since)::timestamptz
+GROUP BY m.map
+ORDER BY player_seconds DESC, seconds DESC, m.map ASC
+LIMIT sqlc.arg(max_entries);
+
+
+-- name: ExportMapSessions :many
+SELECT
+	address,
+	map,
+	started_at,
+	ended_at,
+	peak_players,
+	player_seconds
+FROM map_sessions
+WHERE address = sqlc.arg(address)
+AND started_at < sqlc.arg(until)::timestamptz
+AND (ended_at IS NULL OR ended_at >= sqlc.arg(since)::timestamptz)
+ORDER BY started_at ASC;
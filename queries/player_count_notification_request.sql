@@ -27,10 +27,6 @@ ON CONFLICT (guild_id, channel_id, message_id, user_id)
 DO UPDATE SET threshold = $5;
 
 
--- name: RemovePlayerCountNotificationRequests :exec
-DELETE FROM player_count_notification_requests;
-
-
 -- name: RemovePlayerCountNotificationRequest :exec
 DELETE FROM player_count_notification_requests
 WHERE guild_id = $1
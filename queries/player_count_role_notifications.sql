@@ -0,0 +1,100 @@
+-- name: ListPlayerCountRoleNotifications :many
+SELECT
+	prn.guild_id,
+	prn.channel_id,
+	prn.message_id,
+	t.address,
+	prn.role_id,
+	prn.threshold,
+	prn.cooldown,
+	prn.last_notified
+FROM player_count_role_notifications prn
+JOIN tracking t ON prn.message_id = t.message_id
+WHERE prn.guild_id = $1
+AND prn.channel_id = $2
+ORDER BY t.address ASC, prn.threshold ASC, prn.role_id ASC;
+
+
+-- name: SetPlayerCountRoleNotification :exec
+INSERT INTO player_count_role_notifications (
+	guild_id,
+	channel_id,
+	message_id,
+	role_id,
+	threshold,
+	cooldown
+) VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (guild_id, channel_id, message_id, role_id)
+DO UPDATE SET
+	threshold = $5,
+	cooldown = $6,
+	last_notified = NULL,
+	above_threshold = FALSE;
+
+
+-- name: RemovePlayerCountRoleNotification :exec
+DELETE FROM player_count_role_notifications
+WHERE guild_id = $1
+AND channel_id = $2
+AND message_id = $3
+AND role_id = $4;
+
+
+-- name: GetPlayerCountRoleNotificationMessages :many
+SELECT
+	t.guild_id,
+	t.channel_id,
+	prn.message_id AS req_message_id,
+	COALESCE(pcm.message_id, 0)::bigint AS prev_message_id,
+	prn.role_id,
+	prn.threshold,
+	np.num_players::smallint AS num_players
+FROM channels c
+JOIN tracking t ON c.channel_id = t.channel_id
+JOIN (
+	SELECT ac.address, count(*) AS num_players
+	FROM active_server_clients ac
+	WHERE ac.address = ANY(sqlc.arg(addresses)::TEXT[])
+	GROUP BY ac.address
+) np ON np.address = t.address
+JOIN player_count_role_notifications prn
+ON (
+	t.guild_id = prn.guild_id AND
+	t.channel_id = prn.channel_id AND
+	t.message_id = prn.message_id AND
+	np.num_players >= prn.threshold
+)
+LEFT JOIN player_count_notification_messages pcm
+ON (t.channel_id = pcm.channel_id)
+WHERE c.running = TRUE
+AND prn.above_threshold = FALSE
+AND (
+	prn.last_notified IS NULL OR
+	prn.last_notified + make_interval(secs => prn.cooldown) <= NOW()
+)
+ORDER BY t.guild_id, t.channel_id, prn.message_id, prn.role_id;
+
+
+-- name: MarkPlayerCountRoleNotified :exec
+UPDATE player_count_role_notifications
+SET
+	last_notified = NOW(),
+	above_threshold = TRUE
+WHERE guild_id = $1
+AND channel_id = $2
+AND message_id = $3
+AND role_id = $4;
+
+
+-- name: ResetPlayerCountRoleNotifications :exec
+UPDATE player_count_role_notifications prn
+SET above_threshold = FALSE
+FROM tracking t
+WHERE prn.message_id = t.message_id
+AND t.address = ANY(sqlc.arg(addresses)::TEXT[])
+AND prn.above_threshold = TRUE
+AND prn.threshold > (
+	SELECT count(*)
+	FROM active_server_clients ac
+	WHERE ac.address = t.address
+);
@@ -0,0 +1,61 @@
+-- name: AddPlayerPlaytime :exec
+INSERT INTO player_playtime (
+	address,
+	name,
+	clan,
+	day,
+	seconds
+)
+SELECT
+	u.address,
+	u.name,
+	u.clan,
+	sqlc.arg(day)::date,
+	sqlc.arg(seconds)::integer
+FROM unnest(
+	sqlc.arg(addresses)::VARCHAR(64)[],
+	sqlc.arg(names)::VARCHAR(32)[],
+	sqlc.arg(clans)::VARCHAR(32)[]
+) AS u(address, name, clan)
+ON CONFLICT (address, day, name, clan)
+DO UPDATE SET
+	seconds = player_playtime.seconds + EXCLUDED.seconds;
+
+
+-- name: GetPlayerPlaytimeLeaderboard :many
+SELECT
+	p.name,
+	p.clan,
+	sum(p.seconds)::bigint AS seconds
+FROM player_playtime p
+WHERE p.address IN (
+	SELECT t.address
+	FROM tracking t
+	WHERE t.guild_id = sqlc.arg(guild_id)
+	AND (sqlc.arg(address)::text = '' OR t.address = sqlc.arg(address)::text)
+)
+AND p.day >= sqlc.arg(since)::date
+AND p.day < sqlc.arg(until)::date
+GROUP BY p.name, p.clan
+ORDER BY seconds DESC, p.name ASC, p.clan ASC
+LIMIT sqlc.arg(max_entries);
+
+
+-- name: GetClanPlaytimeLeaderboard :many
+SELECT
+	p.clan,
+	sum(p.seconds)::bigint AS seconds,
+	count(DISTINCT p.name)::integer AS players
+FROM player_playtime p
+WHERE p.address IN (
+	SELECT t.address
+	FROM tracking t
+	WHERE t.guild_id = sqlc.arg(guild_id)
+	AND (sqlc.arg(address)::text = '' OR t.address = sqlc.arg(address)::text)
+)
+AND p.clan <> ''
+AND p.day >= sqlc.arg(since)::date
+AND p.day < sqlc.arg(until)::date
+GROUP BY p.clan
+ORDER BY seconds DESC, p.clan ASC
+LIMIT sqlc.arg(max_entries);
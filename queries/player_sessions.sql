@@ -0,0 +1,92 @@
+-- name: OpenPlayerSessions :exec
+INSERT INTO player_sessions (
+	address,
+	name,
+	clan,
+	joined_at
+)
+SELECT
+	u.address,
+	u.name,
+	u.clan,
+	NOW()
+FROM unnest(
+	sqlc.arg(addresses)::VARCHAR(64)[],
+	sqlc.arg(names)::VARCHAR(32)[],
+	sqlc.arg(clans)::VARCHAR(32)[]
+) AS u(address, name, clan)
+ON CONFLICT (address, name) WHERE left_at IS NULL DO NOTHING;
+
+
+-- name: ClosePlayerSessions :exec
+UPDATE player_sessions s
+SET left_at = NOW()
+FROM unnest(
+	sqlc.arg(addresses)::VARCHAR(64)[],
+	sqlc.arg(names)::VARCHAR(32)[]
+) AS u(address, name)
+WHERE s.address = u.address
+AND s.name = u.name
+AND s.left_at IS NULL;
+
+
+-- name: CloseStalePlayerSessions :exec
+UPDATE player_sessions
+SET left_at = NOW()
+WHERE left_at IS NULL
+AND address NOT IN (
+	SELECT t.address
+	FROM tracking t
+	JOIN channels c ON c.channel_id = t.channel_id
+	WHERE c.running = TRUE
+);
+
+
+-- name: PrunePlayerSessions :exec
+DELETE FROM player_sessions
+WHERE left_at < $1;
+
+
+-- name: GetLastPlayerSessions :many
+SELECT
+	s.address,
+	s.name,
+	s.clan,
+	s.joined_at,
+	s.left_at
+FROM player_sessions s
+WHERE lower(s.name) = lower(sqlc.arg(name))
+AND s.address IN (
+	SELECT t.address
+	FROM tracking t
+	WHERE t.guild_id = sqlc.arg(guild_id)
+)
+ORDER BY s.left_at DESC NULLS FIRST, s.joined_at DESC
+LIMIT sqlc.arg(max_sessions);
+
+
+-- name: ListPlayerSessions :many
+SELECT
+	address,
+	name,
+	clan,
+	joined_at,
+	left_at
+FROM player_sessions
+WHERE address = sqlc.arg(address)
+ORDER BY joined_at DESC, name ASC
+LIMIT sqlc.arg(max_sessions);
+
+
+-- name: ExportPlayerSessions :many
+SELECT
+	address,
+	name,
+	clan,
+	joined_at,
+	left_at
+FROM player_sessions
+WHERE address = sqlc.arg(address)
+AND joined_at < sqlc.arg(until)::timestamptz
+AND (left_at IS NULL OR left_at >= sqlc.arg(since)::timestamptz)
+ORDER BY joined_at ASC, name ASC;
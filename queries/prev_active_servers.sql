@@ -60,8 +60,7 @@ SELECT
 	flag_emoji
 FROM prev_active_server_clients
 WHERE message_id = $1
-ORDER BY id ASC
-LIMIT 1;
+ORDER BY id ASC;
 
 
 -- name: AddPrevActiveServerClient :exec
@@ -0,0 +1,27 @@
+-- name: GetChannelRemovalSummary :many
+SELECT
+	(SELECT COUNT(*) FROM tracking t WHERE t.channel_id = $1) AS trackings,
+	(SELECT COUNT(*) FROM player_count_notification_requests r WHERE r.channel_id = $1) AS notification_requests,
+	(SELECT COUNT(*) FROM player_count_role_notifications rn WHERE rn.channel_id = $1) AS role_notifications,
+	(SELECT COUNT(*) FROM server_status_alerts sa WHERE sa.channel_id = $1) AS status_alerts,
+	(SELECT COUNT(*) FROM map_change_subscriptions ms WHERE ms.channel_id = $1) AS map_subscriptions,
+	(SELECT COUNT(*) FROM flag_mappings fm WHERE fm.channel_id = $1) AS flag_mappings,
+	(SELECT COUNT(*) FROM weekly_heatmaps wh WHERE wh.channel_id = $1) AS weekly_heatmaps,
+	(SELECT COUNT(*) FROM weekly_digests wd WHERE wd.channel_id = $1) AS weekly_digests,
+	(SELECT COUNT(*) FROM top_servers_tracking ts WHERE ts.channel_id = $1) AS top_servers_messages;
+
+
+-- name: GetGuildRemovalSummary :many
+SELECT
+	(SELECT COUNT(*) FROM channels c WHERE c.guild_id = $1) AS channels,
+	(SELECT COUNT(*) FROM tracking t WHERE t.guild_id = $1) AS trackings,
+	(SELECT COUNT(*) FROM player_count_notification_requests r WHERE r.guild_id = $1) AS notification_requests,
+	(SELECT COUNT(*) FROM player_count_role_notifications rn WHERE rn.guild_id = $1) AS role_notifications,
+	(SELECT COUNT(*) FROM server_status_alerts sa WHERE sa.guild_id = $1) AS status_alerts,
+	(SELECT COUNT(*) FROM map_change_subscriptions ms WHERE ms.guild_id = $1) AS map_subscriptions,
+	(SELECT COUNT(*) FROM flag_mappings fm WHERE fm.guild_id = $1) AS flag_mappings,
+	(SELECT COUNT(*) FROM weekly_heatmaps wh WHERE wh.guild_id = $1) AS weekly_heatmaps,
+	(SELECT COUNT(*) FROM weekly_digests wd WHERE wd.guild_id = $1) AS weekly_digests,
+	(SELECT COUNT(*) FROM top_servers_tracking ts WHERE ts.guild_id = $1) AS top_servers_messages,
+	(SELECT COUNT(*) FROM webhooks w WHERE w.guild_id = $1) AS webhooks,
+	(SELECT COUNT(*) FROM discovery_rules dr WHERE dr.guild_id = $1) AS discovery_rules;
@@ -0,0 +1,139 @@
+-- name: AddServerHistory :exec
+INSERT INTO server_history (
+	address,
+	timestamp,
+	online,
+	num_players,
+	num_spectators,
+	max_players,
+	map
+)
+SELECT
+	t.address,
+	NOW(),
+	a.address IS NOT NULL,
+	COALESCE(c.num_players, 0)::smallint,
+	COALESCE(c.num_spectators, 0)::smallint,
+	COALESCE(a.max_players, 0)::smallint,
+	COALESCE(a.map, '')::text
+FROM (
+	SELECT DISTINCT tr.address
+	FROM tracking tr
+) t
+LEFT JOIN active_servers a ON a.address = t.address
+LEFT JOIN (
+	SELECT
+		ac.address,
+		count(*) FILTER (WHERE NOT COALESCE(ac.team < 0, NOT ac.is_player AND ac.score < 0)) AS num_players,
+		count(*) FILTER (WHERE COALESCE(ac.team < 0, NOT ac.is_player AND ac.score < 0)) AS num_spectators
+	FROM active_server_clients ac
+	WHERE ac.address IN (SELECT tr.address FROM tracking tr)
+	GROUP BY ac.address
+) c ON c.address = t.address
+WHERE NOT EXISTS (
+	SELECT 1
+	FROM server_history h
+	WHERE h.address = t.address
+	AND h.timestamp > NOW() - make_interval(secs => sqlc.arg(resolution)::float8)
+)
+ON CONFLICT (address, timestamp) DO NOTHING;
+
+
+-- name: RollupServerHistory :exec
+INSERT INTO server_history_rollups (
+	address,
+	bucket_size,
+	bucket,
+	samples,
+	online_samples,
+	avg_players,
+	peak_players,
+	avg_spectators
+)
+SELECT
+	h.address,
+	sqlc.arg(bucket_size)::integer,
+	date_bin(make_interval(secs => sqlc.arg(bucket_size)::integer), h.timestamp, TIMESTAMPTZ '2000-01-01 00:00:00+00') AS bucket,
+	count(*)::integer,
+	count(*) FILTER (WHERE h.online)::integer,
+	avg(h.num_players)::real,
+	max(h.num_players)::smallint,
+	avg(h.num_spectators)::real
+FROM server_history h
+WHERE h.timestamp >= COALESCE(
+	(
+		SELECT max(r.bucket) + make_interval(secs => sqlc.arg(bucket_size)::integer)
+		FROM server_history_rollups r
+		WHERE r.bucket_size = sqlc.arg(bucket_size)::integer
+	),
+	'-infinity'::timestamptz
+)
+AND h.timestamp < sqlc.arg(until)::timestamptz
+GROUP BY h.address, bucket
+ON CONFLICT (address, bucket_size, bucket)
+DO UPDATE SET
+	samples = EXCLUDED.samples,
+	online_samples = EXCLUDED.online_samples,
+	avg_players = EXCLUDED.avg_players,
+	peak_players = EXCLUDED.peak_players,
+	avg_spectators = EXCLUDED.avg_spectators;
+
+
+-- name: PruneServerHistory :exec
+DELETE FROM server_history
+WHERE timestamp < $1;
+
+
+-- name: PruneServerHistoryRollups :exec
+DELETE FROM server_history_rollups
+WHERE bucket_size = $1
+AND bucket < $2;
+
+
+-- name: GetServerHistory :many
+SELECT
+	address,
+	timestamp,
+	online,
+	num_players,
+	num_spectators,
+	max_players,
+	map
+FROM server_history
+WHERE address = $1
+AND timestamp >= $2
+ORDER BY timestamp ASC;
+
+
+-- name: GetServerHistoryRollups :many
+SELECT
+	address,
+	bucket_size,
+	bucket,
+	samples,
+	online_samples,
+	avg_players,
+	peak_players,
+	avg_spectators
+FROM server_history_rollups
+WHERE address = $1
+AND bucket_size = $2
+AND bucket >= $3
+ORDER BY bucket ASC;
+
+
+-- name: ListServerHistoryRollups :many
+SELECT
+	address,
+	bucket_size,
+	bucket,
+	samples,
+	online_samples,
+	avg_players,
+	peak_players,
+	avg_spectators
+FROM server_history_rollups
+WHERE address = ANY(sqlc.arg(addresses)::VARCHAR(64)[])
+AND bucket_size = sqlc.arg(bucket_size)
+AND bucket >= sqlc.arg(bucket)
+ORDER BY bucket ASC, address ASC;
@@ -0,0 +1,105 @@
+-- name: ListServerStatusAlerts :many
+SELECT
+	a.message_id,
+	t.address,
+	a.alert_channel_id,
+	a.alert_user_id,
+	a.debounce
+FROM server_status_alerts a
+JOIN tracking t ON a.message_id = t.message_id
+WHERE a.guild_id = $1
+AND a.channel_id = $2
+ORDER BY t.address ASC;
+
+
+-- name: SetServerStatusAlert :exec
+INSERT INTO server_status_alerts (
+	message_id,
+	guild_id,
+	channel_id,
+	alert_channel_id,
+	alert_user_id,
+	debounce
+) VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (message_id)
+DO UPDATE SET
+	alert_channel_id = $4,
+	alert_user_id = $5,
+	debounce = $6;
+
+
+-- name: ListAllServerStatusAlerts :many
+SELECT message_id
+FROM server_status_alerts
+ORDER BY message_id ASC;
+
+
+-- name: RemoveServerStatusAlert :exec
+DELETE FROM server_status_alerts
+WHERE guild_id = $1
+AND channel_id = $2
+AND message_id = $3;
+
+
+-- name: AddServerStatusAlertState :exec
+INSERT INTO server_status_alert_states (
+	message_id,
+	name,
+	map,
+	num_players,
+	max_players,
+	offline_since
+) VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (message_id) DO NOTHING;
+
+
+-- name: RemoveServerStatusAlertState :many
+DELETE FROM server_status_alert_states s
+USING server_status_alerts a, tracking t
+WHERE s.message_id = $1
+AND a.message_id = s.message_id
+AND t.message_id = s.message_id
+RETURNING
+	a.message_id,
+	a.guild_id,
+	a.channel_id,
+	t.address,
+	a.alert_channel_id,
+	a.alert_user_id,
+	a.debounce,
+	s.name,
+	s.map,
+	s.num_players,
+	s.max_players,
+	s.offline_since,
+	s.alerted;
+
+
+-- name: ListDueServerStatusAlerts :many
+SELECT
+	a.message_id,
+	a.guild_id,
+	a.channel_id,
+	t.address,
+	a.alert_channel_id,
+	a.alert_user_id,
+	a.debounce,
+	s.name,
+	s.map,
+	s.num_players,
+	s.max_players,
+	s.offline_since
+FROM server_status_alert_states s
+JOIN server_status_alerts a ON s.message_id = a.message_id
+JOIN tracking t ON a.message_id = t.message_id
+JOIN channels c ON a.channel_id = c.channel_id
+WHERE c.running = TRUE
+AND s.alerted = FALSE
+AND s.offline_since + make_interval(secs => a.debounce) <= NOW()
+ORDER BY a.guild_id ASC, a.channel_id ASC, a.message_id ASC;
+
+
+-- name: MarkServerStatusAlerted :exec
+UPDATE server_status_alert_states
+SET alerted = TRUE
+WHERE message_id = $1;
@@ -0,0 +1,42 @@
+-- name: ListTopServersTrackings :many
+SELECT
+	t.message_id,
+	t.guild_id,
+	t.channel_id,
+	t.gametype,
+	t.count,
+	t.ranking
+FROM channels c
+JOIN top_servers_tracking t ON c.channel_id = t.channel_id
+WHERE c.running = TRUE
+ORDER BY t.guild_id ASC, t.channel_id ASC, t.message_id ASC;
+
+
+-- name: ListTopServersMessageIDsByChannelID :many
+SELECT message_id
+FROM top_servers_tracking
+WHERE guild_id = $1
+AND channel_id = $2
+ORDER BY message_id ASC;
+
+
+-- name: AddTopServersTracking :exec
+INSERT INTO top_servers_tracking (
+	guild_id,
+	channel_id,
+	message_id,
+	gametype,
+	count
+) VALUES ($1, $2, $3, $4, $5);
+
+
+-- name: SetTopServersRanking :exec
+UPDATE top_servers_tracking
+SET ranking = $2
+WHERE message_id = $1;
+
+
+-- name: RemoveTopServersTrackingByMessageId :exec
+DELETE FROM top_servers_tracking
+WHERE guild_id = $1
+AND message_id = $2;
@@ -26,4 +26,12 @@ INSERT INTO tracking (
 -- name: RemoveTrackingByMessageId :exec
 DELETE FROM tracking
 WHERE guild_id = $1
-AND message_id = $2;
\ No newline at end of file
+AND message_id = $2;
+
+-- name: GetTrackingByAddress :many
+SELECT guild_id, channel_id, address, message_id
+FROM tracking
+WHERE guild_id = $1
+AND channel_id = $2
+AND address = $3
+LIMIT 1;
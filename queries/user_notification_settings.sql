@@ -0,0 +1,62 @@
+-- name: GetUserNotificationSettings :many
+SELECT
+	user_id,
+	timezone,
+	quiet_start,
+	quiet_end,
+	quiet_days
+FROM user_notification_settings
+WHERE user_id = $1
+LIMIT 1;
+
+
+-- name: ListUserNotificationSettings :many
+SELECT
+	user_id,
+	timezone,
+	quiet_start,
+	quiet_end,
+	quiet_days
+FROM user_notification_settings
+WHERE user_id = ANY(sqlc.arg(user_ids)::BIGINT[])
+ORDER BY user_id ASC;
+
+
+-- name: SetUserNotificationSettings :exec
+INSERT INTO user_notification_settings (
+	user_id,
+	timezone,
+	quiet_start,
+	quiet_end,
+	quiet_days
+) VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (user_id)
+DO UPDATE SET
+	timezone = EXCLUDED.timezone,
+	quiet_start = EXCLUDED.quiet_start,
+	quiet_end = EXCLUDED.quiet_end,
+	quiet_days = EXCLUDED.quiet_days;
+
+
+-- name: AddDeferredPlayerCountNotification :exec
+INSERT INTO deferred_player_count_notifications (
+	user_id,
+	message_id
+) VALUES ($1, $2)
+ON CONFLICT (user_id, message_id) DO NOTHING;
+
+
+-- name: ListDeferredPlayerCountNotifications :many
+SELECT
+	d.user_id,
+	d.message_id,
+	t.address
+FROM deferred_player_count_notifications d
+JOIN tracking t ON d.message_id = t.message_id
+ORDER BY d.user_id ASC, d.message_id ASC;
+
+
+-- name: RemoveDeferredPlayerCountNotification :exec
+DELETE FROM deferred_player_count_notifications
+WHERE user_id = $1
+AND message_id = $2;
@@ -0,0 +1,70 @@
+-- name: ListWebhooks :many
+SELECT
+	w.webhook_id,
+	w.url,
+	w.events,
+	COALESCE(d.status_code, 0)::integer AS last_status_code,
+	COALESCE(d.error, '')::text AS last_error,
+	d.delivered_at AS last_delivered_at
+FROM webhooks w
+LEFT JOIN LATERAL (
+	SELECT wd.status_code, wd.error, wd.delivered_at
+	FROM webhook_deliveries wd
+	WHERE wd.webhook_id = w.webhook_id
+	ORDER BY wd.delivery_id DESC
+	LIMIT 1
+) d ON TRUE
+WHERE w.guild_id = $1
+ORDER BY w.webhook_id ASC;
+
+
+-- name: ListAllWebhooks :many
+SELECT
+	webhook_id,
+	guild_id,
+	url,
+	secret,
+	events
+FROM webhooks
+ORDER BY guild_id ASC, webhook_id ASC;
+
+
+-- name: AddWebhook :exec
+INSERT INTO webhooks (
+	guild_id,
+	url,
+	secret,
+	events
+) VALUES ($1, $2, $3, $4)
+ON CONFLICT (guild_id, url)
+DO UPDATE SET
+	secret = EXCLUDED.secret,
+	events = EXCLUDED.events;
+
+
+-- name: RemoveWebhook :exec
+DELETE FROM webhooks
+WHERE guild_id = $1
+AND url = $2;
+
+
+-- name: AddWebhookDelivery :exec
+INSERT INTO webhook_deliveries (
+	webhook_id,
+	event,
+	status_code,
+	attempts,
+	error
+) VALUES ($1, $2, $3, $4, $5);
+
+
+-- name: PruneWebhookDeliveries :exec
+DELETE FROM webhook_deliveries
+WHERE webhook_id = sqlc.arg(webhook_id)
+AND delivery_id NOT IN (
+	SELECT wd.delivery_id
+	FROM webhook_deliveries wd
+	WHERE wd.webhook_id = sqlc.arg(webhook_id)
+	ORDER BY wd.delivery_id DESC
+	LIMIT sqlc.arg(keep)::integer
+);
@@ -0,0 +1,113 @@
+-- name: ListWeeklyDigests :many
+SELECT
+	guild_id,
+	channel_id,
+	post_channel_id,
+	weekday,
+	time_of_day,
+	timezone,
+	last_posted_at,
+	last_attempt_at,
+	failed_attempts
+FROM weekly_digests
+WHERE guild_id = $1
+ORDER BY channel_id ASC;
+
+
+-- name: ListAllWeeklyDigests :many
+SELECT
+	guild_id,
+	channel_id,
+	post_channel_id,
+	weekday,
+	time_of_day,
+	timezone,
+	last_posted_at,
+	last_attempt_at,
+	failed_attempts
+FROM weekly_digests
+ORDER BY guild_id ASC, channel_id ASC;
+
+
+-- name: SetWeeklyDigest :exec
+INSERT INTO weekly_digests (
+	guild_id,
+	channel_id,
+	post_channel_id,
+	weekday,
+	time_of_day,
+	timezone
+) VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (channel_id)
+DO UPDATE SET
+	post_channel_id = EXCLUDED.post_channel_id,
+	weekday = EXCLUDED.weekday,
+	time_of_day = EXCLUDED.time_of_day,
+	timezone = EXCLUDED.timezone;
+
+
+-- name: RemoveWeeklyDigest :exec
+DELETE FROM weekly_digests
+WHERE guild_id = $1
+AND channel_id = $2;
+
+
+-- name: MarkWeeklyDigestPosted :exec
+UPDATE weekly_digests
+SET
+	last_posted_at = $2,
+	last_attempt_at = $2,
+	failed_attempts = 0
+WHERE channel_id = $1;
+
+
+-- name: MarkWeeklyDigestFailed :exec
+UPDATE weekly_digests
+SET
+	last_attempt_at = $2,
+	failed_attempts = failed_attempts + 1
+WHERE channel_id = $1;
+
+
+-- name: GetDigestMaps :many
+SELECT
+	h.map,
+	count(*)::integer AS samples
+FROM server_history h
+WHERE h.address = ANY(sqlc.arg(addresses)::VARCHAR(64)[])
+AND h.online
+AND h.map <> ''
+AND h.timestamp >= sqlc.arg(since)
+AND h.timestamp < sqlc.arg(until)
+GROUP BY h.map
+ORDER BY samples DESC, h.map ASC;
+
+
+-- name: GetDigestPlaytimeLeaderboard :many
+SELECT
+	p.name,
+	p.clan,
+	sum(p.seconds)::bigint AS seconds
+FROM player_playtime p
+WHERE p.address = ANY(sqlc.arg(addresses)::VARCHAR(64)[])
+AND p.day >= sqlc.arg(since)::date
+AND p.day < sqlc.arg(until)::date
+GROUP BY p.name, p.clan
+ORDER BY seconds DESC, p.name ASC, p.clan ASC
+LIMIT sqlc.arg(max_entries);
+
+
+-- name: GetDigestPlayers :many
+SELECT
+	count(*) FILTER (WHERE p.first_joined_at >= sqlc.arg(since)::timestamptz)::integer AS new_players,
+	count(*) FILTER (WHERE p.first_joined_at < sqlc.arg(since)::timestamptz)::integer AS returning_players
+FROM (
+	SELECT
+		s.name,
+		min(s.joined_at) AS first_joined_at
+	FROM player_sessions s
+	WHERE s.address = ANY(sqlc.arg(addresses)::VARCHAR(64)[])
+	AND s.joined_at < sqlc.arg(until)::timestamptz
+	GROUP BY s.name
+	HAVING max(COALESCE(s.left_at, 'infinity'::timestamptz)) >= sqlc.arg(since)::timestamptz
+) p;
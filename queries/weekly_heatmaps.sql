@@ -0,0 +1,63 @@
+-- name: ListWeeklyHeatmaps :many
+SELECT
+	guild_id,
+	channel_id,
+	address,
+	post_channel_id,
+	last_posted_at
+FROM weekly_heatmaps
+WHERE guild_id = $1
+ORDER BY channel_id ASC, address ASC;
+
+
+-- name: ListAllWeeklyHeatmaps :many
+SELECT
+	w.guild_id,
+	w.channel_id,
+	w.address,
+	w.post_channel_id,
+	w.last_posted_at,
+	w.last_attempt_at,
+	w.failed_attempts,
+	COALESCE(gs.timezone, 'UTC')::text AS timezone
+FROM weekly_heatmaps w
+LEFT JOIN guild_settings gs ON gs.guild_id = w.guild_id
+ORDER BY w.guild_id ASC, w.channel_id ASC, w.address ASC;
+
+
+-- name: AddWeeklyHeatmap :exec
+INSERT INTO weekly_heatmaps (
+	guild_id,
+	channel_id,
+	address,
+	post_channel_id
+) VALUES ($1, $2, $3, $4)
+ON CONFLICT (channel_id, address)
+DO UPDATE SET
+	post_channel_id = EXCLUDED.post_channel_id;
+
+
+-- name: RemoveWeeklyHeatmap :exec
+DELETE FROM weekly_heatmaps
+WHERE guild_id = $1
+AND channel_id = $2
+AND address = $3;
+
+
+-- name: MarkWeeklyHeatmapPosted :exec
+UPDATE weekly_heatmaps
+SET
+	last_posted_at = $3,
+	last_attempt_at = $3,
+	failed_attempts = 0
+WHERE channel_id = $1
+AND address = $2;
+
+
+-- name: MarkWeeklyHeatmapFailed :exec
+UPDATE weekly_heatmaps
+SET
+	last_attempt_at = $3,
+	failed_attempts = failed_attempts + 1
+WHERE channel_id = $1
+AND address = $2;
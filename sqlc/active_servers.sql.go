@@ -12,6 +12,59 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const autocompleteServers = `-- name: AutocompleteServers :many
+SELECT
+	s.address,
+	s.name
+FROM active_servers s
+LEFT JOIN (
+	SELECT
+		address,
+		COUNT(*) AS num_clients
+	FROM active_server_clients
+	GROUP BY address
+) c ON c.address = s.address
+WHERE
+	strpos(s.address, $1) > 0 OR
+	strpos(lower(s.name), lower($1)) > 0
+ORDER BY
+	starts_with(s.address, $1) DESC,
+	COALESCE(c.num_clients, 0) DESC,
+	s.name ASC,
+	s.address ASC
+LIMIT $2
+`
+
+type AutocompleteServersParams struct {
+	Search     string `db:"search"`
+	MaxEntries int32  `db:"max_entries"`
+}
+
+type AutocompleteServersRow struct {
+	Address string `db:"address"`
+	Name    string `db:"name"`
+}
+
+func (q *Queries) AutocompleteServers(ctx context.Context, arg AutocompleteServersParams) ([]AutocompleteServersRow, error) {
+	rows, err := q.db.Query(ctx, autocompleteServers, arg.Search, arg.MaxEntries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AutocompleteServersRow{}
+	for rows.Next() {
+		var i AutocompleteServersRow
+		if err := rows.Scan(&i.Address, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const deleteActiveServerClients = `-- name: DeleteActiveServerClients :exec
 DELETE FROM active_server_clients
 `
@@ -58,6 +111,150 @@ func (q *Queries) ExistsServer(ctx context.Context, address string) ([]string, e
 	return items, nil
 }
 
+const findPlayers = `-- name: FindPlayers :many
+SELECT
+	s.address,
+	s.name AS server_name,
+	s.map,
+	s.score_kind,
+	c.name,
+	c.clan,
+	(CASE WHEN c.score = -9999 THEN 2147483647 ELSE c.score END)::INTEGER as score,
+	c.is_player,
+	c.team,
+	m.match
+FROM (
+	SELECT
+		id,
+		(CASE
+			WHEN lower(name) = lower($1) THEN 0
+			WHEN starts_with(lower(name), lower($1)) THEN 1
+			ELSE 2
+		END)::SMALLINT AS match,
+		similarity(name, $1)::REAL AS similarity
+	FROM active_server_clients
+	WHERE
+		starts_with(lower(name), lower($1)) OR
+		strpos(lower(name), lower($1)) > 0 OR
+		name % $1
+) m
+JOIN active_server_clients c ON c.id = m.id
+JOIN active_servers s ON s.address = c.address
+WHERE m.match <= $2
+ORDER BY
+	m.match ASC,
+	m.similarity DESC,
+	c.name ASC,
+	s.name ASC
+LIMIT $3
+`
+
+type FindPlayersParams struct {
+	Name       string `db:"name"`
+	MaxMatch   int16  `db:"max_match"`
+	MaxEntries int32  `db:"max_entries"`
+}
+
+type FindPlayersRow struct {
+	Address    string `db:"address"`
+	ServerName string `db:"server_name"`
+	Map        string `db:"map"`
+	ScoreKind  string `db:"score_kind"`
+	Name       string `db:"name"`
+	Clan       string `db:"clan"`
+	Score      int32  `db:"score"`
+	IsPlayer   bool   `db:"is_player"`
+	Team       *int16 `db:"team"`
+	Match      int16  `db:"match"`
+}
+
+func (q *Queries) FindPlayers(ctx context.Context, arg FindPlayersParams) ([]FindPlayersRow, error) {
+	rows, err := q.db.Query(ctx, findPlayers, arg.Name, arg.MaxMatch, arg.MaxEntries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FindPlayersRow{}
+	for rows.Next() {
+		var i FindPlayersRow
+		if err := rows.Scan(
+			&i.Address,
+			&i.ServerName,
+			&i.Map,
+			&i.ScoreKind,
+			&i.Name,
+			&i.Clan,
+			&i.Score,
+			&i.IsPlayer,
+			&i.Team,
+			&i.Match,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getActiveServer = `-- name: GetActiveServer :many
+SELECT
+	timestamp,
+	address,
+	protocols,
+	name,
+	gametype,
+	passworded,
+	map,
+	map_sha256sum,
+	map_size,
+	version,
+	max_clients,
+	max_players,
+	score_kind,
+	location
+FROM active_servers
+WHERE address = $1
+LIMIT 1
+`
+
+func (q *Queries) GetActiveServer(ctx context.Context, address string) ([]ActiveServer, error) {
+	rows, err := q.db.Query(ctx, getActiveServer, address)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ActiveServer{}
+	for rows.Next() {
+		var i ActiveServer
+		if err := rows.Scan(
+			&i.Timestamp,
+			&i.Address,
+			&i.Protocols,
+			&i.Name,
+			&i.Gametype,
+			&i.Passworded,
+			&i.Map,
+			&i.MapSha256sum,
+			&i.MapSize,
+			&i.Version,
+			&i.MaxClients,
+			&i.MaxPlayers,
+			&i.ScoreKind,
+			&i.Location,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 type InsertActiveServerClientsParams struct {
 	Address   string `db:"address"`
 	Name      string `db:"name"`
@@ -82,6 +279,75 @@ type InsertActiveServersParams struct {
 	MaxClients   int16              `db:"max_clients"`
 	MaxPlayers   int16              `db:"max_players"`
 	ScoreKind    string             `db:"score_kind"`
+	Location     string             `db:"location"`
+}
+
+const listActiveServerClients = `-- name: ListActiveServerClients :many
+SELECT
+	c.name,
+	c.clan,
+	c.country_id,
+	(CASE WHEN c.score = -9999 THEN 2147483647 ELSE c.score END)::INTEGER as score,
+	c.is_player,
+	c.team,
+	f.abbr,
+	COALESCE(fm.emoji, f.emoji)::VARCHAR(64) as flag_emoji
+FROM active_server_clients c
+JOIN flags f ON c.country_id = f.flag_id
+LEFT JOIN flag_mappings fm ON
+	(
+		fm.channel_id = $2 AND
+		c.country_id = fm.flag_id
+	)
+WHERE c.address = $1
+ORDER BY
+	score DESC,
+	c.name ASC
+`
+
+type ListActiveServerClientsParams struct {
+	Address   string `db:"address"`
+	ChannelID int64  `db:"channel_id"`
+}
+
+type ListActiveServerClientsRow struct {
+	Name      string `db:"name"`
+	Clan      string `db:"clan"`
+	CountryID int16  `db:"country_id"`
+	Score     int32  `db:"score"`
+	IsPlayer  bool   `db:"is_player"`
+	Team      *int16 `db:"team"`
+	Abbr      string `db:"abbr"`
+	FlagEmoji string `db:"flag_emoji"`
+}
+
+func (q *Queries) ListActiveServerClients(ctx context.Context, arg ListActiveServerClientsParams) ([]ListActiveServerClientsRow, error) {
+	rows, err := q.db.Query(ctx, listActiveServerClients, arg.Address, arg.ChannelID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListActiveServerClientsRow{}
+	for rows.Next() {
+		var i ListActiveServerClientsRow
+		if err := rows.Scan(
+			&i.Name,
+			&i.Clan,
+			&i.CountryID,
+			&i.Score,
+			&i.IsPlayer,
+			&i.Team,
+			&i.Abbr,
+			&i.FlagEmoji,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const listTrackedServerClients = `-- name: ListTrackedServerClients :many
@@ -241,3 +507,101 @@ func (q *Queries) ListTrackedServers(ctx context.Context) ([]ListTrackedServersR
 	}
 	return items, nil
 }
+
+const searchServers = `-- name: SearchServers :many
+SELECT
+	s.address,
+	s.name,
+	s.gametype,
+	s.passworded,
+	s.map,
+	s.version,
+	s.location,
+	s.max_clients,
+	s.max_players,
+	(COUNT(c.id) FILTER (WHERE c.is_player))::SMALLINT AS num_players,
+	COUNT(c.id)::SMALLINT AS num_clients
+FROM active_servers s
+LEFT JOIN active_server_clients c ON c.address = s.address
+WHERE
+	strpos(lower(s.name), lower($1)) > 0 AND
+	strpos(lower(s.gametype), lower($2)) > 0 AND
+	strpos(lower(s.map), lower($3)) > 0 AND
+	starts_with(s.version, $4) AND
+	starts_with(s.location, $5) AND
+	($6::BOOLEAN IS NULL OR s.passworded = $6)
+GROUP BY s.address
+HAVING COUNT(c.id) FILTER (WHERE c.is_player) >= $7
+ORDER BY
+	num_players DESC,
+	num_clients DESC,
+	s.name ASC,
+	s.address ASC
+LIMIT $8
+`
+
+type SearchServersParams struct {
+	Name       string `db:"name"`
+	Gametype   string `db:"gametype"`
+	Map        string `db:"map"`
+	Version    string `db:"version"`
+	Region     string `db:"region"`
+	Passworded *bool  `db:"passworded"`
+	MinPlayers int64  `db:"min_players"`
+	MaxEntries int32  `db:"max_entries"`
+}
+
+type SearchServersRow struct {
+	Address    string `db:"address"`
+	Name       string `db:"name"`
+	Gametype   string `db:"gametype"`
+	Passworded bool   `db:"passworded"`
+	Map        string `db:"map"`
+	Version    string `db:"version"`
+	Location   string `db:"location"`
+	MaxClients int16  `db:"max_clients"`
+	MaxPlayers int16  `db:"max_players"`
+	NumPlayers int16  `db:"num_players"`
+	NumClients int16  `db:"num_clients"`
+}
+
+func (q *Queries) SearchServers(ctx context.Context, arg SearchServersParams) ([]SearchServersRow, error) {
+	rows, err := q.db.Query(ctx, searchServers,
+		arg.Name,
+		arg.Gametype,
+		arg.Map,
+		arg.Version,
+		arg.Region,
+		arg.Passworded,
+		arg.MinPlayers,
+		arg.MaxEntries,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchServersRow{}
+	for rows.Next() {
+		var i SearchServersRow
+		if err := rows.Scan(
+			&i.Address,
+			&i.Name,
+			&i.Gametype,
+			&i.Passworded,
+			&i.Map,
+			&i.Version,
+			&i.Location,
+			&i.MaxClients,
+			&i.MaxPlayers,
+			&i.NumPlayers,
+			&i.NumClients,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
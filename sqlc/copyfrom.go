@@ -80,6 +80,7 @@ func (r iteratorForInsertActiveServers) Values() ([]interface{}, error) {
 		r.rows[0].MaxClients,
 		r.rows[0].MaxPlayers,
 		r.rows[0].ScoreKind,
+		r.rows[0].Location,
 	}, nil
 }
 
@@ -88,5 +89,5 @@ func (r iteratorForInsertActiveServers) Err() error {
 }
 
 func (q *Queries) InsertActiveServers(ctx context.Context, arg []InsertActiveServersParams) (int64, error) {
-	return q.db.CopyFrom(ctx, []string{"active_servers"}, []string{"timestamp", "address", "protocols", "name", "gametype", "passworded", "map", "map_sha256sum", "map_size", "version", "max_clients", "max_players", "score_kind"}, &iteratorForInsertActiveServers{rows: arg})
+	return q.db.CopyFrom(ctx, []string{"active_servers"}, []string{"timestamp", "address", "protocols", "name", "gametype", "passworded", "map", "map_sha256sum", "map_size", "version", "max_clients", "max_players", "score_kind", "location"}, &iteratorForInsertActiveServers{rows: arg})
 }
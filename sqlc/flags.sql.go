@@ -29,6 +29,41 @@ func (q *Queries) AddFlag(ctx context.Context, arg AddFlagParams) error {
 	return err
 }
 
+const autocompleteFlags = `-- name: AutocompleteFlags :many
+SELECT flag_id, abbr, emoji
+FROM flags
+WHERE strpos(abbr, lower($1)) > 0
+ORDER BY
+	starts_with(abbr, lower($1)) DESC,
+	abbr ASC
+LIMIT $2
+`
+
+type AutocompleteFlagsParams struct {
+	Search     string `db:"search"`
+	MaxEntries int32  `db:"max_entries"`
+}
+
+func (q *Queries) AutocompleteFlags(ctx context.Context, arg AutocompleteFlagsParams) ([]Flag, error) {
+	rows, err := q.db.Query(ctx, autocompleteFlags, arg.Search, arg.MaxEntries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Flag{}
+	for rows.Next() {
+		var i Flag
+		if err := rows.Scan(&i.FlagID, &i.Abbr, &i.Emoji); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getFlag = `-- name: GetFlag :many
 SELECT flag_id, abbr, emoji
 FROM flags
@@ -24,6 +24,7 @@ type ActiveServer struct {
 	MaxClients   int16              `db:"max_clients"`
 	MaxPlayers   int16              `db:"max_players"`
 	ScoreKind    string             `db:"score_kind"`
+	Location     string             `db:"location"`
 }
 
 type ActiveServerClient struct {
@@ -43,6 +44,19 @@ type Channel struct {
 	Running   bool  `db:"running"`
 }
 
+type DeferredPlayerCountNotification struct {
+	UserID    int64 `db:"user_id"`
+	MessageID int64 `db:"message_id"`
+}
+
+type DiscoveryRule struct {
+	ID             int64  `db:"id"`
+	GuildID        int64  `db:"guild_id"`
+	AlertChannelID int64  `db:"alert_channel_id"`
+	Name           string `db:"name"`
+	Gametype       string `db:"gametype"`
+}
+
 type Flag struct {
 	FlagID int16  `db:"flag_id"`
 	Abbr   string `db:"abbr"`
@@ -61,6 +75,28 @@ type Guild struct {
 	Description string `db:"description"`
 }
 
+type GuildSetting struct {
+	GuildID       int64   `db:"guild_id"`
+	Timezone      string  `db:"timezone"`
+	ManagerRoleID *int64  `db:"manager_role_id"`
+	Language      *string `db:"language"`
+}
+
+type KnownServer struct {
+	Address     string             `db:"address"`
+	FirstSeenAt pgtype.Timestamptz `db:"first_seen_at"`
+	LastSeenAt  pgtype.Timestamptz `db:"last_seen_at"`
+}
+
+type MapChangeSubscription struct {
+	GuildID   int64  `db:"guild_id"`
+	ChannelID int64  `db:"channel_id"`
+	MessageID int64  `db:"message_id"`
+	UserID    int64  `db:"user_id"`
+	Pattern   string `db:"pattern"`
+	Dm        bool   `db:"dm"`
+}
+
 type PlayerCountNotificationMessage struct {
 	ChannelID int64 `db:"channel_id"`
 	MessageID int64 `db:"message_id"`
@@ -74,6 +110,34 @@ type PlayerCountNotificationRequest struct {
 	Threshold int16 `db:"threshold"`
 }
 
+type PlayerCountRoleNotification struct {
+	GuildID        int64              `db:"guild_id"`
+	ChannelID      int64              `db:"channel_id"`
+	MessageID      int64              `db:"message_id"`
+	RoleID         int64              `db:"role_id"`
+	Threshold      int16              `db:"threshold"`
+	Cooldown       int32              `db:"cooldown"`
+	LastNotified   pgtype.Timestamptz `db:"last_notified"`
+	AboveThreshold bool               `db:"above_threshold"`
+}
+
+type PlayerPlaytime struct {
+	Address string      `db:"address"`
+	Name    string      `db:"name"`
+	Clan    string      `db:"clan"`
+	Day     pgtype.Date `db:"day"`
+	Seconds int32       `db:"seconds"`
+}
+
+type PlayerSession struct {
+	ID       int64              `db:"id"`
+	Address  string             `db:"address"`
+	Name     string             `db:"name"`
+	Clan     string             `db:"clan"`
+	JoinedAt pgtype.Timestamptz `db:"joined_at"`
+	LeftAt   pgtype.Timestamptz `db:"left_at"`
+}
+
 type PrevActiveServer struct {
 	MessageID    int64              `db:"message_id"`
 	GuildID      int64              `db:"guild_id"`
@@ -108,6 +172,55 @@ type PrevActiveServerClient struct {
 	FlagEmoji string `db:"flag_emoji"`
 }
 
+type ServerHistory struct {
+	Address       string             `db:"address"`
+	Timestamp     pgtype.Timestamptz `db:"timestamp"`
+	Online        bool               `db:"online"`
+	NumPlayers    int16              `db:"num_players"`
+	NumSpectators int16              `db:"num_spectators"`
+	MaxPlayers    int16              `db:"max_players"`
+	Map           string             `db:"map"`
+}
+
+type ServerHistoryRollup struct {
+	Address       string             `db:"address"`
+	BucketSize    int32              `db:"bucket_size"`
+	Bucket        pgtype.Timestamptz `db:"bucket"`
+	Samples       int32              `db:"samples"`
+	OnlineSamples int32              `db:"online_samples"`
+	AvgPlayers    float32            `db:"avg_players"`
+	PeakPlayers   int16              `db:"peak_players"`
+	AvgSpectators float32            `db:"avg_spectators"`
+}
+
+type ServerStatusAlert struct {
+	MessageID      int64  `db:"message_id"`
+	GuildID        int64  `db:"guild_id"`
+	ChannelID      int64  `db:"channel_id"`
+	AlertChannelID *int64 `db:"alert_channel_id"`
+	AlertUserID    *int64 `db:"alert_user_id"`
+	Debounce       int32  `db:"debounce"`
+}
+
+type ServerStatusAlertState struct {
+	MessageID    int64              `db:"message_id"`
+	Name         string             `db:"name"`
+	Map          string             `db:"map"`
+	NumPlayers   int16              `db:"num_players"`
+	MaxPlayers   int16              `db:"max_players"`
+	OfflineSince pgtype.Timestamptz `db:"offline_since"`
+	Alerted      bool               `db:"alerted"`
+}
+
+type TopServersTracking struct {
+	MessageID int64  `db:"message_id"`
+	GuildID   int64  `db:"guild_id"`
+	ChannelID int64  `db:"channel_id"`
+	Gametype  string `db:"gametype"`
+	Count     int16  `db:"count"`
+	Ranking   string `db:"ranking"`
+}
+
 type Tracking struct {
 	ID        *int64 `db:"id"`
 	MessageID int64  `db:"message_id"`
@@ -115,3 +228,51 @@ type Tracking struct {
 	ChannelID int64  `db:"channel_id"`
 	Address   string `db:"address"`
 }
+
+type UserNotificationSetting struct {
+	UserID     int64  `db:"user_id"`
+	Timezone   string `db:"timezone"`
+	QuietStart int16  `db:"quiet_start"`
+	QuietEnd   int16  `db:"quiet_end"`
+	QuietDays  int16  `db:"quiet_days"`
+}
+
+type Webhook struct {
+	WebhookID int64    `db:"webhook_id"`
+	GuildID   int64    `db:"guild_id"`
+	Url       string   `db:"url"`
+	Secret    string   `db:"secret"`
+	Events    []string `db:"events"`
+}
+
+type WebhookDelivery struct {
+	DeliveryID  int64              `db:"delivery_id"`
+	WebhookID   int64              `db:"webhook_id"`
+	Event       string             `db:"event"`
+	StatusCode  int32              `db:"status_code"`
+	Attempts    int32              `db:"attempts"`
+	Error       string             `db:"error"`
+	DeliveredAt pgtype.Timestamptz `db:"delivered_at"`
+}
+
+type WeeklyDigest struct {
+	GuildID        int64              `db:"guild_id"`
+	ChannelID      int64              `db:"channel_id"`
+	PostChannelID  int64              `db:"post_channel_id"`
+	Weekday        int16              `db:"weekday"`
+	TimeOfDay      int16              `db:"time_of_day"`
+	Timezone       string             `db:"timezone"`
+	LastPostedAt   pgtype.Timestamptz `db:"last_posted_at"`
+	LastAttemptAt  pgtype.Timestamptz `db:"last_attempt_at"`
+	FailedAttempts int32              `db:"failed_attempts"`
+}
+
+type WeeklyHeatmap struct {
+	GuildID        int64              `db:"guild_id"`
+	ChannelID      int64              `db:"channel_id"`
+	Address        string             `db:"address"`
+	PostChannelID  int64              `db:"post_channel_id"`
+	LastPostedAt   pgtype.Timestamptz `db:"last_posted_at"`
+	LastAttemptAt  pgtype.Timestamptz `db:"last_attempt_at"`
+	FailedAttempts int32              `db:"failed_attempts"`
+}
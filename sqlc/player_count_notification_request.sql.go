@@ -90,15 +90,6 @@ func (q *Queries) RemovePlayerCountNotificationRequest(ctx context.Context, arg
 	return err
 }
 
-const removePlayerCountNotificationRequests = `-- name: RemovePlayerCountNotificationRequests :exec
-DELETE FROM player_count_notification_requests
-`
-
-func (q *Queries) RemovePlayerCountNotificationRequests(ctx context.Context) error {
-	_, err := q.db.Exec(ctx, removePlayerCountNotificationRequests)
-	return err
-}
-
 const setPlayerCountNotificationRequest = `-- name: SetPlayerCountNotificationRequest :exec
 INSERT INTO player_count_notification_requests (
 	guild_id,
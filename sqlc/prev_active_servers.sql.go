@@ -137,7 +137,6 @@ SELECT
 FROM prev_active_server_clients
 WHERE message_id = $1
 ORDER BY id ASC
-LIMIT 1
 `
 
 type GetPrevActiveServerClientsRow struct {
@@ -35,6 +35,53 @@ func (q *Queries) AddTracking(ctx context.Context, arg AddTrackingParams) error
 	return err
 }
 
+const getTrackingByAddress = `-- name: GetTrackingByAddress :many
+SELECT guild_id, channel_id, address, message_id
+FROM tracking
+WHERE guild_id = $1
+AND channel_id = $2
+AND address = $3
+LIMIT 1
+`
+
+type GetTrackingByAddressParams struct {
+	GuildID   int64  `db:"guild_id"`
+	ChannelID int64  `db:"channel_id"`
+	Address   string `db:"address"`
+}
+
+type GetTrackingByAddressRow struct {
+	GuildID   int64  `db:"guild_id"`
+	ChannelID int64  `db:"channel_id"`
+	Address   string `db:"address"`
+	MessageID int64  `db:"message_id"`
+}
+
+func (q *Queries) GetTrackingByAddress(ctx context.Context, arg GetTrackingByAddressParams) ([]GetTrackingByAddressRow, error) {
+	rows, err := q.db.Query(ctx, getTrackingByAddress, arg.GuildID, arg.ChannelID, arg.Address)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTrackingByAddressRow{}
+	for rows.Next() {
+		var i GetTrackingByAddressRow
+		if err := rows.Scan(
+			&i.GuildID,
+			&i.ChannelID,
+			&i.Address,
+			&i.MessageID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listAllTrackings = `-- name: ListAllTrackings :many
 SELECT guild_id, channel_id, address, message_id
 FROM tracking
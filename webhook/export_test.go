@@ -0,0 +1,15 @@
+package webhook
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+// NewTestClient returns a client that is allowed to deliver to the local tls test server.
+func NewTestClient(srv *httptest.Server, retries int, wait, maxWait time.Duration) *Client {
+	c := newClient(retries, wait, maxWait, func(net.IP) error { return nil })
+	c.c.SetTLSClientConfig(srv.Client().Transport.(*http.Transport).TLSClientConfig)
+	return c
+}
@@ -0,0 +1,192 @@
+// Package webhook delivers signed JSON payloads to outbound http endpoints.
+//
+// Every request contains the event type, the unix timestamp of the delivery and
+// a hex encoded HMAC-SHA256 signature of "<timestamp>.<body>" that is keyed
+// with the secret of the webhook.
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	HeaderEvent     = "X-Twstatus-Event"
+	HeaderTimestamp = "X-Twstatus-Timestamp"
+	HeaderSignature = "X-Twstatus-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+// Sign returns the signature header value of the body.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature header value of the body in constant time.
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// NewSecret generates a random hex encoded secret.
+func NewSecret() (string, error) {
+	buf := make([]byte, 32)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+var (
+	ErrInvalidURL   = errors.New("invalid webhook url, expected an https url")
+	ErrForbiddenURL = errors.New("webhook url must not point to a loopback, private, link-local or unspecified address")
+)
+
+// ParseURL parses the url of a webhook and checks that it can be delivered to.
+// Host names are resolved at delivery time and checked again when connecting.
+func ParseURL(raw string) (*url.URL, error) {
+	return parseURL(raw, checkIP)
+}
+
+func parseURL(raw string, check func(net.IP) error) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidURL, raw)
+	}
+	if ip := net.ParseIP(u.Hostname()); ip != nil {
+		err = check(ip)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return u, nil
+}
+
+func checkIP(ip net.IP) error {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+		return fmt.Errorf("%w: %s", ErrForbiddenURL, ip)
+	}
+	return nil
+}
+
+type Client struct {
+	c     *resty.Client
+	check func(net.IP) error
+}
+
+// NewClient creates a client that retries failed deliveries with an exponential backoff
+// between wait and maxWait. Deliveries are retried on network errors,
+// http status 429 and 5xx status codes.
+// Redirects are not followed and connections to loopback, private, link-local and
+// unspecified addresses are refused after the host name was resolved.
+func NewClient(retries int, wait, maxWait time.Duration) *Client {
+	return newClient(retries, wait, maxWait, checkIP)
+}
+
+func newClient(retries int, wait, maxWait time.Duration, check func(net.IP) error) *Client {
+	dialer := &net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+		// the address is already resolved at this point, which prevents dns rebinding
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil {
+				return fmt.Errorf("%w: %s", ErrForbiddenURL, host)
+			}
+			return check(ip)
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would connect to the target instead of us
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	c := resty.New().
+		SetTransport(transport).
+		SetRedirectPolicy(resty.RedirectPolicyFunc(func(*http.Request, []*http.Request) error {
+			// the redirect response is returned as is and treated as failed delivery
+			return http.ErrUseLastResponse
+		})).
+		SetHeader("Content-Type", "application/json").
+		SetHeader("User-Agent", "twstatus-bot").
+		SetTimeout(10 * time.Second).
+		SetRetryCount(retries).
+		SetRetryWaitTime(wait).
+		SetRetryMaxWaitTime(maxWait).
+		AddRetryCondition(func(r *resty.Response, err error) bool {
+			if err != nil {
+				return !errors.Is(err, ErrForbiddenURL)
+			}
+			return r.StatusCode() == http.StatusTooManyRequests || r.StatusCode() >= 500
+		})
+	return &Client{c: c, check: check}
+}
+
+// Result of a delivery, the status code is 0 if no response was received.
+type Result struct {
+	StatusCode int
+	Attempts   int
+}
+
+// Send posts the JSON encoded payload to the url.
+// An error is returned in case the endpoint did not respond with a 2xx status code
+// after all retries.
+func (c *Client) Send(ctx context.Context, url, secret, event string, payload any) (Result, error) {
+	u, err := parseURL(url, c.check)
+	if err != nil {
+		return Result{}, err
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	timestamp := time.Now().Unix()
+	resp, err := c.c.R().
+		SetContext(ctx).
+		SetHeader(HeaderEvent, event).
+		SetHeader(HeaderTimestamp, strconv.FormatInt(timestamp, 10)).
+		SetHeader(HeaderSignature, Sign(secret, timestamp, body)).
+		SetBody(body).
+		Post(u.String())
+
+	var result Result
+	if resp != nil {
+		result.StatusCode = resp.StatusCode()
+		if resp.Request != nil {
+			result.Attempts = resp.Request.Attempt
+		}
+	}
+	if err != nil {
+		return result, fmt.Errorf("failed to deliver webhook event %s: %w", event, err)
+	}
+	if resp.IsError() || result.StatusCode >= 300 {
+		return result, fmt.Errorf("failed to deliver webhook event %s: %s", event, resp.Status())
+	}
+	return result, nil
+}
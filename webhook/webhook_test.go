@@ -0,0 +1,135 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jxsl13/twstatus-bot/webhook"
+	"github.com/stretchr/testify/require"
+)
+
+type payload struct {
+	Type    string `json:"type"`
+	Address string `json:"address"`
+}
+
+func TestSendSignedWithRetries(t *testing.T) {
+	const secret = "secret"
+	var calls atomic.Int32
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		timestamp, err := strconv.ParseInt(r.Header.Get(webhook.HeaderTimestamp), 10, 64)
+		require.NoError(t, err)
+		require.Equal(t, "server.online", r.Header.Get(webhook.HeaderEvent))
+		require.True(t, webhook.Verify(secret, timestamp, body, r.Header.Get(webhook.HeaderSignature)))
+		require.False(t, webhook.Verify("other", timestamp, body, r.Header.Get(webhook.HeaderSignature)))
+		require.JSONEq(t, `{"type":"server.online","address":"127.0.0.1:8303"}`, string(body))
+
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := webhook.NewTestClient(srv, 3, time.Millisecond, 5*time.Millisecond)
+	result, err := c.Send(context.Background(), srv.URL, secret, "server.online", payload{
+		Type:    "server.online",
+		Address: "127.0.0.1:8303",
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, result.StatusCode)
+	require.Equal(t, 3, result.Attempts)
+	require.Equal(t, int32(3), calls.Load())
+}
+
+func TestSendFailsAfterRetries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	c := webhook.NewTestClient(srv, 2, time.Millisecond, 5*time.Millisecond)
+	result, err := c.Send(context.Background(), srv.URL, "secret", "server.offline", payload{})
+	require.Error(t, err)
+	require.Equal(t, http.StatusInternalServerError, result.StatusCode)
+	require.Equal(t, 3, result.Attempts)
+	require.Equal(t, int32(3), calls.Load())
+
+	// client errors are not retried
+	calls.Store(0)
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	})
+	result, err = c.Send(context.Background(), srv.URL, "secret", "server.offline", payload{})
+	require.Error(t, err)
+	require.Equal(t, http.StatusNotFound, result.StatusCode)
+	require.Equal(t, int32(1), calls.Load())
+}
+
+func TestParseURL(t *testing.T) {
+	u, err := webhook.ParseURL("https://example.com/hook")
+	require.NoError(t, err)
+	require.Equal(t, "example.com", u.Host)
+
+	for _, raw := range []string{"http://example.com/hook", "ftp://example.com", "https://", "example.com"} {
+		_, err = webhook.ParseURL(raw)
+		require.ErrorIs(t, err, webhook.ErrInvalidURL, raw)
+	}
+
+	for _, raw := range []string{
+		"https://127.0.0.1/hook",
+		"https://[::1]:8080/hook",
+		"https://10.0.0.1/hook",
+		"https://192.168.1.1/hook",
+		"https://169.254.169.254/latest/meta-data",
+		"https://0.0.0.0/hook",
+	} {
+		_, err = webhook.ParseURL(raw)
+		require.ErrorIs(t, err, webhook.ErrForbiddenURL, raw)
+	}
+}
+
+func TestSendRefusesLocalAddresses(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	// host names are checked after they were resolved
+	c := webhook.NewClient(2, time.Millisecond, 5*time.Millisecond)
+	_, err := c.Send(context.Background(), strings.Replace(srv.URL, "127.0.0.1", "localhost", 1), "secret", "server.online", payload{})
+	require.ErrorIs(t, err, webhook.ErrForbiddenURL)
+	require.Equal(t, int32(0), calls.Load())
+}
+
+func TestSendDoesNotFollowRedirects(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Redirect(w, r, "https://169.254.169.254/latest/meta-data", http.StatusTemporaryRedirect)
+	}))
+	defer srv.Close()
+
+	c := webhook.NewTestClient(srv, 2, time.Millisecond, 5*time.Millisecond)
+	result, err := c.Send(context.Background(), srv.URL, "secret", "server.online", payload{})
+	require.Error(t, err)
+	require.Equal(t, http.StatusTemporaryRedirect, result.StatusCode)
+	require.Equal(t, int32(1), calls.Load())
+}